@@ -3,6 +3,7 @@ module github.com/ethereum-optimism/optimism
 go 1.19
 
 require (
+	github.com/andybalholm/brotli v1.1.0
 	github.com/btcsuite/btcd v0.23.3
 	github.com/btcsuite/btcd/chaincfg/chainhash v1.0.1
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.1.0
@@ -0,0 +1,103 @@
+package batcher
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// runInLoop runs fn in the driver loop, which owns the state of the batcher, and waits for it to return.
+func (l *BatchSubmitter) runInLoop(ctx context.Context, fn func()) error {
+	l.mutex.Lock()
+	running, shutdownCtx := l.running, l.shutdownCtx
+	l.mutex.Unlock()
+	if !running {
+		return ErrBatcherNotRunning
+	}
+
+	done := make(chan struct{})
+	req := func() {
+		defer close(done)
+		fn()
+	}
+	select {
+	case l.adminCh <- req:
+	case <-shutdownCtx.Done():
+		return ErrBatcherNotRunning
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PendingBlocks returns the loaded blocks that are not proposed yet.
+func (l *BatchSubmitter) PendingBlocks(ctx context.Context) ([]eth.BlockID, error) {
+	var ids []eth.BlockID
+	err := l.runInLoop(ctx, func() {
+		ids = l.state.PendingBlockIDs()
+	})
+	return ids, err
+}
+
+// InflightTxs returns the proposed blocks whose propose transactions are not confirmed yet,
+// ordered by block number.
+func (l *BatchSubmitter) InflightTxs(ctx context.Context) ([]rpc.InflightTx, error) {
+	var txs []rpc.InflightTx
+	err := l.runInLoop(ctx, func() {
+		for _, tx := range l.inflightTxs {
+			if hash, ok := l.txMgr.LatestTxHash(uint64(tx.Nonce)); ok {
+				tx.TxHash = hash
+			}
+			txs = append(txs, tx)
+		}
+	})
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Block.Number < txs[j].Block.Number })
+	return txs, err
+}
+
+// DropBefore drops the pending blocks below the given block number, so that they are not proposed,
+// and returns how many blocks were dropped.
+func (l *BatchSubmitter) DropBefore(ctx context.Context, number uint64) (int, error) {
+	var dropped int
+	err := l.runInLoop(ctx, func() {
+		dropped = l.state.DropBefore(number)
+		l.metr.RecordBacklogBlocks(l.state.PendingBlocks())
+	})
+	return dropped, err
+}
+
+// Resubmit proposes the confirmed block with the given number again.
+func (l *BatchSubmitter) Resubmit(ctx context.Context, number uint64) error {
+	var resubmitErr error
+	if err := l.runInLoop(ctx, func() {
+		resubmitErr = l.state.Resubmit(number)
+	}); err != nil {
+		return err
+	}
+	return resubmitErr
+}
+
+// recordInflightTx records the propose transaction of the given blocks, until its receipt is handled.
+// Gas price bumps change the hash, so InflightTxs reports the hash of the latest bump.
+func (l *BatchSubmitter) recordInflightTx(datas []plainTxData, tx *types.Transaction) {
+	for _, data := range datas {
+		number := data.id.Uint64()
+		l.inflightTxs[number] = rpc.InflightTx{
+			Block:  eth.BlockID{Hash: data.blockHash, Number: number},
+			TxHash: tx.Hash(),
+			From:   l.TxManager.From(),
+			Nonce:  hexutil.Uint64(tx.Nonce()),
+			Gas:    hexutil.Uint64(tx.Gas()),
+		}
+	}
+}
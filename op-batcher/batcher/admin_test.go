@@ -0,0 +1,72 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+// TestInflightTxs checks that the propose transactions are reported in flight with the hash of their latest
+// gas price bump, until their receipts are handled.
+func TestInflightTxs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlCrit)
+	from := common.Address{0xaa}
+	txMgr := mocks.NewTxManager(t)
+	txMgr.On("From").Return(from)
+	l := &BatchSubmitter{
+		Config:      Config{log: logger, metr: metrics.NoopMetrics, TxManager: txMgr},
+		txMgr:       txMgr,
+		state:       newPlainBlockdataManager(logger, derive.PayloadVersionUncompressed, nil),
+		adminCh:     make(chan func()),
+		inflightTxs: make(map[uint64]rpc.InflightTx),
+	}
+	blocks := randomL2Chain(rng, 2)
+	var datas []plainTxData
+	for _, b := range blocks {
+		require.NoError(t, l.state.AddL2Block(b))
+		datas = append(datas, requireTxData(t, l.state, eth.BlockID{}, b))
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 7, Gas: 50_000})
+	l.inflight++
+	l.recordInflightTx(datas, tx)
+
+	bumped := common.Hash{0xbb}
+	txMgr.On("LatestTxHash", uint64(7)).Return(bumped, true)
+
+	expected := []rpc.InflightTx{
+		{Block: eth.ToBlockID(blocks[0]), TxHash: bumped, From: from, Nonce: 7, Gas: 50_000},
+		{Block: eth.ToBlockID(blocks[1]), TxHash: bumped, From: from, Nonce: 7, Gas: 50_000},
+	}
+	// serve the admin requests like the driver loop
+	l.running = true
+	l.shutdownCtx = context.Background()
+	go func() {
+		for fn := range l.adminCh {
+			fn()
+		}
+	}()
+	defer close(l.adminCh)
+	txs, err := l.InflightTxs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, txs)
+
+	l.handleReceipt(txmgr.TxReceipt[[]big.Int]{ID: []big.Int{datas[0].id, datas[1].id}, Receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: common.Hash{0x01}, BlockHash: common.Hash{0x02}, BlockNumber: big.NewInt(101)}})
+	txs, err = l.InflightTxs(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, txs)
+}
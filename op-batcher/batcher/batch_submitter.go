@@ -82,12 +82,28 @@ func Main(version string, cliCtx *cli.Context) error {
 	}
 
 	rpcCfg := cfg.RPCConfig
+	serverOpts := []oprpc.ServerOption{oprpc.WithLogger(l)}
+	if rpcCfg.EnableSeqsy {
+		secret, err := rpc.ReadJWTSecret(rpcCfg.SeqsyJWTSecret)
+		if err != nil {
+			cancel()
+			return err
+		}
+		serverOpts = append(serverOpts, oprpc.WithJWTSecret(secret))
+	}
 	server := oprpc.NewServer(
 		rpcCfg.ListenAddr,
 		rpcCfg.ListenPort,
 		version,
-		oprpc.WithLogger(l),
+		serverOpts...,
 	)
+	if rpcCfg.EnableSeqsy {
+		server.AddAPI(gethrpc.API{
+			Namespace: "seqsy",
+			Service:   rpc.NewSeqsyAPI(batchSubmitter),
+		})
+		l.Info("Seqsy RPC enabled")
+	}
 	if rpcCfg.EnableAdmin {
 		server.AddAPI(gethrpc.API{
 			Namespace: "admin",
@@ -1,6 +1,8 @@
 package batcher
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -8,9 +10,11 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/journal"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -25,9 +29,24 @@ type Config struct {
 	L2Client   *ethclient.Client
 	RollupNode *sources.RollupClient
 	TxManager  txmgr.TxManager
+	// Journal of the proposed blocks, optional
+	Journal journal.Journal
 
 	NetworkTimeout time.Duration
 	PollInterval   time.Duration
+	// L1FinalityDepth is the number of L1 blocks after which a confirmed propose
+	// transaction is no longer watched for L1 reorgs.
+	L1FinalityDepth uint64
+	// MempoolTimeout is how long the batcher waits at startup for the transactions of the previous
+	// run to leave the mempool, before it replaces the ones that are stuck.
+	MempoolTimeout time.Duration
+	// MaxPendingTransactions is the maximum number of propose transactions in flight at the same time.
+	MaxPendingTransactions uint64
+	// ProposeRange packs contiguous L2 blocks into propose range calls of at most MaxL1TxSize bytes.
+	ProposeRange bool
+	MaxL1TxSize  uint64
+	// Compression is the payload version of the proposed blocks, which selects the compression of their batch.
+	Compression byte
 
 	// RollupConfig is queried at startup
 	Rollup *rollup.Config
@@ -44,6 +63,15 @@ func (c *Config) Check() error {
 	if err := c.Channel.Check(); err != nil {
 		return err
 	}
+	if c.MaxPendingTransactions == 0 {
+		return errors.New("max pending transactions must be at least 1")
+	}
+	if c.ProposeRange && !c.Rollup.ProposeRangeEnabled() {
+		return errors.New("the rollup does not accept propose range calls")
+	}
+	if _, err := derive.EncodeBlockPayload(c.Compression, nil); err != nil {
+		return fmt.Errorf("invalid compression: %w", err)
+	}
 	return nil
 }
 
@@ -91,6 +119,35 @@ type CLIConfig struct {
 
 	Stopped bool
 
+	// JournalDir is the directory of the on-disk journal of proposed blocks,
+	// which lets the batcher resume without duplicate proposals after a restart.
+	// If empty, the journal is disabled.
+	JournalDir string
+
+	// L1FinalityDepth is the number of L1 blocks after which the inclusion of a
+	// propose transaction is considered final. Until then, the batcher proposes
+	// the block again if the inclusion block is reorged out.
+	L1FinalityDepth uint64
+
+	// MempoolTimeout is how long the batcher waits at startup for the propose
+	// transactions of the previous run to be included. Transactions that are
+	// still pending after it are replaced by no-op transactions.
+	MempoolTimeout time.Duration
+
+	// MaxPendingTransactions is the maximum number of propose transactions that
+	// are sent before the previous ones are confirmed. Their nonces are consecutive,
+	// so that they are included in the order of the blocks.
+	MaxPendingTransactions uint64
+
+	// ProposeRange makes the batcher propose contiguous L2 blocks together in a
+	// single propose range call, of at most MaxL1TxSize bytes, to save L1 gas.
+	ProposeRange bool
+
+	// Compression is the compression of the batches of the proposed blocks:
+	// none, zlib or brotli. The achieved compression ratio is reported against
+	// ApproxComprRatio.
+	Compression string
+
 	TxMgrConfig   txmgr.CLIConfig
 	RPCConfig     rpc.CLIConfig
 	LogConfig     oplog.CLIConfig
@@ -114,6 +171,9 @@ func (c CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if _, err := derive.ParsePayloadVersion(c.Compression); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -128,16 +188,22 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		PollInterval:    ctx.GlobalDuration(flags.PollIntervalFlag.Name),
 
 		/* Optional Flags */
-		MaxChannelDuration: ctx.GlobalUint64(flags.MaxChannelDurationFlag.Name),
-		MaxL1TxSize:        ctx.GlobalUint64(flags.MaxL1TxSizeBytesFlag.Name),
-		TargetL1TxSize:     ctx.GlobalUint64(flags.TargetL1TxSizeBytesFlag.Name),
-		TargetNumFrames:    ctx.GlobalInt(flags.TargetNumFramesFlag.Name),
-		ApproxComprRatio:   ctx.GlobalFloat64(flags.ApproxComprRatioFlag.Name),
-		Stopped:            ctx.GlobalBool(flags.StoppedFlag.Name),
-		TxMgrConfig:        txmgr.ReadCLIConfig(ctx),
-		RPCConfig:          rpc.ReadCLIConfig(ctx),
-		LogConfig:          oplog.ReadCLIConfig(ctx),
-		MetricsConfig:      opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:        oppprof.ReadCLIConfig(ctx),
+		MaxChannelDuration:     ctx.GlobalUint64(flags.MaxChannelDurationFlag.Name),
+		MaxL1TxSize:            ctx.GlobalUint64(flags.MaxL1TxSizeBytesFlag.Name),
+		TargetL1TxSize:         ctx.GlobalUint64(flags.TargetL1TxSizeBytesFlag.Name),
+		TargetNumFrames:        ctx.GlobalInt(flags.TargetNumFramesFlag.Name),
+		ApproxComprRatio:       ctx.GlobalFloat64(flags.ApproxComprRatioFlag.Name),
+		Stopped:                ctx.GlobalBool(flags.StoppedFlag.Name),
+		JournalDir:             ctx.GlobalString(flags.JournalDirFlag.Name),
+		L1FinalityDepth:        ctx.GlobalUint64(flags.L1FinalityDepthFlag.Name),
+		MempoolTimeout:         ctx.GlobalDuration(flags.MempoolTimeoutFlag.Name),
+		MaxPendingTransactions: ctx.GlobalUint64(flags.MaxPendingTransactionsFlag.Name),
+		ProposeRange:           ctx.GlobalBool(flags.ProposeRangeFlag.Name),
+		Compression:            ctx.GlobalString(flags.CompressionFlag.Name),
+		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
+		RPCConfig:              rpc.ReadCLIConfig(ctx),
+		LogConfig:              oplog.ReadCLIConfig(ctx),
+		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:            oppprof.ReadCLIConfig(ctx),
 	}
 }
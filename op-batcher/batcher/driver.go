@@ -4,17 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/ethereum/go-ethereum/common"
-"io"
+	"io"
 	"math/big"
 	_ "net/http/pprof"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/journal"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -26,6 +29,8 @@ type BatchSubmitter struct {
 	Config // directly embed the config + sources
 
 	txMgr txmgr.TxManager
+	// queue sends the propose transactions, identified by the numbers of the proposed blocks.
+	queue *txmgr.Queue[[]big.Int]
 	wg    sync.WaitGroup
 
 	shutdownCtx       context.Context
@@ -38,12 +43,39 @@ type BatchSubmitter struct {
 
 	// lastStoredBlock is the last block loaded into `state`. If it is empty it should be set to the l2 safe head.
 	lastStoredBlock eth.BlockID
-	lastL1Tip       eth.L1BlockRef
+	// submittedTip is the last block that an external sequencer pushed, until the L2 safe head reaches it.
+	// While it is set, the stored blocks are kept when the local L2 chain does not extend them.
+	submittedTip eth.BlockID
+	lastL1Tip    eth.L1BlockRef
+
+	// verifiedL1Tip is the last L1 tip that the inclusion blocks of the confirmed
+	// transactions in verifiedInclusions were checked against.
+	verifiedL1Tip      eth.L1BlockRef
+	verifiedInclusions map[eth.BlockID]struct{}
+
+	// inflight is the number of propose transactions sent and not yet confirmed or failed.
+	inflight int
+	// sendPausedUntil is the time until which the circuit breaker of the txmgr pauses sending.
+	sendPausedUntil time.Time
 
 	// NOTE(norswap) changed
 	state *plainBlockdataManager
+
+	// submitCh passes the blocks that external sequencers push to the driver loop.
+	submitCh chan submittedBlock
+	// adminCh passes the requests of the admin API to the driver loop.
+	adminCh chan func()
+	// inflightTxs are the propose transactions in flight, by proposed block number.
+	inflightTxs map[uint64]rpc.InflightTx
 }
 
+// ErrProposeReverted is the error of a propose transaction that was included but reverted by the data stream contract.
+var ErrProposeReverted = errors.New("propose transaction reverted")
+
+// proposeExecutionGas is the gas that the data stream contract spends on a propose call, on top of the
+// intrinsic gas. Storing the latest block number of a chain for the first time takes about 22.4k gas.
+const proposeExecutionGas = 30_000
+
 // NewBatchSubmitterFromCLIConfig initializes the BatchSubmitter, gathering any resources
 // that will be needed during operation.
 func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*BatchSubmitter, error) {
@@ -71,19 +103,42 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 		return nil, fmt.Errorf("querying rollup config: %w", err)
 	}
 
-	txManager, err := txmgr.NewSimpleTxManager("batcher", l, m, cfg.TxMgrConfig)
+	// the proposals are pipelined, a timed out proposal that is still pending keeps its nonce
+	txMgrConfig := cfg.TxMgrConfig
+	txMgrConfig.KeepPendingAfterSendTimeout = true
+	txManager, err := txmgr.NewSimpleTxManager("batcher", l, m, txMgrConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	compression, err := derive.ParsePayloadVersion(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	var j journal.Journal
+	if cfg.JournalDir != "" {
+		if err := os.MkdirAll(cfg.JournalDir, 0755); err != nil {
+			return nil, fmt.Errorf("creating journal dir: %w", err)
+		}
+		j = journal.NewDiskJournal(cfg.JournalDir)
+	}
+
 	batcherCfg := Config{
-		L1Client:       l1Client,
-		L2Client:       l2Client,
-		RollupNode:     rollupClient,
-		PollInterval:   cfg.PollInterval,
-		NetworkTimeout: cfg.TxMgrConfig.NetworkTimeout,
-		TxManager:      txManager,
-		Rollup:         rcfg,
+		L1Client:               l1Client,
+		L2Client:               l2Client,
+		RollupNode:             rollupClient,
+		PollInterval:           cfg.PollInterval,
+		NetworkTimeout:         cfg.TxMgrConfig.NetworkTimeout,
+		L1FinalityDepth:        cfg.L1FinalityDepth,
+		MempoolTimeout:         cfg.MempoolTimeout,
+		MaxPendingTransactions: cfg.MaxPendingTransactions,
+		ProposeRange:           cfg.ProposeRange,
+		MaxL1TxSize:            cfg.MaxL1TxSize,
+		Compression:            compression,
+		TxManager:              txManager,
+		Journal:                j,
+		Rollup:                 rcfg,
 		Channel: ChannelConfig{
 			SeqWindowSize:      rcfg.SeqWindowSize,
 			ChannelTimeout:     rcfg.ChannelTimeout,
@@ -118,9 +173,12 @@ func NewBatchSubmitter(ctx context.Context, cfg Config, l log.Logger, m metrics.
 	cfg.metr = m
 
 	return &BatchSubmitter{
-		Config: cfg,
-		txMgr:  cfg.TxManager,
-		state:  newPlainBlockdataManager(l),
+		Config:      cfg,
+		txMgr:       cfg.TxManager,
+		state:       newPlainBlockdataManager(l, cfg.Compression, cfg.Journal),
+		submitCh:    make(chan submittedBlock),
+		adminCh:     make(chan func()),
+		inflightTxs: make(map[uint64]rpc.InflightTx),
 	}, nil
 
 }
@@ -138,8 +196,10 @@ func (l *BatchSubmitter) Start() error {
 
 	l.shutdownCtx, l.cancelShutdownCtx = context.WithCancel(context.Background())
 	l.killCtx, l.cancelKillCtx = context.WithCancel(context.Background())
+	l.queue = txmgr.NewQueue[[]big.Int](l.killCtx, l.txMgr, l.MaxPendingTransactions)
 	l.state.Clear()
 	l.lastStoredBlock = eth.BlockID{}
+	l.submittedTip = eth.BlockID{}
 
 	l.wg.Add(1)
 	go l.loop()
@@ -197,11 +257,16 @@ func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context) {
 		return
 	}
 
+	prevInputBytes, prevOutputBytes := l.state.CompressedBytes()
 	var latestBlock *types.Block
 	// Add all blocks to "state"
 	for i := start.Number + 1; i < end.Number+1; i++ {
 		block, err := l.loadBlockIntoState(ctx, i)
-		if errors.Is(err, ErrReorg) {
+		if errors.Is(err, ErrReorg) && l.submittedTip != (eth.BlockID{}) {
+			// the local node does not have the submitted blocks yet, it picks them up once they are proposed
+			l.log.Warn("local L2 chain does not extend the submitted blocks, waiting for it", "block_number", i, "submitted", l.submittedTip)
+			return
+		} else if errors.Is(err, ErrReorg) {
 			l.log.Warn("Found L2 reorg", "block_number", i)
 			l.state.Clear()
 			l.lastStoredBlock = eth.BlockID{}
@@ -221,6 +286,26 @@ func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context) {
 	}
 
 	l.metr.RecordL2BlocksLoaded(l2ref)
+	inputBytes, outputBytes := l.state.CompressedBytes()
+	inputBytes, outputBytes = inputBytes-prevInputBytes, outputBytes-prevOutputBytes
+	l.metr.RecordL2BlocksAdded(l2ref, int(end.Number-start.Number), l.state.PendingBlocks(), inputBytes, outputBytes)
+	l.recordCompression(inputBytes, outputBytes)
+}
+
+// recordCompression reports the compression ratio achieved on the batches of the loaded blocks,
+// and warns if it is worse than the approximate ratio the batcher is configured with.
+func (l *BatchSubmitter) recordCompression(inputBytes, outputBytes int) {
+	if inputBytes == 0 {
+		return
+	}
+	l.metr.RecordBlocksCompressed(inputBytes, outputBytes)
+	ratio := float64(outputBytes) / float64(inputBytes)
+	if l.Compression != derive.PayloadVersionUncompressed && ratio > l.Channel.ApproxComprRatio {
+		l.log.Warn("Compression ratio of the loaded blocks is worse than the approximate ratio",
+			"ratio", ratio, "approx_ratio", l.Channel.ApproxComprRatio, "input_bytes", inputBytes, "output_bytes", outputBytes)
+	} else {
+		l.log.Debug("Compressed the loaded blocks", "ratio", ratio, "input_bytes", inputBytes, "output_bytes", outputBytes)
+	}
 }
 
 // loadBlockIntoState fetches & stores a single block into `state`. It returns the block it loaded.
@@ -257,6 +342,10 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 
 	// Check last stored to see if it needs to be set on startup OR set if is lagged behind.
 	// It lagging implies that the op-node processed some batches that were submitted prior to the current instance of the batcher being alive.
+	if err := l.state.PruneJournal(syncStatus.SafeL2.Number); err != nil {
+		l.log.Warn("failed to prune journal", "err", err)
+	}
+
 	if l.lastStoredBlock == (eth.BlockID{}) {
 		l.log.Info("Starting batch-submitter work at safe-head", "safe", syncStatus.SafeL2)
 		l.lastStoredBlock = syncStatus.SafeL2.ID()
@@ -264,6 +353,9 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 		l.log.Warn("last submitted block lagged behind L2 safe head: batch submission will continue from the safe head now", "last", l.lastStoredBlock, "safe", syncStatus.SafeL2)
 		l.lastStoredBlock = syncStatus.SafeL2.ID()
 	}
+	if l.submittedTip != (eth.BlockID{}) && l.submittedTip.Number <= syncStatus.SafeL2.Number {
+		l.submittedTip = eth.BlockID{}
+	}
 
 	// Check if we should even attempt to load any blocks. TODO: May not need this check
 	if syncStatus.SafeL2.Number >= syncStatus.UnsafeL2.Number {
@@ -289,22 +381,50 @@ func (l *BatchSubmitter) loop() {
 
 	ticker := time.NewTicker(l.PollInterval)
 	defer ticker.Stop()
+	// there are at most MaxPendingTransactions transactions in flight, so sending their receipts never blocks
+	receiptsCh := make(chan txmgr.TxReceipt[[]big.Int], l.MaxPendingTransactions)
+	// blocks that were proposed before a restart are only known once the journal is reconciled with L1
+	reconciled := l.Journal == nil
 	for {
 		select {
 		case <-ticker.C:
+			if !reconciled {
+				if err := l.reconcileJournal(l.shutdownCtx); err != nil {
+					l.log.Warn("failed to reconcile journal with L1", "err", err)
+					continue
+				}
+				reconciled = true
+			}
 			l.loadBlocksIntoState(l.shutdownCtx)
 			// TODO(norswap): modify this to hit the contracts instead of the EOA
-			l.publishStateToL1(l.killCtx)
+			l.publishStateToL1(l.killCtx, receiptsCh)
+		case r := <-receiptsCh:
+			l.handleReceipt(r)
+		case req := <-l.submitCh:
+			if !reconciled {
+				req.errCh <- ErrBatcherNotReady
+				continue
+			}
+			req.errCh <- l.addSubmittedBlock(l.shutdownCtx, req.block)
+		case fn := <-l.adminCh:
+			fn()
 		case <-l.shutdownCtx.Done():
-			l.publishStateToL1(l.killCtx)
+			if reconciled {
+				l.publishStateToL1(l.killCtx, receiptsCh)
+			}
+			for l.inflight > 0 {
+				l.handleReceipt(<-receiptsCh)
+			}
 			return
 		}
 	}
 }
 
 // publishStateToL1 loops through the block data loaded into `state` and
-// submits the associated data to the L1 in the form of channel frames.
-func (l *BatchSubmitter) publishStateToL1(ctx context.Context) {
+// submits the associated data to the L1 in the form of propose transactions,
+// until MaxPendingTransactions transactions are in flight.
+// The receipts of the transactions are sent to receiptsCh.
+func (l *BatchSubmitter) publishStateToL1(ctx context.Context, receiptsCh chan txmgr.TxReceipt[[]big.Int]) {
 	for {
 		// Attempt to gracefully terminate the current channel, ensuring that no new frames will be
 		// produced. Any remaining frames must still be published to the L1 to prevent stalling.
@@ -328,9 +448,29 @@ func (l *BatchSubmitter) publishStateToL1(ctx context.Context) {
 			return
 		}
 		l.recordL1Tip(l1tip)
+		if err := l.checkL1Reorgs(ctx, l1tip); err != nil {
+			l.log.Error("Failed to check for L1 reorgs", "error", err)
+			return
+		}
+
+		l.metr.RecordBacklogBlocks(l.state.PendingBlocks())
+		if time.Now().Before(l.sendPausedUntil) {
+			l.log.Debug("txmgr paused sending", "until", l.sendPausedUntil)
+			break
+		}
+		if l.inflight >= int(l.MaxPendingTransactions) {
+			l.log.Debug("max pending transactions reached", "inflight", l.inflight)
+			break
+		}
 
 		// Collect next transaction data
-		txdata, err := l.state.TxData(l1tip.ID())
+		var fits func([]plainTxData) bool
+		if l.ProposeRange {
+			fits = func(datas []plainTxData) bool {
+				return proposeRangeCalldataSize(datas) <= l.MaxL1TxSize
+			}
+		}
+		txdatas, err := l.state.TxDataRange(l1tip.ID(), fits)
 		if err == io.EOF {
 			l.log.Trace("no transaction data available")
 			break
@@ -338,68 +478,80 @@ func (l *BatchSubmitter) publishStateToL1(ctx context.Context) {
 			l.log.Error("unable to get tx data", "err", err)
 			break
 		}
-		// Record TX Status
-		if receipt, err := l.sendTransaction(ctx, txdata); err != nil {
-			l.recordFailedTx(txdata.id, err)
-		} else {
-			l.recordConfirmedTx(txdata.id, receipt)
-		}
+		l.sendTransaction(txdatas, receiptsCh)
 	}
 }
 
-// sendTransaction creates & submits a transaction to the batch inbox address with the given `data`.
-// It currently uses the underlying `txmgr` to handle transaction sending & price management.
-// This is a blocking method. It should not be called concurrently.
-func (l *BatchSubmitter) sendTransaction(ctx context.Context, data plainTxData) (*types.Receipt, error) {
-
-	// TODO(norswap): create new data so that it includes a call to the solidity function with
-	// signature function propose(uint32 chainID, uint256 blockNumber, bytes32 blockHash, bytes
-	// calldata /* block */), where the calldata is the old data.
-
-	// ABI encoding for:
-	// propose(uint32 chainID, uint256 blockNumber, bytes32 blockHash, bytes calldata block)
-
-	// NOTE(norswap): we could use"github.com/ethereum/go-ethereum/accounts/abi" but this is easier
-
-	// encode selector for propose: 0x74123bf9
-	selector := []byte{0x74, 0x12, 0x3b, 0xf9}
+// handleReceipt records the outcome of a propose transaction that was in flight.
+// A failed transaction that is gone may leave its nonce unused, the txmgr assigns it to the next transaction,
+// which proposes the blocks of the failed transaction again before the blocks in flight after it.
+// The txmgr doesn't fail a transaction that may still be included, unless the batcher shuts down.
+func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[[]big.Int]) {
+	l.inflight--
+	l.metr.RecordInflightTxs(l.inflight)
+	for i := range r.ID {
+		delete(l.inflightTxs, r.ID[i].Uint64())
+	}
+	if r.Err == nil && r.Receipt.Status != types.ReceiptStatusSuccessful {
+		// the data stream contract rejected the proposal, e.g. because it leaves a gap
+		r.Err = fmt.Errorf("%w: tx %s", ErrProposeReverted, r.Receipt.TxHash)
+	}
+	var circuitErr *txmgr.CircuitOpenError
+	if errors.As(r.Err, &circuitErr) {
+		// the transaction was not sent, its blocks are proposed again once sending resumes
+		l.log.Warn("txmgr paused sending", "reason", circuitErr.Reason, "until", circuitErr.Until)
+		l.sendPausedUntil = circuitErr.Until
+		for _, id := range r.ID {
+			l.state.TxFailed(id)
+		}
+		return
+	}
+	if r.Err != nil {
+		l.recordFailedTx(r.ID, r.Err)
+	} else {
+		l.recordConfirmedTx(r.ID, r.Receipt)
+	}
+}
 
-	blockNumber := make([]byte, 32)
-    data.id.FillBytes(blockNumber)
-	blockHash := data.blockHash.Bytes() // length 32
-	padding := make([]byte, len(data.data) % 32)
+// sendTransaction creates & submits a propose call to the data stream contract with the given `datas`.
+// Several contiguous blocks are proposed together in a propose range call.
+// It currently uses the underlying `txmgr` queue to handle transaction sending & price management.
+// The transaction is sent in the background and its receipt is sent to receiptsCh.
+func (l *BatchSubmitter) sendTransaction(datas []plainTxData, receiptsCh chan<- txmgr.TxReceipt[[]big.Int]) {
+	l.inflight++
+	l.metr.RecordInflightTxs(l.inflight)
+	l.metr.RecordBatchTxSubmitted()
+	ids := make([]big.Int, len(datas))
+	for i := range datas {
+		ids[i] = datas[i].id
+	}
 
-	calldata := append(selector, blockNumber...)
-	calldata  = append(calldata, blockHash...)
-	calldata  = append(calldata, data.data...)
-	calldata  = append(calldata, padding...)
+	calldata, err := l.proposeCalldata(datas)
+	if err != nil {
+		receiptsCh <- txmgr.TxReceipt[[]big.Int]{ID: ids, Err: err}
+		return
+	}
 
-	l.log.Info("submitting batch", "size", len(calldata), "block", data.id)
+	l.log.Info("submitting batch", "size", len(calldata), "block", &datas[0].id, "blocks", len(datas))
 
 	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
 	intrinsicGas, err := core.IntrinsicGas(calldata, nil, false, true, true, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to calculate intrinsic gas: %w", err)
+		receiptsCh <- txmgr.TxReceipt[[]big.Int]{ID: ids, Err: fmt.Errorf("failed to calculate intrinsic gas: %w", err)}
+		return
 	}
 
-	// NOTE(norswap): Constant estimated via a Foundry script with "hello world" calldata.
-	// This estimate obviously does not include the 21k base gas (accounted for in intrinsic gas).
-	gasLimit := intrinsicGas + 5750
-
-	// TODO(norswap): obvious hack for config value
-	dataStreamAddress := common.HexToAddress("0x99bbA657f2BbC93c02D617f8bA121cB8Fc104Acf")
+	// The data stream contract stores the latest proposed block number of the chain once per call.
+	gasLimit := intrinsicGas + proposeExecutionGas
 
-	// Send the transaction through the txmgr
-	if receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
-		To:       &dataStreamAddress,
+	// Send the transaction through the txmgr, at most MaxPendingTransactions are in flight
+	tx := l.queue.Send(ids, txmgr.TxCandidate{
+		To:       &l.Rollup.DataStreamAddress,
 		TxData:   calldata,
 		GasLimit: gasLimit,
-	}); err != nil {
-		l.log.Warn("unable to publish tx", "err", err, "data_size", len(calldata))
-		return nil, err
-	} else {
-		l.log.Info("tx successfully published", "tx_hash", receipt.TxHash, "data_size", len(calldata))
-		return receipt, nil
+	}, receiptsCh)
+	if tx != nil {
+		l.recordInflightTx(datas, tx)
 	}
 }
 
@@ -411,15 +563,58 @@ func (l *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
 	l.metr.RecordLatestL1Block(l1tip)
 }
 
-func (l *BatchSubmitter) recordFailedTx(id big.Int, err error) {
+// proposeCalldata returns the calldata of the propose call of a single block,
+// or of the propose range call of several contiguous blocks.
+func (l *BatchSubmitter) proposeCalldata(datas []plainTxData) ([]byte, error) {
+	// the data stream may be shared by multiple rollups, the chain ID tells them apart
+	chainID := uint32(l.Rollup.L2ChainID.Uint64())
+	if len(datas) == 1 {
+		return derive.EncodeProposeCall(l.Rollup.ProposeSelector, &derive.ProposeCall{
+			ChainID:     chainID,
+			BlockNumber: &datas[0].id,
+			BlockHash:   datas[0].blockHash,
+			Block:       datas[0].data,
+		})
+	}
+	call := &derive.ProposeRangeCall{
+		ChainID:          chainID,
+		FirstBlockNumber: &datas[0].id,
+		BlockHashes:      make([]common.Hash, len(datas)),
+		Blocks:           make([][]byte, len(datas)),
+	}
+	for i, data := range datas {
+		call.BlockHashes[i] = data.blockHash
+		call.Blocks[i] = data.data
+	}
+	return derive.EncodeProposeRangeCall(l.Rollup.ProposeRangeSelector, call)
+}
+
+// proposeRangeCalldataSize returns the size of the calldata of the propose range call of the blocks:
+// the selector, the four head words, the length and the hashes, and the length, offsets, and padded
+// data of the blocks.
+func proposeRangeCalldataSize(datas []plainTxData) uint64 {
+	size := uint64(4 + 4*32 + 32 + 32)
+	for _, data := range datas {
+		size += 32 + 32 + 32 + (uint64(len(data.data))+31)/32*32
+	}
+	return size
+}
+
+func (l *BatchSubmitter) recordFailedTx(ids []big.Int, err error) {
 	l.log.Warn("Failed to send transaction", "err", err)
-	l.state.TxFailed(id)
+	l.metr.RecordBatchTxFailed()
+	for _, id := range ids {
+		l.state.TxFailed(id)
+	}
 }
 
-func (l *BatchSubmitter) recordConfirmedTx(id big.Int, receipt *types.Receipt) {
+func (l *BatchSubmitter) recordConfirmedTx(ids []big.Int, receipt *types.Receipt) {
 	l.log.Info("Transaction confirmed", "tx_hash", receipt.TxHash, "status", receipt.Status, "block_hash", receipt.BlockHash, "block_number", receipt.BlockNumber)
+	l.metr.RecordBatchTxSuccess()
 	l1block := eth.BlockID{Number: receipt.BlockNumber.Uint64(), Hash: receipt.BlockHash}
-	l.state.TxConfirmed(id, l1block)
+	for _, id := range ids {
+		l.state.TxConfirmed(id, receipt.TxHash, l1block)
+	}
 }
 
 // l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
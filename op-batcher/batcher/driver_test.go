@@ -0,0 +1,90 @@
+package batcher
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// TestProposeCalldata checks that single blocks are proposed with propose calls, ranges of blocks
+// with propose range calls, and that the size of the range calls is computed correctly.
+func TestProposeCalldata(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{
+		L2ChainID:            big.NewInt(901),
+		ProposeSelector:      eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+		ProposeRangeSelector: eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f},
+	}
+	l := &BatchSubmitter{Config: Config{Rollup: cfg}}
+
+	var datas []plainTxData
+	for i, size := range []int{0, 1, 32, 33, 1000} {
+		datas = append(datas, plainTxData{
+			id:        *big.NewInt(int64(100 + i)),
+			blockHash: testutils.RandomHash(rng),
+			data:      testutils.RandomData(rng, size),
+		})
+	}
+
+	calldata, err := l.proposeCalldata(datas[:1])
+	require.NoError(t, err)
+	calls, err := derive.DecodeProposeCalls(cfg, calldata)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Equal(t, datas[0].blockHash, calls[0].BlockHash)
+
+	for n := 2; n <= len(datas); n++ {
+		calldata, err := l.proposeCalldata(datas[:n])
+		require.NoError(t, err)
+		require.Equal(t, uint64(len(calldata)), proposeRangeCalldataSize(datas[:n]))
+		calls, err := derive.DecodeProposeCalls(cfg, calldata)
+		require.NoError(t, err)
+		require.Len(t, calls, n)
+		for i, call := range calls {
+			require.Equal(t, uint32(901), call.ChainID)
+			require.Zero(t, call.BlockNumber.Cmp(&datas[i].id))
+			require.Equal(t, datas[i].blockHash, call.BlockHash)
+			require.Equal(t, datas[i].data, call.Block)
+		}
+	}
+}
+
+// TestCircuitOpenRequeuesBlocks checks that the blocks of a transaction that the circuit breaker of
+// the txmgr didn't send are proposed again, without counting a failed transaction, and that sending
+// is paused until the circuit breaker closes.
+func TestCircuitOpenRequeuesBlocks(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlCrit)
+	l := &BatchSubmitter{
+		Config: Config{log: logger, metr: metrics.NoopMetrics},
+		state:  newPlainBlockdataManager(logger, derive.PayloadVersionUncompressed, nil),
+	}
+	blocks := randomL2Chain(rng, 2)
+	var ids []big.Int
+	for _, b := range blocks {
+		require.NoError(t, l.state.AddL2Block(b))
+		ids = append(ids, requireTxData(t, l.state, eth.BlockID{}, b).id)
+	}
+	l.inflight++
+
+	until := time.Now().Add(time.Minute)
+	err := &txmgr.CircuitOpenError{Reason: txmgr.CircuitReasonSpendLimit, Until: until}
+	l.handleReceipt(txmgr.TxReceipt[[]big.Int]{ID: ids, Err: err})
+	require.Equal(t, until, l.sendPausedUntil)
+	require.Zero(t, l.inflight)
+	for _, b := range blocks {
+		requireTxData(t, l.state, eth.BlockID{}, b)
+	}
+}
@@ -1,42 +1,74 @@
 package batcher
+
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"sort"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/journal"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// ErrBlockInFlight is returned when resubmitting a block whose propose transaction is in flight.
+	ErrBlockInFlight = errors.New("block is in flight")
+	// ErrUnknownBlock is returned when resubmitting a block that is neither pending nor confirmed.
+	ErrUnknownBlock = errors.New("unknown block")
 )
 
 type plainTxData struct {
-	id big.Int // TODO rename to blockNumber everywhere
+	id        big.Int // TODO rename to blockNumber everywhere
 	blockHash common.Hash
-	data []byte // block data encoded as a batch
+	data      []byte // block data encoded as a batch, prefixed with the payload version
+}
+
+// confirmedTxData is the data of a confirmed transaction and the L1 block that included it.
+// The data is retained to propose the block again if the inclusion block is reorged out.
+type confirmedTxData struct {
+	plainTxData
+	inclusion eth.BlockID
 }
 
 type plainBlockdataManager struct {
-	log  log.Logger
+	log log.Logger
+	// Payload version of the proposed blocks, which selects the compression of their batch.
+	compression byte
+	// Total size of the encoded batches of the added blocks, before and after compression.
+	inputBytes  int
+	outputBytes int
+	// Optional journal of the proposed blocks, to pick up where we left off after a restart.
+	journal journal.Journal
 	// Data for blocks that haven't been posted yet.
 	datas []plainTxData
 	// last block hash - for reorg detection
 	tip common.Hash
+	// Number of the last added block. Requeued blocks above it were proposed before the last Clear,
+	// and are not proposed again until the block is added again.
+	tipNumber uint64
 	// Set of unconfirmed tx for given L2 block num -> block data. For tx resubmission
+	// Kept across Clear, so that the receipts of the transactions in flight are still handled.
 	pendingTransactions map[[32]byte]plainTxData
-	// Set of confirmed tx for given block num -> inclusion block. For timeouts
-	confirmedTransactions map[[32]byte]eth.BlockID
-	closed bool
+	// Set of confirmed tx for given block num -> block data and inclusion block. For L1 reorgs
+	// Kept across Clear, so that the confirmed transactions are still watched for L1 reorgs.
+	confirmedTransactions map[[32]byte]confirmedTxData
+	closed                bool
 }
 
-func newPlainBlockdataManager(log log.Logger) *plainBlockdataManager {
+func newPlainBlockdataManager(log log.Logger, compression byte, j journal.Journal) *plainBlockdataManager {
 	return &plainBlockdataManager{
-		log:  log,
+		log:                   log,
+		compression:           compression,
+		journal:               j,
 		pendingTransactions:   make(map[[32]byte]plainTxData),
-		confirmedTransactions: make(map[[32]byte]eth.BlockID),
+		confirmedTransactions: make(map[[32]byte]confirmedTxData),
 	}
 }
 
@@ -47,67 +79,214 @@ func toBytes32(bigInt big.Int) [32]byte {
 }
 
 func (mgr *plainBlockdataManager) TxData(l1Head eth.BlockID) (plainTxData, error) {
+	datas, err := mgr.TxDataRange(l1Head, nil)
+	if err != nil {
+		return plainTxData{}, err
+	}
+	return datas[0], nil
+}
+
+// TxDataRange returns the data of the next contiguous blocks to propose together. It returns at least
+// one block, and adds the following blocks as long as fits accepts the whole range. A nil fits only
+// returns one block.
+func (mgr *plainBlockdataManager) TxDataRange(l1Head eth.BlockID, fits func([]plainTxData) bool) ([]plainTxData, error) {
 	mgr.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", "blocks_pending", len(mgr.datas))
 
-	if (mgr.closed) {
-    		// NOTE(norswap): I assume this is the intended behaviour? Can't hurt.
-    		return plainTxData{}, io.EOF
-    	}
+	if mgr.closed {
+		// NOTE(norswap): I assume this is the intended behaviour? Can't hurt.
+		return nil, io.EOF
+	}
 
-    // All blocks have been submitted already!
-    if len(mgr.datas) == 0 {
-        return plainTxData{}, io.EOF
-    }
+	// All blocks have been submitted already!
+	if len(mgr.datas) == 0 || !mgr.proposable(mgr.datas[0]) {
+		return nil, io.EOF
+	}
 
-	block := mgr.datas[0]
-	mgr.datas = mgr.datas[1:]
+	n := 1
+	for fits != nil && n < len(mgr.datas) {
+		next := new(big.Int).Add(&mgr.datas[n-1].id, common.Big1)
+		if mgr.datas[n].id.Cmp(next) != 0 || !mgr.proposable(mgr.datas[n]) || !fits(mgr.datas[:n+1]) {
+			break
+		}
+		n++
+	}
+	for i := 0; i < n; i++ {
+		if err := mgr.journalSubmitted(mgr.datas[i], l1Head); err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			mgr.log.Warn("failed to journal block, proposing a shorter range", "id", &mgr.datas[i].id, "err", err)
+			n = i
+			break
+		}
+	}
+	datas := mgr.datas[:n:n]
+	mgr.datas = mgr.datas[n:]
 
-    mgr.log.Trace("returning next tx data")
-	bytesID := [32]byte{}
-	block.id.FillBytes(bytesID[:])
-    mgr.pendingTransactions[bytesID] = block
-    return block, nil
+	mgr.log.Trace("returning next tx data", "blocks", n)
+	for _, block := range datas {
+		mgr.pendingTransactions[toBytes32(block.id)] = block
+	}
+	return datas, nil
 }
 
 func (mgr *plainBlockdataManager) AddL2Block(block *types.Block) error {
 	if mgr.tip != (common.Hash{}) && mgr.tip != block.ParentHash() {
-        return ErrReorg
-    }
+		return ErrReorg
+	}
 	batch, _, err := derive.BlockToBatch(block) // middle is l1Info
-    if err != nil {
-        return fmt.Errorf("converting block to batch: %w", err)
-    }
+	if err != nil {
+		return fmt.Errorf("converting block to batch: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := rlp.Encode(&buf, batch); err != nil {
+		return err
+	}
+	payload, err := derive.EncodeBlockPayload(mgr.compression, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("encoding block payload: %w", err)
+	}
+	mgr.inputBytes += buf.Len()
+	mgr.outputBytes += len(payload)
+	data := plainTxData{*block.Number(), block.Hash(), payload}
+	mgr.tip = block.Hash()
+	mgr.tipNumber = block.NumberU64()
+
+	if mgr.journalConfirmed(data) || mgr.tracked(data) {
+		return nil
+	}
 
 	mgr.log.Info("adding L2 block", "number", block.Number(), "txs", block.Transactions().Len())
 
-    var buf bytes.Buffer
-    if err := rlp.Encode(&buf, batch); err != nil {
-        return err
-    }
-    data := plainTxData{*block.Number(), block.Hash(), buf.Bytes()}
+	i, queued := mgr.queueIndex(&data.id)
+	if queued {
+		// requeued after Clear, before the block was added again
+		mgr.datas[i] = data
+		return nil
+	}
+	mgr.insert(i, data)
+	return nil
+}
 
-    mgr.datas = append(mgr.datas, data)
-    mgr.tip = block.Hash()
-    return nil
+// tracked returns whether the block is already proposed by a transaction that is in flight or confirmed,
+// which happens when blocks are added again after Clear. A transaction of the same block number that
+// proposed a different block proposed a block that was reorged out of L2: a confirmed one is no longer
+// watched, and one in flight is superseded by the block once it returns.
+func (mgr *plainBlockdataManager) tracked(data plainTxData) bool {
+	bytesID := toBytes32(data.id)
+	if p, ok := mgr.pendingTransactions[bytesID]; ok {
+		if p.blockHash == data.blockHash {
+			return true
+		}
+		mgr.log.Warn("transaction in flight proposes a block that was reorged out", "id", &data.id, "old_hash", p.blockHash, "new_hash", data.blockHash)
+	}
+	if c, ok := mgr.confirmedTransactions[bytesID]; ok {
+		if c.blockHash == data.blockHash {
+			return true
+		}
+		mgr.log.Warn("confirmed transaction proposed a block that was reorged out", "id", &data.id, "old_hash", c.blockHash, "new_hash", data.blockHash, "inclusion", c.inclusion)
+		delete(mgr.confirmedTransactions, bytesID)
+	}
+	return false
+}
+
+// proposable returns whether the queued block can be proposed: it must have been added since
+// the last Clear, and no transaction that proposes a block of the same number may be in flight.
+func (mgr *plainBlockdataManager) proposable(data plainTxData) bool {
+	if !data.id.IsUint64() || data.id.Uint64() > mgr.tipNumber {
+		return false
+	}
+	_, inFlight := mgr.pendingTransactions[toBytes32(data.id)]
+	return !inFlight
+}
+
+// CompressedBytes returns the total size of the encoded batches of the added blocks,
+// before and after compression.
+func (mgr *plainBlockdataManager) CompressedBytes() (inputBytes, outputBytes int) {
+	return mgr.inputBytes, mgr.outputBytes
+}
+
+// PendingBlocks returns the number of loaded blocks that are not proposed yet.
+func (mgr *plainBlockdataManager) PendingBlocks() int {
+	return len(mgr.datas)
+}
+
+// PendingBlockIDs returns the loaded blocks that are not proposed yet, in the order they will be proposed.
+func (mgr *plainBlockdataManager) PendingBlockIDs() []eth.BlockID {
+	ids := make([]eth.BlockID, len(mgr.datas))
+	for i, data := range mgr.datas {
+		ids[i] = eth.BlockID{Hash: data.blockHash, Number: data.id.Uint64()}
+	}
+	return ids
 }
 
-// NOTE(norswap): Useful? Only in case the drive is restarted at best.
+// HasBlock returns whether the block is loaded and not proposed yet, in flight, or confirmed.
+func (mgr *plainBlockdataManager) HasBlock(id eth.BlockID) bool {
+	number := new(big.Int).SetUint64(id.Number)
+	if i, ok := mgr.queueIndex(number); ok && mgr.datas[i].blockHash == id.Hash {
+		return true
+	}
+	if p, ok := mgr.pendingTransactions[toBytes32(*number)]; ok && p.blockHash == id.Hash {
+		return true
+	}
+	c, ok := mgr.confirmedTransactions[toBytes32(*number)]
+	return ok && c.blockHash == id.Hash
+}
+
+// DropBefore forgets the loaded blocks below the given block number that are not proposed yet,
+// and returns how many blocks were dropped. Blocks in flight or confirmed are kept.
+func (mgr *plainBlockdataManager) DropBefore(number uint64) int {
+	n := new(big.Int).SetUint64(number)
+	i := sort.Search(len(mgr.datas), func(i int) bool { return mgr.datas[i].id.Cmp(n) >= 0 })
+	mgr.datas = mgr.datas[i:]
+	if i > 0 {
+		mgr.log.Warn("dropped pending L2 blocks", "before", number, "count", i)
+	}
+	return i
+}
+
+// Resubmit moves the confirmed transaction of the given block back into the blocks to propose.
+// It is a no-op for blocks that are not proposed yet. It fails for blocks that are in flight,
+// and for blocks that are not known, such as the blocks whose inclusion was finalized.
+func (mgr *plainBlockdataManager) Resubmit(number uint64) error {
+	id := new(big.Int).SetUint64(number)
+	bytesID := toBytes32(*id)
+	for _, data := range mgr.datas {
+		if data.id.Cmp(id) == 0 {
+			return nil
+		}
+	}
+	if _, ok := mgr.pendingTransactions[bytesID]; ok {
+		return fmt.Errorf("%w: %d", ErrBlockInFlight, number)
+	}
+	c, ok := mgr.confirmedTransactions[bytesID]
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownBlock, number)
+	}
+	mgr.log.Warn("resubmitting confirmed L2 block", "id", id, "inclusion", c.inclusion)
+	mgr.unconfirm(bytesID, c)
+	return nil
+}
+
+// Clear forgets the blocks that are not proposed yet, after an L2 reorg or a restart of the driver.
+// The transactions in flight and the confirmed transactions are kept, and are reconciled with the
+// blocks that are added again: blocks that they proposed are not proposed again.
 func (mgr *plainBlockdataManager) Clear() {
 	mgr.log.Trace("clearing channel manager state")
-    mgr.datas = mgr.datas[:0]
-    mgr.tip = common.Hash{}
-	mgr.pendingTransactions = make(map[[32]byte]plainTxData)
-	mgr.confirmedTransactions = make(map[[32]byte]eth.BlockID)
+	mgr.datas = mgr.datas[:0]
+	mgr.tip = common.Hash{}
+	mgr.tipNumber = 0
 	mgr.closed = false
 }
 
 // NOTE(norsawp): Useful? Only if we would request blocks after closing.
 func (mgr *plainBlockdataManager) Close() error {
 	// Yes, this could be simpler, but keep structure if there is a need to change.
-    if mgr.closed {
-        return nil
-    }
-    mgr.closed = true
+	if mgr.closed {
+		return nil
+	}
+	mgr.closed = true
 	return nil
 }
 
@@ -117,7 +296,8 @@ func (mgr *plainBlockdataManager) TxFailed(id big.Int) {
 	bytesID := toBytes32(id)
 	if data, ok := mgr.pendingTransactions[bytesID]; ok {
 		mgr.log.Trace("handling failed tranasaction", "id", id)
-		mgr.datas = append([]plainTxData{data}, mgr.datas...)
+		// transactions in flight may fail in any order
+		mgr.requeue(data)
 		delete(mgr.pendingTransactions, bytesID)
 	} else {
 		mgr.log.Warn("unknown transaction marked as failed", "id", id)
@@ -125,13 +305,167 @@ func (mgr *plainBlockdataManager) TxFailed(id big.Int) {
 }
 
 // TxConfirmed marks a transaction as confirmed on L1.
-func (mgr *plainBlockdataManager) TxConfirmed(id big.Int, inclusionBlock eth.BlockID) {
+func (mgr *plainBlockdataManager) TxConfirmed(id big.Int, txHash common.Hash, inclusionBlock eth.BlockID) {
 	mgr.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
 	bytesID := toBytes32(id)
-	if _, ok := mgr.pendingTransactions[bytesID]; !ok {
+	data, ok := mgr.pendingTransactions[bytesID]
+	if !ok {
 		mgr.log.Warn("unknown transaction marked as confirmed", "id", id, "block", inclusionBlock)
 		return
 	}
 	delete(mgr.pendingTransactions, bytesID)
-	mgr.confirmedTransactions[bytesID] = inclusionBlock
+	if _, queued := mgr.queueIndex(&id); queued {
+		mgr.log.Warn("confirmed transaction proposed a block that was reorged out", "id", &id, "hash", data.blockHash, "block", inclusionBlock)
+		return
+	}
+	mgr.confirmedTransactions[bytesID] = confirmedTxData{data, inclusionBlock}
+
+	if mgr.journal == nil {
+		return
+	}
+	e, err := mgr.journal.Get(id.Uint64())
+	if err != nil || e.Block.Hash != data.blockHash {
+		mgr.log.Error("confirmed transaction is missing from journal", "id", id, "err", err)
+		e = journal.Entry{Block: eth.BlockID{Hash: data.blockHash, Number: id.Uint64()}, SubmittedAt: inclusionBlock}
+	}
+	e.TxHash = txHash
+	e.Inclusion = inclusionBlock
+	if err := mgr.journal.Put(e); err != nil {
+		// the block will be reconciled against L1 after a restart
+		mgr.log.Error("failed to journal confirmed transaction", "id", id, "err", err)
+	}
+}
+
+// journalSubmitted records that the block is about to be proposed while the L1 head is l1Head.
+// It must be durable before the propose transaction is sent.
+func (mgr *plainBlockdataManager) journalSubmitted(block plainTxData, l1Head eth.BlockID) error {
+	if mgr.journal == nil {
+		return nil
+	}
+	e := journal.Entry{
+		Block:       eth.BlockID{Hash: block.blockHash, Number: block.id.Uint64()},
+		SubmittedAt: l1Head,
+	}
+	// a resubmission may still be included after the first submission, keep looking from there
+	if prev, err := mgr.journal.Get(e.Block.Number); err == nil && prev.Block == e.Block && prev.SubmittedAt.Number < l1Head.Number {
+		e.SubmittedAt = prev.SubmittedAt
+	} else if err != nil && !errors.Is(err, journal.ErrNotFound) {
+		return fmt.Errorf("reading journal: %w", err)
+	}
+	if err := mgr.journal.Put(e); err != nil {
+		return fmt.Errorf("journaling submitted block: %w", err)
+	}
+	return nil
+}
+
+// journalConfirmed returns whether the journal shows that the block was already proposed on L1,
+// and marks it as confirmed if so.
+func (mgr *plainBlockdataManager) journalConfirmed(data plainTxData) bool {
+	if mgr.journal == nil {
+		return false
+	}
+	e, err := mgr.journal.Get(data.id.Uint64())
+	if err != nil || e.Block.Hash != data.blockHash || !e.Confirmed() {
+		return false
+	}
+	mgr.log.Info("skipping L2 block that was already proposed", "number", &data.id, "inclusion", e.Inclusion)
+	mgr.confirmedTransactions[toBytes32(data.id)] = confirmedTxData{data, e.Inclusion}
+	return true
+}
+
+// InclusionBlocks returns the L1 blocks that included the confirmed transactions.
+func (mgr *plainBlockdataManager) InclusionBlocks() []eth.BlockID {
+	seen := make(map[eth.BlockID]struct{})
+	var out []eth.BlockID
+	for _, c := range mgr.confirmedTransactions {
+		if _, ok := seen[c.inclusion]; !ok {
+			seen[c.inclusion] = struct{}{}
+			out = append(out, c.inclusion)
+		}
+	}
+	return out
+}
+
+// InclusionReorged moves the transactions that were confirmed in the given L1 block,
+// which is no longer canonical, back into the blocks to propose.
+func (mgr *plainBlockdataManager) InclusionReorged(inclusion eth.BlockID) {
+	for bytesID, c := range mgr.confirmedTransactions {
+		if c.inclusion != inclusion {
+			continue
+		}
+		mgr.log.Warn("inclusion block of confirmed transaction was reorged out", "id", &c.id, "block", inclusion)
+		mgr.unconfirm(bytesID, c)
+	}
+}
+
+// unconfirm moves a confirmed transaction back into the blocks to propose, and forgets its inclusion in the journal.
+func (mgr *plainBlockdataManager) unconfirm(bytesID [32]byte, c confirmedTxData) {
+	delete(mgr.confirmedTransactions, bytesID)
+	mgr.requeue(c.plainTxData)
+	if mgr.journal == nil {
+		return
+	}
+	if e, err := mgr.journal.Get(c.id.Uint64()); err == nil && e.Block.Hash == c.blockHash {
+		e.TxHash = common.Hash{}
+		e.Inclusion = eth.BlockID{}
+		if err := mgr.journal.Put(e); err != nil {
+			mgr.log.Error("failed to journal unconfirmed transaction", "id", &c.id, "err", err)
+		}
+	}
+}
+
+// requeue puts the block data back into the blocks to propose, keeping them ordered by block number.
+// A block of the same number that is queued already was added after the data was proposed, and
+// takes precedence.
+func (mgr *plainBlockdataManager) requeue(data plainTxData) {
+	i, queued := mgr.queueIndex(&data.id)
+	if queued {
+		mgr.log.Debug("not requeueing superseded block", "id", &data.id, "hash", data.blockHash)
+		return
+	}
+	mgr.insert(i, data)
+}
+
+// queueIndex returns the index of the queued block with the given number, and whether it is queued.
+// If it is not, the index is where the block would be inserted.
+func (mgr *plainBlockdataManager) queueIndex(id *big.Int) (int, bool) {
+	i := sort.Search(len(mgr.datas), func(i int) bool { return mgr.datas[i].id.Cmp(id) >= 0 })
+	return i, i < len(mgr.datas) && mgr.datas[i].id.Cmp(id) == 0
+}
+
+func (mgr *plainBlockdataManager) insert(i int, data plainTxData) {
+	mgr.datas = append(mgr.datas, plainTxData{})
+	copy(mgr.datas[i+1:], mgr.datas[i:])
+	mgr.datas[i] = data
+}
+
+// PruneConfirmed forgets the confirmed transactions that were included at or below the given L1 block number,
+// which can no longer be reorged out.
+func (mgr *plainBlockdataManager) PruneConfirmed(finalized uint64) {
+	for bytesID, c := range mgr.confirmedTransactions {
+		if c.inclusion.Number <= finalized {
+			delete(mgr.confirmedTransactions, bytesID)
+		}
+	}
+}
+
+// PruneJournal removes the journal entries of the blocks up to and including the L2 safe head,
+// since they will never be proposed again.
+func (mgr *plainBlockdataManager) PruneJournal(safeHead uint64) error {
+	if mgr.journal == nil {
+		return nil
+	}
+	entries, err := mgr.journal.Entries()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Block.Number > safeHead {
+			break
+		}
+		if err := mgr.journal.Delete(e.Block.Number); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,318 @@
+package batcher
+
+import (
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/journal"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// randomL2Chain returns n consecutive L2 blocks that can be converted to batches.
+func randomL2Chain(rng *rand.Rand, n int) []*types.Block {
+	first, _ := derivetest.RandomL2Block(rng, 2)
+	blocks := []*types.Block{first}
+	for i := 1; i < n; i++ {
+		parent := blocks[i-1]
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			UncleHash:  types.EmptyUncleHash,
+			Number:     new(big.Int).Add(parent.Number(), common.Big1),
+			Time:       parent.Time() + 2,
+		}
+		blocks = append(blocks, types.NewBlockWithHeader(header).WithBody(first.Transactions(), nil))
+	}
+	return blocks
+}
+
+func requireTxData(t *testing.T, m *plainBlockdataManager, l1Head eth.BlockID, expected *types.Block) plainTxData {
+	data, err := m.TxData(l1Head)
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash(), data.blockHash)
+	return data
+}
+
+// TestPlainBlockdataManagerJournal checks that the journal records the submitted and confirmed blocks,
+// and that a restarted manager does not propose the confirmed blocks again.
+func TestPlainBlockdataManagerJournal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	log := testlog.Logger(t, log.LvlCrit)
+	j := journal.NewMemJournal()
+	blocks := randomL2Chain(rng, 3)
+
+	m := newPlainBlockdataManager(log, derive.PayloadVersionUncompressed, j)
+	for _, b := range blocks {
+		require.NoError(t, m.AddL2Block(b))
+	}
+
+	data0 := requireTxData(t, m, eth.BlockID{Number: 100}, blocks[0])
+	data1 := requireTxData(t, m, eth.BlockID{Number: 101}, blocks[1])
+	e, err := j.Get(blocks[1].NumberU64())
+	require.NoError(t, err)
+	require.Equal(t, journal.Entry{Block: eth.ToBlockID(blocks[1]), SubmittedAt: eth.BlockID{Number: 101}}, e)
+
+	inclusion := eth.BlockID{Hash: common.Hash{0x11}, Number: 102}
+	m.TxConfirmed(data0.id, common.Hash{0xaa}, inclusion)
+	e, err = j.Get(blocks[0].NumberU64())
+	require.NoError(t, err)
+	require.True(t, e.Confirmed())
+	require.Equal(t, common.Hash{0xaa}, e.TxHash)
+	require.Equal(t, inclusion, e.Inclusion)
+
+	// a resubmission keeps looking for the first submission
+	m.TxFailed(data1.id)
+	requireTxData(t, m, eth.BlockID{Number: 105}, blocks[1])
+	e, err = j.Get(blocks[1].NumberU64())
+	require.NoError(t, err)
+	require.Equal(t, uint64(101), e.SubmittedAt.Number)
+	require.False(t, e.Confirmed())
+
+	// after a restart, only the unconfirmed blocks are proposed
+	m = newPlainBlockdataManager(log, derive.PayloadVersionUncompressed, j)
+	for _, b := range blocks {
+		require.NoError(t, m.AddL2Block(b))
+	}
+	requireTxData(t, m, eth.BlockID{Number: 110}, blocks[1])
+	requireTxData(t, m, eth.BlockID{Number: 110}, blocks[2])
+	require.Contains(t, m.confirmedTransactions, toBytes32(*blocks[0].Number()))
+
+	// a different block at the height of a confirmed block is proposed
+	other := randomL2Chain(rng, 1)[0]
+	m = newPlainBlockdataManager(log, derive.PayloadVersionUncompressed, j)
+	require.NoError(t, m.AddL2Block(types.NewBlockWithHeader(&types.Header{
+		ParentHash: other.ParentHash(),
+		Number:     blocks[0].Number(),
+	}).WithBody(other.Transactions(), nil)))
+	require.Len(t, m.datas, 1)
+
+	// blocks up to the safe head are pruned
+	require.NoError(t, m.PruneJournal(blocks[0].NumberU64()))
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, blocks[1].NumberU64(), entries[0].Block.Number)
+}
+
+// TestPlainBlockdataManagerReorg checks that the blocks of the transactions included in a reorged L1 block
+// are proposed again in order, and that pruned transactions are no longer watched.
+func TestPlainBlockdataManagerReorg(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	j := journal.NewMemJournal()
+	blocks := randomL2Chain(rng, 4)
+
+	m := newPlainBlockdataManager(testlog.Logger(t, log.LvlCrit), derive.PayloadVersionUncompressed, j)
+	for _, b := range blocks {
+		require.NoError(t, m.AddL2Block(b))
+	}
+	var datas []plainTxData
+	for _, b := range blocks[:3] {
+		datas = append(datas, requireTxData(t, m, eth.BlockID{Number: 100}, b))
+	}
+	a := eth.BlockID{Hash: common.Hash{0x11}, Number: 101}
+	b := eth.BlockID{Hash: common.Hash{0x22}, Number: 102}
+	m.TxConfirmed(datas[0].id, common.Hash{0xa0}, a)
+	m.TxConfirmed(datas[1].id, common.Hash{0xa1}, b)
+	m.TxConfirmed(datas[2].id, common.Hash{0xa2}, b)
+	require.ElementsMatch(t, []eth.BlockID{a, b}, m.InclusionBlocks())
+
+	m.InclusionReorged(b)
+	require.Equal(t, []eth.BlockID{a}, m.InclusionBlocks())
+	e, err := j.Get(blocks[1].NumberU64())
+	require.NoError(t, err)
+	require.False(t, e.Confirmed())
+
+	// the reorged blocks are proposed again before the pending one
+	requireTxData(t, m, eth.BlockID{Number: 103}, blocks[1])
+	requireTxData(t, m, eth.BlockID{Number: 103}, blocks[2])
+	requireTxData(t, m, eth.BlockID{Number: 103}, blocks[3])
+
+	m.PruneConfirmed(100)
+	require.Equal(t, []eth.BlockID{a}, m.InclusionBlocks())
+	m.PruneConfirmed(101)
+	require.Empty(t, m.InclusionBlocks())
+}
+
+// TestPlainBlockdataManagerClear checks that the transactions in flight and the confirmed transactions
+// are kept across Clear, and that the blocks they proposed are not proposed again unless they were
+// replaced by an L2 reorg.
+func TestPlainBlockdataManagerClear(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blocks := randomL2Chain(rng, 4)
+
+	m := newPlainBlockdataManager(testlog.Logger(t, log.LvlCrit), derive.PayloadVersionUncompressed, nil)
+	for _, b := range blocks {
+		require.NoError(t, m.AddL2Block(b))
+	}
+	data0 := requireTxData(t, m, eth.BlockID{Number: 100}, blocks[0])
+	data1 := requireTxData(t, m, eth.BlockID{Number: 100}, blocks[1])
+	data2 := requireTxData(t, m, eth.BlockID{Number: 100}, blocks[2])
+	inclusion := eth.BlockID{Hash: common.Hash{0x11}, Number: 101}
+	m.TxConfirmed(data0.id, common.Hash{0xa0}, inclusion)
+
+	// an L2 reorg replaces the blocks from blocks[2] on
+	m.Clear()
+	reorged := randomL2Chain(rng, 2)
+	for i, b := range reorged {
+		header := b.Header()
+		header.Number = blocks[2+i].Number()
+		header.ParentHash = blocks[1].Hash()
+		if i > 0 {
+			header.ParentHash = reorged[i-1].Hash()
+		}
+		reorged[i] = types.NewBlockWithHeader(header).WithBody(b.Transactions(), nil)
+	}
+	for _, b := range append(blocks[:2:2], reorged...) {
+		require.NoError(t, m.AddL2Block(b))
+	}
+	require.Equal(t, []eth.BlockID{inclusion}, m.InclusionBlocks())
+	require.Equal(t, []eth.BlockID{eth.ToBlockID(reorged[0]), eth.ToBlockID(reorged[1])}, m.PendingBlockIDs())
+
+	// the replaced block is not proposed while the transaction of the reorged block is in flight
+	_, err := m.TxData(eth.BlockID{Number: 102})
+	require.ErrorIs(t, err, io.EOF)
+
+	// the receipts of the transactions in flight are still handled
+	m.TxConfirmed(data1.id, common.Hash{0xa1}, inclusion)
+	m.TxConfirmed(data2.id, common.Hash{0xa2}, inclusion)
+	require.ElementsMatch(t, []eth.BlockID{inclusion}, m.InclusionBlocks())
+	requireTxData(t, m, eth.BlockID{Number: 102}, reorged[0])
+	requireTxData(t, m, eth.BlockID{Number: 102}, reorged[1])
+
+	// a confirmed block that is reorged out of L1 after Clear is only proposed again once it is added again
+	m.Clear()
+	m.InclusionReorged(inclusion)
+	_, err = m.TxData(eth.BlockID{Number: 103})
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, m.AddL2Block(blocks[0]))
+	requireTxData(t, m, eth.BlockID{Number: 103}, blocks[0])
+	require.NoError(t, m.AddL2Block(blocks[1]))
+	requireTxData(t, m, eth.BlockID{Number: 103}, blocks[1])
+}
+
+// TestPlainBlockdataManagerTxDataRange checks that ranges of blocks are contiguous and accepted by fits.
+func TestPlainBlockdataManagerTxDataRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blocks := randomL2Chain(rng, 6)
+	m := newPlainBlockdataManager(testlog.Logger(t, log.LvlCrit), derive.PayloadVersionUncompressed, nil)
+	for _, b := range blocks {
+		require.NoError(t, m.AddL2Block(b))
+	}
+	upTo := func(n int) func([]plainTxData) bool {
+		return func(datas []plainTxData) bool { return len(datas) <= n }
+	}
+
+	datas, err := m.TxDataRange(eth.BlockID{}, upTo(2))
+	require.NoError(t, err)
+	require.Len(t, datas, 2)
+	require.Equal(t, blocks[0].Hash(), datas[0].blockHash)
+	require.Equal(t, blocks[1].Hash(), datas[1].blockHash)
+
+	// the range stops at the gap left by blocks that are in flight
+	inflight, err := m.TxDataRange(eth.BlockID{}, upTo(2))
+	require.NoError(t, err)
+	m.TxFailed(datas[1].id)
+	datas, err = m.TxDataRange(eth.BlockID{}, upTo(10))
+	require.NoError(t, err)
+	require.Len(t, datas, 1)
+	require.Equal(t, blocks[1].Hash(), datas[0].blockHash)
+
+	// a nil fits returns a single block
+	m.TxFailed(inflight[0].id)
+	m.TxFailed(inflight[1].id)
+	datas, err = m.TxDataRange(eth.BlockID{}, nil)
+	require.NoError(t, err)
+	require.Len(t, datas, 1)
+	require.Equal(t, blocks[2].Hash(), datas[0].blockHash)
+
+	datas, err = m.TxDataRange(eth.BlockID{}, upTo(10))
+	require.NoError(t, err)
+	require.Len(t, datas, 3)
+	require.Equal(t, blocks[5].Hash(), datas[2].blockHash)
+	_, err = m.TxDataRange(eth.BlockID{}, upTo(10))
+	require.ErrorIs(t, err, io.EOF)
+}
+
+// TestPlainBlockdataManagerCompression checks that the proposed blocks are prefixed with the payload
+// version of the configured compression, and that the compressed sizes are tracked.
+func TestPlainBlockdataManagerCompression(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blocks := randomL2Chain(rng, 3)
+	plain := newPlainBlockdataManager(testlog.Logger(t, log.LvlCrit), derive.PayloadVersionUncompressed, nil)
+	zlib := newPlainBlockdataManager(testlog.Logger(t, log.LvlCrit), derive.PayloadVersionZlib, nil)
+	for _, block := range blocks {
+		require.NoError(t, plain.AddL2Block(block))
+		require.NoError(t, zlib.AddL2Block(block))
+	}
+
+	for _, block := range blocks {
+		plainData := requireTxData(t, plain, eth.BlockID{}, block)
+		zlibData := requireTxData(t, zlib, eth.BlockID{}, block)
+		require.Equal(t, derive.PayloadVersionUncompressed, plainData.data[0])
+		require.Equal(t, derive.PayloadVersionZlib, zlibData.data[0])
+		batch, err := derive.DecodeBlockPayload(zlibData.data)
+		require.NoError(t, err)
+		require.Equal(t, plainData.data[1:], batch)
+	}
+
+	in, out := plain.CompressedBytes()
+	require.Equal(t, in+len(blocks), out, "uncompressed payloads only add the version byte")
+	zin, zout := zlib.CompressedBytes()
+	require.Equal(t, in, zin)
+	require.NotZero(t, zout)
+
+	brotli := newPlainBlockdataManager(testlog.Logger(t, log.LvlCrit), derive.PayloadVersionBrotli, nil)
+	require.NoError(t, brotli.AddL2Block(blocks[0]))
+	brotliData := requireTxData(t, brotli, eth.BlockID{}, blocks[0])
+	require.Equal(t, derive.PayloadVersionBrotli, brotliData.data[0])
+	batch, err := derive.DecodeBlockPayload(brotliData.data)
+	require.NoError(t, err)
+	expected, _, err := derive.BlockToBatch(blocks[0])
+	require.NoError(t, err)
+	expectedBatch, err := rlp.EncodeToBytes(expected)
+	require.NoError(t, err)
+	require.Equal(t, expectedBatch, batch)
+}
+
+// TestPlainBlockdataManagerAdmin checks the inspection and manipulation of the pending blocks by the admin API.
+func TestPlainBlockdataManagerAdmin(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	j := journal.NewMemJournal()
+	m := newPlainBlockdataManager(testlog.Logger(t, log.LvlCrit), derive.PayloadVersionUncompressed, j)
+	blocks := randomL2Chain(rng, 5)
+	var ids []eth.BlockID
+	for _, b := range blocks {
+		require.NoError(t, m.AddL2Block(b))
+		ids = append(ids, eth.ToBlockID(b))
+	}
+	require.Equal(t, ids, m.PendingBlockIDs())
+
+	// blocks[0] is confirmed, blocks[1] is in flight
+	data0 := requireTxData(t, m, eth.BlockID{Number: 100}, blocks[0])
+	m.TxConfirmed(data0.id, common.Hash{0xaa}, eth.BlockID{Number: 101})
+	requireTxData(t, m, eth.BlockID{Number: 101}, blocks[1])
+	require.Equal(t, ids[2:], m.PendingBlockIDs())
+
+	require.Zero(t, m.DropBefore(ids[2].Number))
+	require.Equal(t, 1, m.DropBefore(ids[3].Number))
+	require.Equal(t, ids[3:], m.PendingBlockIDs())
+
+	require.NoError(t, m.Resubmit(ids[4].Number), "resubmitting a pending block is a no-op")
+	require.ErrorIs(t, m.Resubmit(ids[1].Number), ErrBlockInFlight)
+	require.ErrorIs(t, m.Resubmit(ids[2].Number), ErrUnknownBlock)
+	require.NoError(t, m.Resubmit(ids[0].Number))
+	require.Equal(t, []eth.BlockID{ids[0], ids[3], ids[4]}, m.PendingBlockIDs())
+	e, err := j.Get(ids[0].Number)
+	require.NoError(t, err)
+	require.False(t, e.Confirmed(), "the journal forgets the inclusion of resubmitted blocks")
+}
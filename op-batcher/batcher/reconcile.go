@@ -0,0 +1,230 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/journal"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// reconcileJournal brings the journal in line with L1 before anything is proposed after a (re)start:
+//  1. it waits for the propose transactions of the previous run that are still in the mempool to be included,
+//  2. it checks that the confirmed propose transactions are still canonical,
+//  3. it looks for the propose transactions of the submitted blocks that were never confirmed.
+//
+// Blocks that end up confirmed in the journal are not proposed again, the others are.
+func (l *BatchSubmitter) reconcileJournal(ctx context.Context) error {
+	entries, err := l.Journal.Entries()
+	if err != nil {
+		return fmt.Errorf("reading journal: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := l.waitForMempool(ctx); err != nil {
+		return err
+	}
+
+	unconfirmed := make(map[uint64]*journal.Entry)
+	var from uint64
+	for i := range entries {
+		e := &entries[i]
+		if e.Confirmed() {
+			if err := l.checkConfirmed(ctx, e); err != nil {
+				return err
+			}
+			if e.Confirmed() {
+				continue
+			}
+		}
+		if len(unconfirmed) == 0 || e.SubmittedAt.Number < from {
+			from = e.SubmittedAt.Number
+		}
+		unconfirmed[e.Block.Number] = e
+	}
+	if len(unconfirmed) == 0 {
+		return nil
+	}
+
+	tip, err := l.l1Tip(ctx)
+	if err != nil {
+		return err
+	}
+	l.log.Info("looking for propose transactions of journaled blocks", "blocks", len(unconfirmed), "from", from, "to", tip.Number)
+	signer := types.LatestSignerForChainID(l.Rollup.L1ChainID)
+	for n := from; n <= tip.Number && len(unconfirmed) > 0; n++ {
+		block, err := l.l1BlockByNumber(ctx, n)
+		if err != nil {
+			return err
+		}
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != l.Rollup.DataStreamAddress {
+				continue
+			}
+			if sender, err := types.Sender(signer, tx); err != nil || sender != l.TxManager.From() {
+				continue
+			}
+			calls, err := derive.DecodeProposeCalls(l.Rollup, tx.Data())
+			if err != nil || uint64(calls[0].ChainID) != l.Rollup.L2ChainID.Uint64() {
+				continue
+			}
+			var proposed []*journal.Entry
+			for _, call := range calls {
+				if !call.BlockNumber.IsUint64() {
+					continue
+				}
+				if e, ok := unconfirmed[call.BlockNumber.Uint64()]; ok && e.Block.Hash == call.BlockHash {
+					proposed = append(proposed, e)
+				}
+			}
+			if len(proposed) == 0 {
+				continue
+			}
+			receipt, err := l.l1TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return err
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				continue
+			}
+			for _, e := range proposed {
+				e.TxHash = tx.Hash()
+				e.Inclusion = eth.BlockID{Hash: block.Hash(), Number: n}
+				if err := l.Journal.Put(*e); err != nil {
+					return fmt.Errorf("journaling confirmed block: %w", err)
+				}
+				delete(unconfirmed, e.Block.Number)
+				l.log.Info("found propose transaction of journaled block", "block", e.Block, "tx_hash", e.TxHash, "inclusion", e.Inclusion)
+			}
+		}
+	}
+	for _, e := range unconfirmed {
+		l.log.Info("journaled block was not proposed yet", "block", e.Block)
+	}
+	return nil
+}
+
+// waitForMempool waits until the mempool holds no more transactions of the batcher,
+// as they could still propose blocks that we are about to propose again.
+// The transactions that are still pending after MempoolTimeout are replaced, so that
+// a stuck or underpriced transaction of the previous run can't block the startup forever.
+func (l *BatchSubmitter) waitForMempool(ctx context.Context) error {
+	deadline := time.Now().Add(l.MempoolTimeout)
+	ticker := time.NewTicker(l.PollInterval)
+	defer ticker.Stop()
+	for {
+		tctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+		pending, err := l.L1Client.PendingNonceAt(tctx, l.TxManager.From())
+		if err != nil {
+			cancel()
+			return fmt.Errorf("getting pending nonce: %w", err)
+		}
+		latest, err := l.L1Client.NonceAt(tctx, l.TxManager.From(), nil)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("getting nonce: %w", err)
+		}
+		if pending <= latest {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return l.replaceStuckTxs(ctx, latest, pending)
+		}
+		l.log.Info("waiting for transactions of the previous run to leave the mempool", "pending_nonce", pending, "nonce", latest)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// replaceStuckTxs replaces the pending transactions with the nonces in [latest, pending) by
+// no-op transactions to the batcher itself. The TxManager assigns the nonces from the latest
+// block, and bumps the fees of the no-op transactions until they replace the stuck ones.
+// It returns once all of them are confirmed.
+func (l *BatchSubmitter) replaceStuckTxs(ctx context.Context, latest, pending uint64) error {
+	l.log.Warn("transactions of the previous run are stuck in the mempool, replacing them", "nonce", latest, "pending_nonce", pending)
+	from := l.TxManager.From()
+	results := make(chan error, pending-latest)
+	for n := latest; n < pending; n++ {
+		tx := l.TxManager.SendAsync(ctx, txmgr.TxCandidate{
+			To:       &from,
+			GasLimit: params.TxGas,
+		}, func(_ *types.Receipt, err error) {
+			results <- err
+		})
+		if tx == nil {
+			// the error was passed to the callback already
+			break
+		}
+		l.log.Info("replacing stuck transaction", "nonce", tx.Nonce(), "tx_hash", tx.Hash())
+	}
+	for n := latest; n < pending; n++ {
+		select {
+		case err := <-results:
+			if err != nil {
+				return fmt.Errorf("replacing stuck transactions: %w", err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// checkConfirmed checks that the propose transaction of the entry is still included on L1,
+// and clears the confirmation of the entry if it is not.
+func (l *BatchSubmitter) checkConfirmed(ctx context.Context, e *journal.Entry) error {
+	receipt, err := l.l1TransactionReceipt(ctx, e.TxHash)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return err
+	}
+	var inclusion eth.BlockID
+	if err == nil && receipt.Status == types.ReceiptStatusSuccessful {
+		inclusion = eth.BlockID{Hash: receipt.BlockHash, Number: receipt.BlockNumber.Uint64()}
+	}
+	if inclusion == e.Inclusion {
+		return nil
+	}
+	l.log.Warn("journaled propose transaction was reorged", "block", e.Block, "tx_hash", e.TxHash, "old_inclusion", e.Inclusion, "new_inclusion", inclusion)
+	e.Inclusion = inclusion
+	if inclusion == (eth.BlockID{}) {
+		e.TxHash = common.Hash{}
+	}
+	if err := l.Journal.Put(*e); err != nil {
+		return fmt.Errorf("journaling reorged block: %w", err)
+	}
+	return nil
+}
+
+func (l *BatchSubmitter) l1BlockByNumber(ctx context.Context, number uint64) (*types.Block, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+	defer cancel()
+	block, err := l.L1Client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("getting L1 block %d: %w", number, err)
+	}
+	return block, nil
+}
+
+func (l *BatchSubmitter) l1TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+	defer cancel()
+	receipt, err := l.L1Client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting receipt of L1 transaction %x: %w", txHash, err)
+	}
+	return receipt, nil
+}
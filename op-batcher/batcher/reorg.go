@@ -0,0 +1,82 @@
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// checkL1Reorgs checks that the inclusion blocks of the confirmed propose transactions are still
+// canonical at the given L1 tip. The blocks of the transactions whose inclusion block was reorged
+// out are proposed again. Transactions included at least L1FinalityDepth blocks below the tip
+// are no longer watched.
+//
+// If the tip extends the previously verified tip, only the inclusion blocks that were not verified
+// against it need to be checked.
+func (l *BatchSubmitter) checkL1Reorgs(ctx context.Context, tip eth.L1BlockRef) error {
+	extends := l.verifiedL1Tip != (eth.L1BlockRef{}) && (tip == l.verifiedL1Tip || tip.ParentHash == l.verifiedL1Tip.Hash)
+
+	verified := make(map[eth.BlockID]struct{})
+	for _, inclusion := range l.state.InclusionBlocks() {
+		if _, ok := l.verifiedInclusions[inclusion]; ok && extends {
+			verified[inclusion] = struct{}{}
+			continue
+		}
+		if inclusion.Number > tip.Number {
+			// the receipt was seen on a node ahead of the tip, it is checked against a later tip
+			l.log.Debug("inclusion block is ahead of the L1 tip", "inclusion", inclusion, "l1_tip", tip)
+			continue
+		}
+		canonical, err := l.isCanonical(ctx, tip, inclusion)
+		if err != nil {
+			return err
+		}
+		if !canonical {
+			l.log.Warn("L1 reorg of confirmed propose transactions", "inclusion", inclusion, "l1_tip", tip)
+			l.state.InclusionReorged(inclusion)
+			continue
+		}
+		verified[inclusion] = struct{}{}
+	}
+
+	if tip.Number >= l.L1FinalityDepth {
+		finalized := tip.Number - l.L1FinalityDepth
+		l.state.PruneConfirmed(finalized)
+		for inclusion := range verified {
+			if inclusion.Number <= finalized {
+				delete(verified, inclusion)
+			}
+		}
+	}
+	l.verifiedL1Tip = tip
+	l.verifiedInclusions = verified
+	return nil
+}
+
+// isCanonical returns whether the given L1 block, which must not be above the tip,
+// is part of the chain that ends at the given tip.
+func (l *BatchSubmitter) isCanonical(ctx context.Context, tip eth.L1BlockRef, block eth.BlockID) (bool, error) {
+	if block.Number == tip.Number {
+		return block.Hash == tip.Hash, nil
+	}
+	hash, err := l.l1HashByNumber(ctx, block.Number)
+	if err != nil {
+		return false, err
+	}
+	return block.Hash == hash, nil
+}
+
+// l1HashByNumber returns the hash of the canonical L1 block with the given number.
+func (l *BatchSubmitter) l1HashByNumber(ctx context.Context, number uint64) (common.Hash, error) {
+	tctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+	defer cancel()
+	header, err := l.L1Client.HeaderByNumber(tctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("getting L1 block %d: %w", number, err)
+	}
+	return header.Hash(), nil
+}
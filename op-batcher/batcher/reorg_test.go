@@ -0,0 +1,88 @@
+package batcher
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+// TestCheckL1Reorgs checks that the batcher proposes a block again when the L1 tip replaces
+// the inclusion block of its propose transaction, and stops watching it past the finality depth.
+func TestCheckL1Reorgs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlCrit)
+	blocks := randomL2Chain(rng, 2)
+
+	l := &BatchSubmitter{
+		Config: Config{log: logger, L1FinalityDepth: 10},
+		state:  newPlainBlockdataManager(logger, derive.PayloadVersionUncompressed, nil),
+	}
+	for _, b := range blocks {
+		require.NoError(t, l.state.AddL2Block(b))
+	}
+	parent := testutils.RandomBlockRef(rng)
+	tip := testutils.NextRandomRef(rng, parent)
+	data := requireTxData(t, l.state, parent.ID(), blocks[0])
+	l.state.TxConfirmed(data.id, common.Hash{0xaa}, tip.ID())
+	require.NoError(t, l.checkL1Reorgs(context.Background(), tip))
+	require.Equal(t, []eth.BlockID{tip.ID()}, l.state.InclusionBlocks())
+
+	// the same tip and its children do not need to be checked again
+	require.NoError(t, l.checkL1Reorgs(context.Background(), tip))
+	child := testutils.NextRandomRef(rng, tip)
+	require.NoError(t, l.checkL1Reorgs(context.Background(), child))
+	require.Equal(t, []eth.BlockID{tip.ID()}, l.state.InclusionBlocks())
+
+	// a tip that replaces the inclusion block
+	fork := testutils.NextRandomRef(rng, parent)
+	require.NoError(t, l.checkL1Reorgs(context.Background(), fork))
+	require.Empty(t, l.state.InclusionBlocks())
+	data = requireTxData(t, l.state, fork.ID(), blocks[0])
+
+	// the inclusion is no longer watched once it is final
+	l.state.TxConfirmed(data.id, common.Hash{0xbb}, fork.ID())
+	require.NoError(t, l.checkL1Reorgs(context.Background(), fork))
+	next := fork
+	for i := 0; i < 10; i++ {
+		require.Len(t, l.state.InclusionBlocks(), 1)
+		next = testutils.NextRandomRef(rng, next)
+		require.NoError(t, l.checkL1Reorgs(context.Background(), next))
+	}
+	require.Empty(t, l.state.InclusionBlocks())
+}
+
+// TestCheckL1ReorgsAheadOfTip checks that an inclusion block above a lagging L1 tip is not
+// treated as reorged out, and is checked against a later tip instead.
+func TestCheckL1ReorgsAheadOfTip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	logger := testlog.Logger(t, log.LvlCrit)
+	blocks := randomL2Chain(rng, 1)
+
+	l := &BatchSubmitter{
+		Config: Config{log: logger, L1FinalityDepth: 10},
+		state:  newPlainBlockdataManager(logger, derive.PayloadVersionUncompressed, nil),
+	}
+	require.NoError(t, l.state.AddL2Block(blocks[0]))
+	tip := testutils.RandomBlockRef(rng)
+	ahead := testutils.NextRandomRef(rng, tip)
+	data := requireTxData(t, l.state, tip.ID(), blocks[0])
+	l.state.TxConfirmed(data.id, common.Hash{0xaa}, ahead.ID())
+
+	require.NoError(t, l.checkL1Reorgs(context.Background(), tip))
+	require.Equal(t, []eth.BlockID{ahead.ID()}, l.state.InclusionBlocks())
+	require.Zero(t, l.state.PendingBlocks())
+	require.Empty(t, l.verifiedInclusions)
+
+	require.NoError(t, l.checkL1Reorgs(context.Background(), ahead))
+	require.Equal(t, []eth.BlockID{ahead.ID()}, l.state.InclusionBlocks())
+	require.Contains(t, l.verifiedInclusions, ahead.ID())
+}
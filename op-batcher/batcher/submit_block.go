@@ -0,0 +1,153 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+var (
+	// ErrBatcherNotRunning is returned for blocks submitted while the batcher is stopped.
+	ErrBatcherNotRunning = errors.New("batcher is not running")
+	// ErrBatcherNotReady is returned for blocks submitted before the batcher knows where the
+	// local L2 chain and its journal of proposed blocks stand.
+	ErrBatcherNotReady = errors.New("batcher is not ready to accept blocks")
+	// ErrUnlinkedBlock is returned for submitted blocks that do not extend the last stored block.
+	ErrUnlinkedBlock = errors.New("submitted block does not extend the last stored block")
+	// ErrInvalidBody is returned for submitted blocks whose body does not match the roots of their header.
+	ErrInvalidBody = errors.New("submitted block body does not match its header")
+)
+
+// submittedBlock is an L2 block that an external sequencer pushed to the batcher.
+// It is handled by the driver loop, which sends the outcome to errCh.
+type submittedBlock struct {
+	block *types.Block
+	errCh chan error
+}
+
+// SubmitBlock queues an L2 block that an external sequencer pushed to the batcher, to be
+// proposed on L1 along with the blocks loaded from the local L2 node. The block must extend the
+// last stored block, after the new local blocks are loaded, unless it was stored already.
+func (l *BatchSubmitter) SubmitBlock(ctx context.Context, block *types.Block) error {
+	if err := checkBlockBody(block); err != nil {
+		return err
+	}
+
+	l.mutex.Lock()
+	running, shutdownCtx := l.running, l.shutdownCtx
+	l.mutex.Unlock()
+	if !running {
+		return ErrBatcherNotRunning
+	}
+
+	req := submittedBlock{block: block, errCh: make(chan error, 1)}
+	select {
+	case l.submitCh <- req:
+	case <-shutdownCtx.Done():
+		return ErrBatcherNotRunning
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-req.errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// checkBlockBody checks that the transactions, uncles and withdrawals of the block match the roots
+// of its header, so that the block hash commits to the proposed block body.
+func checkBlockBody(block *types.Block) error {
+	header := block.Header()
+	if computed := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); computed != header.TxHash {
+		return fmt.Errorf("%w: computed transactions root %s, header has %s", ErrInvalidBody, computed, header.TxHash)
+	}
+	if computed := types.CalcUncleHash(block.Uncles()); computed != header.UncleHash {
+		return fmt.Errorf("%w: computed uncles hash %s, header has %s", ErrInvalidBody, computed, header.UncleHash)
+	}
+	if header.WithdrawalsHash == nil {
+		if block.Withdrawals() != nil {
+			return fmt.Errorf("%w: withdrawals without withdrawals root", ErrInvalidBody)
+		}
+	} else if computed := types.DeriveSha(block.Withdrawals(), trie.NewStackTrie(nil)); computed != *header.WithdrawalsHash {
+		return fmt.Errorf("%w: computed withdrawals root %s, header has %s", ErrInvalidBody, computed, *header.WithdrawalsHash)
+	}
+	return nil
+}
+
+// addSubmittedBlock loads the new blocks of the local L2 node, and then adds the submitted block to
+// the state if it extends the last stored block, so that blocks whose parent is a local L2 block are
+// linked through the local blocks loaded first. The local node does not need to have the submitted
+// block: it is kept across L2 reorgs of the local node until the L2 safe head reaches it.
+// Blocks that are already stored, or part of the local L2 chain, are not added again.
+func (l *BatchSubmitter) addSubmittedBlock(ctx context.Context, block *types.Block) error {
+	l.loadBlocksIntoState(ctx)
+	id := eth.ToBlockID(block)
+	if l.lastStoredBlock == (eth.BlockID{}) || id == l.lastStoredBlock || block.ParentHash() == l.lastStoredBlock.Hash {
+		return l.queueSubmittedBlock(block)
+	}
+	if block.NumberU64() <= l.lastStoredBlock.Number {
+		if l.state.HasBlock(id) {
+			return nil
+		}
+		local, err := l.isLocalBlock(ctx, id)
+		if err != nil {
+			return err
+		} else if local && l.submittedTip == (eth.BlockID{}) {
+			// loaded from the local node, or already safe
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: block %s has parent %s, last stored block is %s",
+		ErrUnlinkedBlock, id, block.ParentHash(), l.lastStoredBlock)
+}
+
+// isLocalBlock returns whether the local L2 node has the block at its height.
+func (l *BatchSubmitter) isLocalBlock(ctx context.Context, id eth.BlockID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+	defer cancel()
+	header, err := l.L2Client.HeaderByNumber(ctx, new(big.Int).SetUint64(id.Number))
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting local L2 block %d: %w", id.Number, err)
+	}
+	return header.Hash() == id.Hash, nil
+}
+
+// queueSubmittedBlock adds the submitted block to the state if it extends the last stored block.
+// Submitting the last stored block again is a no-op, so that external sequencers can retry.
+func (l *BatchSubmitter) queueSubmittedBlock(block *types.Block) error {
+	id := eth.ToBlockID(block)
+	if l.lastStoredBlock == (eth.BlockID{}) {
+		return ErrBatcherNotReady
+	}
+	if id == l.lastStoredBlock {
+		return nil
+	}
+	if block.ParentHash() != l.lastStoredBlock.Hash {
+		return fmt.Errorf("%w: block %s has parent %s, last stored block is %s",
+			ErrUnlinkedBlock, id, block.ParentHash(), l.lastStoredBlock)
+	}
+	l2ref, err := derive.L2BlockToBlockRef(block, &l.Rollup.Genesis)
+	if err != nil {
+		return fmt.Errorf("invalid submitted block: %w", err)
+	}
+	if err := l.state.AddL2Block(block); err != nil {
+		return fmt.Errorf("adding submitted L2 block to state: %w", err)
+	}
+	l.lastStoredBlock = id
+	l.submittedTip = id
+	l.log.Info("added submitted L2 block to local state", "block", id, "tx_count", len(block.Transactions()), "time", block.Time())
+	l.metr.RecordL2BlocksLoaded(l2ref)
+	return nil
+}
@@ -0,0 +1,224 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/journal"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// TestQueueSubmittedBlock checks that submitted blocks are only queued if they extend the last stored block.
+func TestQueueSubmittedBlock(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	log := testlog.Logger(t, log.LvlCrit)
+	blocks := randomL2Chain(rng, 3)
+
+	l := &BatchSubmitter{
+		Config: Config{
+			log:    log,
+			metr:   metrics.NoopMetrics,
+			Rollup: &rollup.Config{},
+		},
+		state: newPlainBlockdataManager(log, derive.PayloadVersionUncompressed, journal.NewMemJournal()),
+	}
+	require.ErrorIs(t, l.queueSubmittedBlock(blocks[1]), ErrBatcherNotReady)
+
+	l.lastStoredBlock = eth.ToBlockID(blocks[0])
+	require.ErrorIs(t, l.queueSubmittedBlock(blocks[2]), ErrUnlinkedBlock)
+	require.NoError(t, l.queueSubmittedBlock(blocks[1]))
+	require.Equal(t, eth.ToBlockID(blocks[1]), l.lastStoredBlock)
+
+	// submitting the last stored block again is a no-op
+	require.NoError(t, l.queueSubmittedBlock(blocks[1]))
+	require.NoError(t, l.queueSubmittedBlock(blocks[2]))
+	require.Equal(t, eth.ToBlockID(blocks[2]), l.lastStoredBlock)
+
+	requireTxData(t, l.state, eth.BlockID{Number: 100}, blocks[1])
+	requireTxData(t, l.state, eth.BlockID{Number: 101}, blocks[2])
+	_, err := l.state.TxData(eth.BlockID{Number: 102})
+	require.Error(t, err)
+}
+
+// fakeL2Node serves the L2 blocks and the sync status of the local node over an in-process RPC server.
+type fakeL2Node struct {
+	mu     sync.Mutex
+	blocks map[uint64]*types.Block
+	safe   *types.Block
+	unsafe *types.Block
+}
+
+// setChain replaces the blocks of the local node from the safe block up to the unsafe head.
+func (n *fakeL2Node) setChain(safe *types.Block, blocks ...*types.Block) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.blocks = map[uint64]*types.Block{safe.NumberU64(): safe}
+	for _, b := range blocks {
+		n.blocks[b.NumberU64()] = b
+	}
+	n.safe, n.unsafe = safe, safe
+	if len(blocks) > 0 {
+		n.unsafe = blocks[len(blocks)-1]
+	}
+}
+
+type fakeEthAPI struct{ n *fakeL2Node }
+
+func (api *fakeEthAPI) GetBlockByNumber(number hexutil.Uint64, fullTx bool) (map[string]interface{}, error) {
+	api.n.mu.Lock()
+	block, ok := api.n.blocks[uint64(number)]
+	api.n.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["transactions"] = block.Transactions()
+	fields["uncles"] = []common.Hash{}
+	return fields, nil
+}
+
+type fakeOptimismAPI struct{ n *fakeL2Node }
+
+func (api *fakeOptimismAPI) SyncStatus() (*eth.SyncStatus, error) {
+	api.n.mu.Lock()
+	defer api.n.mu.Unlock()
+	ref := func(b *types.Block) eth.L2BlockRef {
+		return eth.L2BlockRef{Hash: b.Hash(), Number: b.NumberU64(), ParentHash: b.ParentHash(), Time: b.Time()}
+	}
+	return &eth.SyncStatus{
+		HeadL1:   eth.L1BlockRef{Number: 1},
+		SafeL2:   ref(api.n.safe),
+		UnsafeL2: ref(api.n.unsafe),
+	}, nil
+}
+
+// newSubmitTestBatcher returns a batch submitter whose local L2 node is served by the fake node.
+func newSubmitTestBatcher(t *testing.T, node *fakeL2Node) *BatchSubmitter {
+	logger := testlog.Logger(t, log.LvlCrit)
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", &fakeEthAPI{node}))
+	require.NoError(t, srv.RegisterName("optimism", &fakeOptimismAPI{node}))
+	t.Cleanup(srv.Stop)
+	cl := rpc.DialInProc(srv)
+	t.Cleanup(cl.Close)
+	return &BatchSubmitter{
+		Config: Config{
+			log:            logger,
+			metr:           metrics.NoopMetrics,
+			Rollup:         &rollup.Config{},
+			L2Client:       ethclient.NewClient(cl),
+			RollupNode:     sources.NewRollupClient(client.NewBaseRPCClient(cl)),
+			NetworkTimeout: time.Second,
+		},
+		state: newPlainBlockdataManager(logger, derive.PayloadVersionUncompressed, journal.NewMemJournal()),
+	}
+}
+
+// childL2Block returns a block that extends the parent, and differs from the other children by its time.
+func childL2Block(parent *types.Block, timeDelta uint64) *types.Block {
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		Time:       parent.Time() + timeDelta,
+	}
+	return types.NewBlockWithHeader(header).WithBody(parent.Transactions(), nil)
+}
+
+// TestAddSubmittedBlockNotLocal checks that submitted blocks that the local node does not have are
+// queued once they extend the local chain, and are kept when the local chain does not extend them.
+func TestAddSubmittedBlockNotLocal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blocks := randomL2Chain(rng, 5)
+	node := &fakeL2Node{}
+	node.setChain(blocks[0], blocks[1], blocks[2])
+	l := newSubmitTestBatcher(t, node)
+	ctx := context.Background()
+
+	// the local blocks are loaded first, the submitted blocks extend them
+	require.NoError(t, l.addSubmittedBlock(ctx, blocks[3]))
+	require.NoError(t, l.addSubmittedBlock(ctx, blocks[4]))
+	require.Equal(t, eth.ToBlockID(blocks[4]), l.lastStoredBlock)
+	stored := []eth.BlockID{eth.ToBlockID(blocks[1]), eth.ToBlockID(blocks[2]), eth.ToBlockID(blocks[3]), eth.ToBlockID(blocks[4])}
+	require.Equal(t, stored, l.state.PendingBlockIDs())
+
+	// stored blocks can be submitted again, local blocks too
+	require.NoError(t, l.addSubmittedBlock(ctx, blocks[3]))
+	require.NoError(t, l.addSubmittedBlock(ctx, blocks[1]))
+
+	// blocks that do not extend the last stored block are rejected
+	require.ErrorIs(t, l.addSubmittedBlock(ctx, childL2Block(blocks[2], 3)), ErrUnlinkedBlock)
+	require.ErrorIs(t, l.addSubmittedBlock(ctx, childL2Block(childL2Block(blocks[4], 2), 2)), ErrUnlinkedBlock)
+
+	// the local node builds another chain on top of its head: the submitted blocks are kept
+	fork := []*types.Block{childL2Block(blocks[2], 3)}
+	for i := 0; i < 3; i++ {
+		fork = append(fork, childL2Block(fork[i], 2))
+	}
+	node.setChain(blocks[0], append([]*types.Block{blocks[1], blocks[2]}, fork...)...)
+	next := childL2Block(blocks[4], 2)
+	require.NoError(t, l.addSubmittedBlock(ctx, next))
+	require.Equal(t, eth.ToBlockID(next), l.lastStoredBlock)
+	require.Equal(t, append(stored, eth.ToBlockID(next)), l.state.PendingBlockIDs())
+
+	// once the local node picks up the submitted blocks, its new blocks are loaded again
+	after := childL2Block(next, 2)
+	node.setChain(blocks[0], blocks[1], blocks[2], blocks[3], blocks[4], next, after)
+	l.loadBlocksIntoState(ctx)
+	require.Equal(t, eth.ToBlockID(after), l.lastStoredBlock)
+
+	// when the safe head passes the submitted blocks, an L2 reorg of the local node clears the state
+	reorged := childL2Block(next, 3)
+	node.setChain(next, reorged, childL2Block(reorged, 2))
+	l.loadBlocksIntoState(ctx)
+	require.Equal(t, eth.BlockID{}, l.submittedTip)
+	require.Equal(t, eth.BlockID{}, l.lastStoredBlock)
+}
+
+// TestCheckBlockBody checks that submitted blocks are rejected if their body does not match the roots of their header.
+func TestCheckBlockBody(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	block, _ := derivetest.RandomL2Block(rng, 2)
+	require.NoError(t, checkBlockBody(block))
+
+	noTxs := types.NewBlockWithHeader(block.Header()).WithBody(nil, nil)
+	require.ErrorIs(t, checkBlockBody(noTxs), ErrInvalidBody)
+
+	uncles := block.WithBody(block.Transactions(), []*types.Header{block.Header()})
+	require.ErrorIs(t, checkBlockBody(uncles), ErrInvalidBody)
+
+	withdrawals := block.WithWithdrawals(types.Withdrawals{{Index: 1, Amount: 1}})
+	require.ErrorIs(t, checkBlockBody(withdrawals), ErrInvalidBody)
+
+	header := block.Header()
+	header.WithdrawalsHash = &types.EmptyWithdrawalsHash
+	require.ErrorIs(t, checkBlockBody(types.NewBlockWithHeader(header).WithBody(block.Transactions(), nil).WithWithdrawals(withdrawals.Withdrawals())), ErrInvalidBody)
+	require.NoError(t, checkBlockBody(types.NewBlockWithHeader(header).WithBody(block.Transactions(), nil).WithWithdrawals(types.Withdrawals{})))
+}
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli"
 
@@ -83,6 +84,40 @@ var (
 		Usage:  "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
 		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "STOPPED"),
 	}
+	JournalDirFlag = cli.StringFlag{
+		Name:   "journal-dir",
+		Usage:  "Directory of the journal of proposed blocks, used to resume without duplicate proposals after a restart. Disabled if empty",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "JOURNAL_DIR"),
+	}
+	L1FinalityDepthFlag = cli.Uint64Flag{
+		Name:   "l1-finality-depth",
+		Usage:  "Number of L1 blocks after which a confirmed propose transaction is no longer watched for L1 reorgs",
+		Value:  64,
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "L1_FINALITY_DEPTH"),
+	}
+	MempoolTimeoutFlag = cli.DurationFlag{
+		Name:   "mempool-timeout",
+		Usage:  "How long to wait at startup for the transactions of the previous run to be included, before replacing the stuck ones",
+		Value:  10 * time.Minute,
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "MEMPOOL_TIMEOUT"),
+	}
+	MaxPendingTransactionsFlag = cli.Uint64Flag{
+		Name:   "max-pending-tx",
+		Usage:  "The maximum number of propose transactions in flight at the same time",
+		Value:  1,
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "MAX_PENDING_TX"),
+	}
+	ProposeRangeFlag = cli.BoolFlag{
+		Name:   "propose-range",
+		Usage:  "Propose contiguous L2 blocks together in a single L1 transaction of at most max-l1-tx-size-bytes",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "PROPOSE_RANGE"),
+	}
+	CompressionFlag = cli.StringFlag{
+		Name:   "compression",
+		Usage:  "The compression of the batches of the proposed blocks: none, zlib or brotli",
+		Value:  "none",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "COMPRESSION"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -102,6 +137,12 @@ var optionalFlags = []cli.Flag{
 	TargetNumFramesFlag,
 	ApproxComprRatioFlag,
 	StoppedFlag,
+	JournalDirFlag,
+	L1FinalityDepthFlag,
+	MempoolTimeoutFlag,
+	MaxPendingTransactionsFlag,
+	ProposeRangeFlag,
+	CompressionFlag,
 }
 
 func init() {
@@ -0,0 +1,143 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const entryExt = ".json"
+
+// DiskJournal is a disk-backed journal, every entry is a JSON file named after its L2 block number.
+// Entries are written to a temporary file that is synced and then renamed, so that a crash never
+// leaves a partially written entry behind.
+// DiskJournal is safe for concurrent use with a single DiskJournal instance.
+// DiskJournal is not safe for concurrent use between different DiskJournal instances of the same disk directory.
+type DiskJournal struct {
+	sync.RWMutex
+	path string
+}
+
+var _ Journal = (*DiskJournal)(nil)
+
+// NewDiskJournal creates a DiskJournal that puts/gets entries as files in the given directory path.
+// The path must exist, or subsequent calls will error when it does not.
+func NewDiskJournal(path string) *DiskJournal {
+	return &DiskJournal{path: path}
+}
+
+func (d *DiskJournal) pathKey(number uint64) string {
+	return path.Join(d.path, strconv.FormatUint(number, 10)+entryExt)
+}
+
+func (d *DiskJournal) Put(e Entry) error {
+	d.Lock()
+	defer d.Unlock()
+	dat, err := json.Marshal(&e)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry %d: %w", e.Block.Number, err)
+	}
+	f, err := os.CreateTemp(d.path, "tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create journal entry file %d: %w", e.Block.Number, err)
+	}
+	tmp := f.Name()
+	if _, err := f.Write(dat); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write journal entry %d to disk: %w", e.Block.Number, err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to sync journal entry %d to disk: %w", e.Block.Number, err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to close journal entry %d file: %w", e.Block.Number, err)
+	}
+	if err := os.Rename(tmp, d.pathKey(e.Block.Number)); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to move journal entry %d into place: %w", e.Block.Number, err)
+	}
+	return d.syncDir()
+}
+
+// syncDir makes the creation, renaming and removal of entry files durable.
+func (d *DiskJournal) syncDir() error {
+	dir, err := os.Open(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to open journal dir: %w", err)
+	}
+	defer dir.Close() // fine to ignore closing error here
+	if err := dir.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal dir: %w", err)
+	}
+	return nil
+}
+
+func (d *DiskJournal) Get(number uint64) (Entry, error) {
+	d.RLock()
+	defer d.RUnlock()
+	return d.get(d.pathKey(number))
+}
+
+func (d *DiskJournal) get(file string) (Entry, error) {
+	dat, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Entry{}, ErrNotFound
+		}
+		return Entry{}, fmt.Errorf("failed to read journal entry file %s: %w", file, err)
+	}
+	var e Entry
+	if err := json.Unmarshal(dat, &e); err != nil {
+		return Entry{}, fmt.Errorf("failed to decode journal entry file %s: %w", file, err)
+	}
+	return e, nil
+}
+
+func (d *DiskJournal) Delete(number uint64) error {
+	d.Lock()
+	defer d.Unlock()
+	if err := os.Remove(d.pathKey(number)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to remove journal entry %d: %w", number, err)
+	}
+	return d.syncDir()
+}
+
+func (d *DiskJournal) Entries() ([]Entry, error) {
+	d.RLock()
+	defer d.RUnlock()
+	files, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal dir: %w", err)
+	}
+	var out []Entry
+	for _, f := range files {
+		name := f.Name()
+		// skip temporary files that were left behind by a crash
+		if f.IsDir() || !strings.HasSuffix(name, entryExt) {
+			continue
+		}
+		if _, err := strconv.ParseUint(strings.TrimSuffix(name, entryExt), 10, 64); err != nil {
+			continue
+		}
+		e, err := d.get(path.Join(d.path, name))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Block.Number < out[j].Block.Number })
+	return out, nil
+}
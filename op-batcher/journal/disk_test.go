@@ -0,0 +1,29 @@
+package journal
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+func TestDiskJournal(t *testing.T) {
+	tmp := t.TempDir() // automatically removed by testing cleanup
+	journalTest(t, NewDiskJournal(tmp))
+}
+
+func TestDiskJournalReopen(t *testing.T) {
+	tmp := t.TempDir()
+	e := Entry{Block: eth.BlockID{Number: 42}}
+	require.NoError(t, NewDiskJournal(tmp).Put(e))
+
+	// a temporary file of an interrupted write is ignored
+	require.NoError(t, os.WriteFile(path.Join(tmp, "tmp-123"), []byte("{"), 0644))
+
+	entries, err := NewDiskJournal(tmp).Entries()
+	require.NoError(t, err)
+	require.Equal(t, []Entry{e}, entries)
+}
@@ -0,0 +1,48 @@
+package journal
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// ErrNotFound is returned when no entry exists for an L2 block number.
+var ErrNotFound = errors.New("not found")
+
+// Entry records the state of the propose transaction of a single L2 block.
+type Entry struct {
+	// Block is the proposed L2 block.
+	Block eth.BlockID `json:"block"`
+	// SubmittedAt is the L1 head at the time the block was first submitted.
+	// Propose transactions of the block can only be included after it.
+	SubmittedAt eth.BlockID `json:"submittedAt"`
+	// TxHash is the hash of the propose transaction, zero until it is confirmed.
+	TxHash common.Hash `json:"txHash"`
+	// Inclusion is the L1 block that included the propose transaction, zero until it is confirmed.
+	Inclusion eth.BlockID `json:"inclusion"`
+}
+
+// Confirmed returns whether the propose transaction of the block was included on L1.
+func (e *Entry) Confirmed() bool {
+	return e.Inclusion != (eth.BlockID{})
+}
+
+// Journal records the propose transactions of the batcher, keyed by L2 block number,
+// so that they can be reconciled with L1 after a restart.
+type Journal interface {
+	// Put stores the entry, replacing any entry of the same L2 block number.
+	// The entry must be durable once Put returns.
+	Put(e Entry) error
+
+	// Get retrieves the entry of the given L2 block number.
+	// It returns ErrNotFound when there is no such entry.
+	Get(number uint64) (Entry, error)
+
+	// Delete removes the entry of the given L2 block number, if any.
+	Delete(number uint64) error
+
+	// Entries returns all entries, ordered by L2 block number.
+	Entries() ([]Entry, error)
+}
@@ -0,0 +1,52 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+func journalTest(t *testing.T, j Journal) {
+	submitted := Entry{
+		Block:       eth.BlockID{Hash: common.Hash{0xaa}, Number: 10},
+		SubmittedAt: eth.BlockID{Hash: common.Hash{0x01}, Number: 100},
+	}
+	confirmed := submitted
+	confirmed.TxHash = common.Hash{0xbb}
+	confirmed.Inclusion = eth.BlockID{Hash: common.Hash{0x02}, Number: 101}
+
+	_, err := j.Get(10)
+	require.ErrorIs(t, err, ErrNotFound, "entry does not exist yet")
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.NoError(t, j.Put(submitted))
+	e, err := j.Get(10)
+	require.NoError(t, err, "entry must exist now")
+	require.Equal(t, submitted, e)
+	require.False(t, e.Confirmed())
+
+	require.NoError(t, j.Put(confirmed), "entries can be overwritten")
+	e, err = j.Get(10)
+	require.NoError(t, err)
+	require.Equal(t, confirmed, e)
+	require.True(t, e.Confirmed())
+
+	other := Entry{Block: eth.BlockID{Hash: common.Hash{0xcc}, Number: 9}}
+	require.NoError(t, j.Put(other))
+	entries, err = j.Entries()
+	require.NoError(t, err)
+	require.Equal(t, []Entry{other, confirmed}, entries, "entries are ordered by block number")
+
+	require.NoError(t, j.Delete(10))
+	require.NoError(t, j.Delete(10), "deleting a missing entry is not an error")
+	_, err = j.Get(10)
+	require.ErrorIs(t, err, ErrNotFound)
+	entries, err = j.Entries()
+	require.NoError(t, err)
+	require.Equal(t, []Entry{other}, entries)
+}
@@ -0,0 +1,55 @@
+package journal
+
+import (
+	"sort"
+	"sync"
+)
+
+// MemJournal implements the Journal interface in memory, backed by a regular Go map.
+// This should only be used in testing, as the entries do not survive a restart.
+// MemJournal is safe for concurrent use.
+type MemJournal struct {
+	sync.RWMutex
+	m map[uint64]Entry
+}
+
+var _ Journal = (*MemJournal)(nil)
+
+func NewMemJournal() *MemJournal {
+	return &MemJournal{m: make(map[uint64]Entry)}
+}
+
+func (m *MemJournal) Put(e Entry) error {
+	m.Lock()
+	defer m.Unlock()
+	m.m[e.Block.Number] = e
+	return nil
+}
+
+func (m *MemJournal) Get(number uint64) (Entry, error) {
+	m.RLock()
+	defer m.RUnlock()
+	e, ok := m.m[number]
+	if !ok {
+		return Entry{}, ErrNotFound
+	}
+	return e, nil
+}
+
+func (m *MemJournal) Delete(number uint64) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.m, number)
+	return nil
+}
+
+func (m *MemJournal) Entries() ([]Entry, error) {
+	m.RLock()
+	defer m.RUnlock()
+	out := make([]Entry, 0, len(m.m))
+	for _, e := range m.m {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Block.Number < out[j].Block.Number })
+	return out, nil
+}
@@ -0,0 +1,7 @@
+package journal
+
+import "testing"
+
+func TestMemJournal(t *testing.T) {
+	journalTest(t, NewMemJournal())
+}
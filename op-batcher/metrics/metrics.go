@@ -38,6 +38,10 @@ type Metricer interface {
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
+	RecordInflightTxs(count int)
+	RecordBacklogBlocks(count int)
+	RecordBlocksCompressed(inputBytes, outputComprBytes int)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -68,6 +72,9 @@ type Metrics struct {
 	ChannelOutputBytesTotal prometheus.Counter
 
 	BatcherTxEvs opmetrics.EventVec
+
+	InflightTxs   prometheus.Gauge
+	BacklogBlocks prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -158,6 +165,17 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		BatcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		InflightTxs: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "inflight_txs",
+			Help:      "Number of propose transactions sent and not yet confirmed or failed.",
+		}),
+		BacklogBlocks: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "backlog_blocks",
+			Help:      "Number of loaded L2 blocks that are not proposed yet.",
+		}),
 	}
 }
 
@@ -267,3 +285,21 @@ func (m *Metrics) RecordBatchTxSuccess() {
 func (m *Metrics) RecordBatchTxFailed() {
 	m.BatcherTxEvs.Record(TxStageFailed)
 }
+
+func (m *Metrics) RecordInflightTxs(count int) {
+	m.InflightTxs.Set(float64(count))
+}
+
+func (m *Metrics) RecordBacklogBlocks(count int) {
+	m.BacklogBlocks.Set(float64(count))
+}
+
+// RecordBlocksCompressed should be called when the batches of loaded L2 blocks
+// were encoded into their payload, with their total size before and after compression.
+func (m *Metrics) RecordBlocksCompressed(inputBytes, outputComprBytes int) {
+	m.ChannelInputBytesTotal.Add(float64(inputBytes))
+	m.ChannelOutputBytesTotal.Add(float64(outputComprBytes))
+	if inputBytes > 0 {
+		m.ChannelComprRatio.Observe(float64(outputComprBytes) / float64(inputBytes))
+	}
+}
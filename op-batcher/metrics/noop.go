@@ -32,3 +32,7 @@ func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
 func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
+
+func (*noopMetrics) RecordInflightTxs(int)           {}
+func (*noopMetrics) RecordBacklogBlocks(int)         {}
+func (*noopMetrics) RecordBlocksCompressed(int, int) {}
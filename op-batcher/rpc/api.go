@@ -2,11 +2,35 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
 )
 
 type batcherClient interface {
 	Start() error
 	Stop(ctx context.Context) error
+	PendingBlocks(ctx context.Context) ([]eth.BlockID, error)
+	InflightTxs(ctx context.Context) ([]InflightTx, error)
+	DropBefore(ctx context.Context, number uint64) (int, error)
+	Resubmit(ctx context.Context, number uint64) error
+}
+
+// InflightTx is a proposed block whose propose transaction is not confirmed yet.
+// The transaction is identified by its sender and nonce, since gas price bumps replace
+// it with transactions of the same nonce and gas limit, but a different hash.
+type InflightTx struct {
+	Block eth.BlockID `json:"block"`
+	// TxHash is the hash of the latest published transaction, it changes with gas price bumps.
+	TxHash common.Hash    `json:"txHash"`
+	From   common.Address `json:"from"`
+	Nonce  hexutil.Uint64 `json:"nonce"`
+	Gas    hexutil.Uint64 `json:"gas"`
 }
 
 type adminAPI struct {
@@ -26,3 +50,49 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.Stop(ctx)
 }
+
+// PendingBlocks returns the loaded blocks that are not proposed yet.
+func (a *adminAPI) PendingBlocks(ctx context.Context) ([]eth.BlockID, error) {
+	return a.b.PendingBlocks(ctx)
+}
+
+// InflightTxs returns the proposed blocks whose propose transactions are not confirmed yet.
+func (a *adminAPI) InflightTxs(ctx context.Context) ([]InflightTx, error) {
+	return a.b.InflightTxs(ctx)
+}
+
+// DropBefore drops the pending blocks below the given block number, and returns how many were dropped.
+func (a *adminAPI) DropBefore(ctx context.Context, number hexutil.Uint64) (int, error) {
+	return a.b.DropBefore(ctx, uint64(number))
+}
+
+// Resubmit proposes the confirmed block with the given number again.
+func (a *adminAPI) Resubmit(ctx context.Context, number hexutil.Uint64) error {
+	return a.b.Resubmit(ctx, uint64(number))
+}
+
+type blockSubmitter interface {
+	SubmitBlock(ctx context.Context, block *types.Block) error
+}
+
+// seqsyAPI lets an external shared sequencer push the L2 blocks it produced to the batcher,
+// which proposes them on L1.
+type seqsyAPI struct {
+	b blockSubmitter
+}
+
+func NewSeqsyAPI(b blockSubmitter) *seqsyAPI {
+	return &seqsyAPI{
+		b: b,
+	}
+}
+
+// SubmitBlock queues the RLP-encoded L2 block to be proposed on L1. The block must extend the
+// last block that the batcher stored; the local node does not need to have it.
+func (a *seqsyAPI) SubmitBlock(ctx context.Context, data hexutil.Bytes) error {
+	var block types.Block
+	if err := rlp.DecodeBytes(data, &block); err != nil {
+		return fmt.Errorf("invalid block encoding: %w", err)
+	}
+	return a.b.SubmitBlock(ctx, &block)
+}
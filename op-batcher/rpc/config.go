@@ -1,6 +1,12 @@
 package rpc
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -8,7 +14,9 @@ import (
 )
 
 const (
-	EnableAdminFlagName = "rpc.enable-admin"
+	EnableAdminFlagName    = "rpc.enable-admin"
+	EnableSeqsyFlagName    = "rpc.enable-seqsy"
+	SeqsyJWTSecretFlagName = "rpc.seqsy-jwt-secret"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -18,17 +26,55 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:  "Enable the admin API (experimental)",
 			EnvVar: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
 		},
+		cli.BoolFlag{
+			Name:   EnableSeqsyFlagName,
+			Usage:  "Enable the seqsy API, through which an external sequencer submits the L2 blocks to propose. Requires " + SeqsyJWTSecretFlagName,
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_SEQSY"),
+		},
+		cli.StringFlag{
+			Name:   SeqsyJWTSecretFlagName,
+			Usage:  "Path to the JWT secret (32 hex-encoded bytes) that authenticates all RPC requests when the seqsy API is enabled",
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "RPC_SEQSY_JWT_SECRET"),
+		},
 	}
 }
 
 type CLIConfig struct {
 	oprpc.CLIConfig
 	EnableAdmin bool
+	EnableSeqsy bool
+	// SeqsyJWTSecret is the path to the JWT secret of the RPC server, required by the seqsy API.
+	SeqsyJWTSecret string
+}
+
+func (c CLIConfig) Check() error {
+	if err := c.CLIConfig.Check(); err != nil {
+		return err
+	}
+	if c.EnableSeqsy && c.SeqsyJWTSecret == "" {
+		return errors.New("the seqsy API requires a JWT secret")
+	}
+	return nil
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		CLIConfig:   oprpc.ReadCLIConfig(ctx),
-		EnableAdmin: ctx.GlobalBool(EnableAdminFlagName),
+		CLIConfig:      oprpc.ReadCLIConfig(ctx),
+		EnableAdmin:    ctx.GlobalBool(EnableAdminFlagName),
+		EnableSeqsy:    ctx.GlobalBool(EnableSeqsyFlagName),
+		SeqsyJWTSecret: ctx.GlobalString(SeqsyJWTSecretFlagName),
+	}
+}
+
+// ReadJWTSecret reads the hex-encoded 32 bytes JWT secret at the given path.
+func ReadJWTSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWT secret: %w", err)
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("invalid JWT secret in path %s, not 32 hex-formatted bytes", path)
 	}
+	return secret, nil
 }
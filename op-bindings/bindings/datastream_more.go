@@ -0,0 +1,12 @@
+package bindings
+
+// DataStreamDeployedBin is hand-assembled runtime code for contracts/L1/DataStream.sol.
+// It is not solc output and has no metadata or storage layout: it only matches the
+// contract's ABI and behaviour, which TestL1DeveloperGenesisDataStream checks. Reverts
+// carry no reason strings. Replace this file with the output of
+// `make data-stream-bindings more` once the contract is compiled.
+var DataStreamDeployedBin = "0x3461003457600436106100345760003560e01c806374123bf9146100395780637abddb0f1461004c57806327c503f1146100d1575b600080fd5b506084361061003457600160243561007b565b506084361061003457604435600401356064356004013581141561003457801561003457803610610034576024355b6004358063ffffffff106100345733600052600060205260406000206020526000526040600020805480156100b557806001018311610034575b8383018381106100345760019003808210156100cf578083555b005b5060443610610034576004358073ffffffffffffffffffffffffffffffffffffffff1061003457600052600060205260406000206020526024358063ffffffff106100345760005260406000205460005260206000f3"
+
+func init() {
+	deployedBytecodes["DataStream"] = DataStreamDeployedBin
+}
@@ -41,6 +41,9 @@ type DeployConfig struct {
 	P2PSequencerAddress       common.Address `json:"p2pSequencerAddress"`
 	BatchInboxAddress         common.Address `json:"batchInboxAddress"`
 	BatchSenderAddress        common.Address `json:"batchSenderAddress"`
+	DataStreamAddress         common.Address `json:"dataStreamAddress"`
+	ProposeSelector           eth.Bytes4     `json:"proposeSelector"`
+	ProposeRangeSelector      eth.Bytes4     `json:"proposeRangeSelector"`
 
 	L2OutputOracleSubmissionInterval uint64         `json:"l2OutputOracleSubmissionInterval"`
 	L2OutputOracleStartingTimestamp  int            `json:"l2OutputOracleStartingTimestamp"`
@@ -151,6 +154,12 @@ func (d *DeployConfig) Check() error {
 	if d.BatchSenderAddress == (common.Address{}) {
 		return fmt.Errorf("%w: BatchSenderAddress cannot be address(0)", ErrInvalidDeployConfig)
 	}
+	if d.DataStreamAddress == (common.Address{}) {
+		return fmt.Errorf("%w: DataStreamAddress cannot be address(0)", ErrInvalidDeployConfig)
+	}
+	if d.ProposeSelector == (eth.Bytes4{}) {
+		return fmt.Errorf("%w: ProposeSelector cannot be empty", ErrInvalidDeployConfig)
+	}
 	if d.L2OutputOracleSubmissionInterval == 0 {
 		return fmt.Errorf("%w: L2OutputOracleSubmissionInterval cannot be 0", ErrInvalidDeployConfig)
 	}
@@ -344,6 +353,9 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		BatchInboxAddress:      d.BatchInboxAddress,
 		DepositContractAddress: d.OptimismPortalProxy,
 		L1SystemConfigAddress:  d.SystemConfigProxy,
+		DataStreamAddress:      d.DataStreamAddress,
+		ProposeSelector:        d.ProposeSelector,
+		ProposeRangeSelector:   d.ProposeRangeSelector,
 		RegolithTime:           d.RegolithTime(l1StartBlock.Time()),
 	}, nil
 }
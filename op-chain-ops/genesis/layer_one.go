@@ -225,6 +225,15 @@ func BuildL1DeveloperGenesis(config *DeployConfig) (*core.Genesis, error) {
 	FundDevAccounts(memDB)
 	SetPrecompileBalances(memDB)
 
+	// The batcher proposes L2 blocks to the data stream contract, it has no constructor
+	// so its runtime code is set directly.
+	dataStreamCode, err := bindings.GetDeployedBytecode("DataStream")
+	if err != nil {
+		return nil, err
+	}
+	memDB.CreateAccount(config.DataStreamAddress)
+	memDB.SetCode(config.DataStreamAddress, dataStreamCode)
+
 	for name, proxyAddr := range predeploys.DevPredeploys {
 		memDB.SetState(*proxyAddr, ImplementationSlot, depsByName[name].Address.Hash())
 
@@ -2,6 +2,8 @@ package genesis
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"math/big"
 	"os"
@@ -14,8 +16,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/deployer"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
@@ -136,3 +140,120 @@ func TestBuildL1DeveloperGenesis(t *testing.T) {
 	_, err = bridge.DepositETH(tOpts, 200000, nil)
 	require.NoError(t, err)
 }
+
+// TestL1DeveloperGenesisDataStream checks that the data stream contract is predeployed
+// and that it accepts and rejects propose calls on-chain.
+func TestL1DeveloperGenesisDataStream(t *testing.T) {
+	b, err := os.ReadFile("testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	config := new(DeployConfig)
+	require.NoError(t, json.NewDecoder(bytes.NewReader(b)).Decode(config))
+	config.L1GenesisBlockTimestamp = hexutil.Uint64(time.Now().Unix() - 100)
+
+	genesis, err := BuildL1DeveloperGenesis(config)
+	require.NoError(t, err)
+	dataStreamCode, err := bindings.GetDeployedBytecode("DataStream")
+	require.NoError(t, err)
+	require.Equal(t, dataStreamCode, genesis.Alloc[config.DataStreamAddress].Code)
+
+	sim := backends.NewSimulatedBackend(genesis.Alloc, 15000000)
+	priv, err := crypto.HexToECDSA("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	require.NoError(t, err)
+	other, err := crypto.HexToECDSA("59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d")
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	signer := types.LatestSignerForChainID(sim.Blockchain().Config().ChainID)
+	ctx := context.Background()
+
+	sendFrom := func(priv *ecdsa.PrivateKey, data []byte, value *big.Int) uint64 {
+		nonce, err := sim.PendingNonceAt(ctx, crypto.PubkeyToAddress(priv.PublicKey))
+		require.NoError(t, err)
+		tx := types.MustSignNewTx(priv, signer, &types.DynamicFeeTx{
+			ChainID:   sim.Blockchain().Config().ChainID,
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: big.NewInt(100 * params.GWei),
+			Gas:       500_000,
+			To:        &config.DataStreamAddress,
+			Value:     value,
+			Data:      data,
+		})
+		require.NoError(t, sim.SendTransaction(ctx, tx))
+		sim.Commit()
+		receipt, err := sim.TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		return receipt.Status
+	}
+	send := func(data []byte, value *big.Int) uint64 {
+		return sendFrom(priv, data, value)
+	}
+	proposeBig := func(chainID uint32, number *big.Int) []byte {
+		data, err := derive.EncodeProposeCall(config.ProposeSelector, &derive.ProposeCall{
+			ChainID:     chainID,
+			BlockNumber: number,
+			BlockHash:   common.Hash{0xaa},
+			Block:       []byte{0x01, 0x02},
+		})
+		require.NoError(t, err)
+		return data
+	}
+	propose := func(chainID uint32, number uint64) []byte {
+		return proposeBig(chainID, new(big.Int).SetUint64(number))
+	}
+	proposeRange := func(chainID uint32, first uint64, hashes []common.Hash, blocks [][]byte) []byte {
+		data, err := derive.EncodeProposeRangeCall(config.ProposeRangeSelector, &derive.ProposeRangeCall{
+			ChainID:          chainID,
+			FirstBlockNumber: new(big.Int).SetUint64(first),
+			BlockHashes:      hashes,
+			Blocks:           blocks,
+		})
+		require.NoError(t, err)
+		return data
+	}
+	caller, err := bindings.NewDataStreamCaller(config.DataStreamAddress, sim)
+	require.NoError(t, err)
+	latestOf := func(addr common.Address, chainID uint32) *big.Int {
+		out, err := caller.LatestBlockNumber(&bind.CallOpts{}, addr, chainID)
+		require.NoError(t, err)
+		return out
+	}
+	latest := func(chainID uint32) uint64 {
+		return latestOf(sender, chainID).Uint64()
+	}
+
+	require.EqualValues(t, types.ReceiptStatusSuccessful, send(propose(902, 1), nil))
+	require.EqualValues(t, 1, latest(902))
+	require.EqualValues(t, types.ReceiptStatusSuccessful, send(propose(902, 2), nil))
+	require.EqualValues(t, types.ReceiptStatusSuccessful, send(propose(902, 1), nil), "blocks may be proposed again")
+	require.EqualValues(t, 2, latest(902))
+	require.EqualValues(t, types.ReceiptStatusFailed, send(propose(902, 4), nil), "gap after block 2")
+	require.EqualValues(t, types.ReceiptStatusFailed, send(propose(902, 3), big.NewInt(1)), "value is rejected")
+
+	hashes := []common.Hash{{0x03}, {0x04}, {0x05}}
+	blocks := [][]byte{{0x03}, {0x04}, {0x05}}
+	require.EqualValues(t, types.ReceiptStatusSuccessful, send(proposeRange(902, 3, hashes, blocks), nil))
+	require.EqualValues(t, 5, latest(902))
+	require.EqualValues(t, types.ReceiptStatusFailed, send(proposeRange(902, 6, hashes[:2], blocks), nil), "mismatched range")
+	require.EqualValues(t, types.ReceiptStatusFailed, send(proposeRange(902, 6, nil, nil), nil), "empty range")
+	require.EqualValues(t, types.ReceiptStatusFailed, send(proposeRange(902, 7, hashes, blocks), nil), "gap after block 5")
+
+	// chains are tracked separately, the first proposal of a chain may start anywhere
+	require.EqualValues(t, types.ReceiptStatusSuccessful, send(propose(903, 100), nil))
+	require.EqualValues(t, 100, latest(903))
+	require.EqualValues(t, 5, latest(902))
+
+	// senders are tracked separately, other senders do not lift the gap check
+	require.EqualValues(t, types.ReceiptStatusSuccessful, sendFrom(other, propose(902, 1000), nil))
+	require.EqualValues(t, 1000, latestOf(crypto.PubkeyToAddress(other.PublicKey), 902).Uint64())
+	require.EqualValues(t, 5, latest(902))
+	require.EqualValues(t, types.ReceiptStatusFailed, send(propose(902, 7), nil), "gap after block 5 of the sender")
+
+	// the last block must be below the maximum block number, so that it can be followed
+	maxNumber := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+	require.EqualValues(t, types.ReceiptStatusFailed, send(proposeBig(904, maxNumber), nil), "block number overflow")
+	require.EqualValues(t, types.ReceiptStatusSuccessful, send(proposeBig(904, new(big.Int).Sub(maxNumber, common.Big1)), nil))
+	require.Equal(t, new(big.Int).Sub(maxNumber, common.Big1), latestOf(sender, 904))
+
+	require.EqualValues(t, types.ReceiptStatusFailed, send([]byte{0xde, 0xad, 0xbe, 0xef}, nil), "unknown selector")
+	require.EqualValues(t, types.ReceiptStatusFailed, send(propose(902, 6)[:0x80], nil), "truncated calldata")
+}
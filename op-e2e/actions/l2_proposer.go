@@ -47,6 +47,12 @@ func (f fakeTxMgr) From() common.Address {
 func (f fakeTxMgr) Send(_ context.Context, _ txmgr.TxCandidate) (*types.Receipt, error) {
 	panic("unimplemented")
 }
+func (f fakeTxMgr) SendAsync(_ context.Context, _ txmgr.TxCandidate, _ func(*types.Receipt, error)) *types.Transaction {
+	panic("unimplemented")
+}
+func (f fakeTxMgr) LatestTxHash(_ uint64) (common.Hash, bool) {
+	panic("unimplemented")
+}
 
 func NewL2Proposer(t Testing, log log.Logger, cfg *ProposerCfg, l1 *ethclient.Client, rollupCl *sources.RollupClient) *L2Proposer {
 
@@ -67,6 +67,8 @@ func MakeDeployParams(t require.TestingT, tp *TestParams) *DeployParams {
 		P2PSequencerAddress: addresses.SequencerP2P,
 		BatchInboxAddress:   common.Address{0: 0x42, 19: 0xff}, // tbd
 		BatchSenderAddress:  addresses.Batcher,
+		DataStreamAddress:   common.Address{0: 0x43, 19: 0xff}, // tbd
+		ProposeSelector:     eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
 
 		L2OutputOracleSubmissionInterval: 6,
 		L2OutputOracleStartingTimestamp:  -1,
@@ -214,6 +216,8 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		BatchInboxAddress:      deployConf.BatchInboxAddress,
 		DepositContractAddress: predeploys.DevOptimismPortalAddr,
 		L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
+		DataStreamAddress:      deployConf.DataStreamAddress,
+		ProposeSelector:        deployConf.ProposeSelector,
 		RegolithTime:           deployConf.RegolithTime(uint64(deployConf.L1GenesisBlockTimestamp)),
 	}
 
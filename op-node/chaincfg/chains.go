@@ -3,6 +3,7 @@ package chaincfg
 import (
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -90,11 +91,31 @@ func AvailableNetworks() []string {
 	return networks
 }
 
+// NetworksWithoutDataStream returns the predefined networks that have no data stream contract deployment.
+// GetRollupConfig rejects these, as no blocks could be derived for them.
+func NetworksWithoutDataStream() []string {
+	var networks []string
+	for name, netCfg := range NetworksByName {
+		if !hasDataStream(&netCfg) {
+			networks = append(networks, name)
+		}
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+func hasDataStream(cfg *rollup.Config) bool {
+	return cfg.DataStreamAddress != (common.Address{}) && cfg.ProposeSelector != (eth.Bytes4{})
+}
+
 func GetRollupConfig(name string) (rollup.Config, error) {
 	network, ok := NetworksByName[name]
 	if !ok {
 		return rollup.Config{}, fmt.Errorf("invalid network %s", name)
 	}
+	if !hasDataStream(&network) {
+		return rollup.Config{}, fmt.Errorf("network %s has no data stream address or propose selector, use a rollup config file instead", name)
+	}
 
 	return network, nil
 }
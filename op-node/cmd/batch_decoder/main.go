@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/plain"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -114,6 +117,96 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "plain",
+			Usage: "Fetches the blocks proposed to the data stream contract in the specified range",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:     "start",
+					Required: true,
+					Usage:    "First block (inclusive) to fetch",
+				},
+				cli.IntFlag{
+					Name:     "end",
+					Required: true,
+					Usage:    "Last block (exclusive) to fetch",
+				},
+				cli.StringFlag{
+					Name:     "rollup-config",
+					Required: true,
+					Usage:    "Rollup config file of the L2 chain, with the data stream address and propose selectors",
+				},
+				cli.StringFlag{
+					Name:  "sender",
+					Usage: "(Optional) Batch Sender Address, defaults to the batcher of the rollup config genesis",
+				},
+				cli.StringFlag{
+					Name:  "out",
+					Value: "/tmp/batch_decoder/plain_cache",
+					Usage: "Cache directory for the found blocks",
+				},
+				cli.StringFlag{
+					Name:     "l1",
+					Required: true,
+					Usage:    "L1 RPC URL",
+					EnvVar:   "L1_RPC",
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				client, err := ethclient.Dial(cliCtx.String("l1"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				rollupCfg := loadRollupConfig(cliCtx.String("rollup-config"))
+				sender := rollupCfg.Genesis.SystemConfig.BatcherAddr
+				if cliCtx.IsSet("sender") {
+					sender = common.HexToAddress(cliCtx.String("sender"))
+				}
+				config := plain.Config{
+					Start:  uint64(cliCtx.Int("start")),
+					End:    uint64(cliCtx.Int("end")),
+					Rollup: rollupCfg,
+					BatchSenders: map[common.Address]struct{}{
+						sender: {},
+					},
+					OutDirectory: cliCtx.String("out"),
+				}
+				totalValid, totalInvalid := plain.Blocks(client, config)
+				fmt.Printf("Fetched proposed blocks in range [%v,%v). Found %v valid & %v invalid blocks\n", config.Start, config.End, totalValid, totalInvalid)
+				fmt.Printf("Fetch Config: L2 Chain ID: %v. Data Stream Address: %v. Valid Senders: %v.\n", rollupCfg.L2ChainID, rollupCfg.DataStreamAddress, config.BatchSenders)
+				fmt.Printf("Wrote proposed blocks to %v\n", config.OutDirectory)
+				return nil
+			},
+		},
+		{
+			Name:  "verify",
+			Usage: "Verifies the fetched proposed blocks against the canonical L2 chain",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "in",
+					Value: "/tmp/batch_decoder/plain_cache",
+					Usage: "Cache directory for the found blocks",
+				},
+				cli.StringFlag{
+					Name:     "l2",
+					Required: true,
+					Usage:    "L2 RPC URL",
+					EnvVar:   "L2_RPC",
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				client, err := ethclient.Dial(cliCtx.String("l2"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				checked, mismatches := plain.Verify(client, cliCtx.String("in"))
+				fmt.Printf("Verified %v proposed blocks. Found %v mismatches\n", checked, mismatches)
+				if mismatches > 0 {
+					return fmt.Errorf("%v proposed blocks do not match the canonical L2 chain", mismatches)
+				}
+				return nil
+			},
+		},
 		{
 			Name:  "force-close",
 			Usage: "Create the tx data which will force close a channel",
@@ -160,3 +253,16 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+func loadRollupConfig(file string) *rollup.Config {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	var cfg rollup.Config
+	if err := json.NewDecoder(f).Decode(&cfg); err != nil {
+		log.Fatalf("Failed to decode rollup config %v. Err: %v\n", file, err)
+	}
+	return &cfg
+}
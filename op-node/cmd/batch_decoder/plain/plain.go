@@ -0,0 +1,193 @@
+package plain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// BlockWithMetadata is an L2 block proposed to the data stream contract, with the L1 transaction that proposed it.
+type BlockWithMetadata struct {
+	TxHash         common.Hash    `json:"transaction_hash"`
+	TxIndex        uint64         `json:"tx_index"`
+	InclusionBlock uint64         `json:"inclusion_block"`
+	InclusionHash  common.Hash    `json:"inclusion_block_hash"`
+	Timestamp      uint64         `json:"timestamp"`
+	Sender         common.Address `json:"sender"`
+	ValidSender    bool           `json:"valid_sender"`
+	// Reverted propose calls are ignored by the derivation
+	Reverted       bool            `json:"reverted"`
+	ChainID        uint32          `json:"chain_id"`
+	BlockNumber    uint64          `json:"block_number"`
+	BlockHash      common.Hash     `json:"block_hash"`
+	PayloadVersion uint8           `json:"payload_version"`
+	Batch          *derive.BatchV1 `json:"batch"`
+	BatchErr       string          `json:"batch_parse_error"`
+	ValidBatch     bool            `json:"valid_data"`
+}
+
+type Config struct {
+	Start, End   uint64
+	Rollup       *rollup.Config
+	BatchSenders map[common.Address]struct{}
+	OutDirectory string
+}
+
+// Blocks fetches & stores all blocks proposed to the data stream contract for the L2 chain of the
+// rollup config in the given L1 block range (inclusive to exclusive).
+// Each proposed block & its metadata is written to the out directory, in a file named after the
+// L2 block number and the hash of the transaction that proposed it.
+func Blocks(client *ethclient.Client, config Config) (totalValid, totalInvalid int) {
+	if err := os.MkdirAll(config.OutDirectory, 0750); err != nil {
+		log.Fatal(err)
+	}
+	signer := config.Rollup.L1Signer()
+	for i := config.Start; i < config.End; i++ {
+		valid, invalid := fetchBlocksPerBlock(client, new(big.Int).SetUint64(i), signer, config)
+		totalValid += valid
+		totalInvalid += invalid
+	}
+	return
+}
+
+// fetchBlocksPerBlock gets an L1 block & then decodes all of the propose calls in the block.
+func fetchBlocksPerBlock(client *ethclient.Client, number *big.Int, signer types.Signer, config Config) (validCount, invalidCount int) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	block, err := client.BlockByNumber(ctx, number)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Fetched block: ", number)
+	for i, tx := range block.Transactions() {
+		if tx.To() == nil || *tx.To() != config.Rollup.DataStreamAddress {
+			continue
+		}
+		sender, err := signer.Sender(tx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		_, validSender := config.BatchSenders[sender]
+		if !validSender {
+			fmt.Printf("Found a transaction (%s) from an invalid sender (%s)\n", tx.Hash().String(), sender.String())
+		}
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			log.Fatal(err)
+		}
+		reverted := receipt.Status != types.ReceiptStatusSuccessful
+		if reverted {
+			fmt.Printf("Found a reverted transaction (%s)\n", tx.Hash().String())
+		}
+		calls, err := derive.DecodeProposeCalls(config.Rollup, tx.Data())
+		if err != nil {
+			fmt.Printf("Found a transaction (%s) that is not a valid propose call: %v\n", tx.Hash().String(), err)
+			invalidCount += 1
+			continue
+		}
+		for _, call := range calls {
+			if new(big.Int).SetUint64(uint64(call.ChainID)).Cmp(config.Rollup.L2ChainID) != 0 {
+				fmt.Printf("Skipping a block proposed for chain %d in transaction (%s)\n", call.ChainID, tx.Hash().String())
+				continue
+			}
+			bm := &BlockWithMetadata{
+				TxHash:         tx.Hash(),
+				TxIndex:        uint64(i),
+				InclusionBlock: block.NumberU64(),
+				InclusionHash:  block.Hash(),
+				Timestamp:      block.Time(),
+				Sender:         sender,
+				ValidSender:    validSender,
+				Reverted:       reverted,
+				ChainID:        call.ChainID,
+				BlockNumber:    call.BlockNumber.Uint64(),
+				BlockHash:      call.BlockHash,
+			}
+			if len(call.Block) > 0 {
+				bm.PayloadVersion = call.Block[0]
+			}
+			batch, err := decodeBatch(call.Block)
+			if err != nil {
+				fmt.Printf("Found a block (%d) with invalid data in transaction (%s): %v\n", bm.BlockNumber, tx.Hash().String(), err)
+				bm.BatchErr = err.Error()
+			} else {
+				bm.Batch = &batch.BatchV1
+				bm.ValidBatch = true
+			}
+
+			if validSender && !reverted && bm.ValidBatch {
+				validCount += 1
+			} else {
+				invalidCount += 1
+			}
+			filename := path.Join(config.OutDirectory, fmt.Sprintf("%d_%s.json", bm.BlockNumber, tx.Hash().String()))
+			if err := writeBlock(bm, filename); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	return
+}
+
+// decodeBatch decodes the batch of a proposed block, decompressing it according to its payload version.
+func decodeBatch(block []byte) (*derive.BatchData, error) {
+	payload, err := derive.DecodeBlockPayload(block)
+	if err != nil {
+		return nil, err
+	}
+	var batch derive.BatchData
+	if err := rlp.DecodeBytes(payload, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func writeBlock(bm *BlockWithMetadata, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	return enc.Encode(bm)
+}
+
+// LoadBlocks loads the proposed blocks that were fetched to the given directory.
+func LoadBlocks(dir string) []BlockWithMetadata {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var out []BlockWithMetadata
+	for _, file := range files {
+		out = append(out, loadBlockFile(path.Join(dir, file.Name())))
+	}
+	return out
+}
+
+func loadBlockFile(file string) BlockWithMetadata {
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+	var bm BlockWithMetadata
+	if err := dec.Decode(&bm); err != nil {
+		log.Fatalf("Failed to decode %v. Err: %v\n", file, err)
+	}
+	return bm
+}
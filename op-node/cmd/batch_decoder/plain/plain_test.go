@@ -0,0 +1,56 @@
+package plain
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+)
+
+func TestDecodeBatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	block, _ := derivetest.RandomL2Block(rng, 4)
+	batch, _, err := derive.BlockToBatch(block)
+	require.NoError(t, err)
+	encoded, err := rlp.EncodeToBytes(batch)
+	require.NoError(t, err)
+
+	encode := func(version byte) []byte {
+		payload, err := derive.EncodeBlockPayload(version, encoded)
+		require.NoError(t, err)
+		return payload
+	}
+
+	tests := []struct {
+		name    string
+		payload []byte
+		valid   bool
+		err     error
+	}{
+		{name: "none", payload: encode(derive.PayloadVersionUncompressed), valid: true},
+		{name: "zlib", payload: encode(derive.PayloadVersionZlib), valid: true},
+		{name: "brotli", payload: encode(derive.PayloadVersionBrotli), valid: true},
+		{name: "empty", payload: nil, err: derive.ErrEmptyBlockPayload},
+		{name: "unknown version", payload: append([]byte{3}, encoded...), err: derive.ErrUnknownPayloadVersion},
+		{name: "truncated zlib", payload: encode(derive.PayloadVersionZlib)[:10]},
+		{name: "malformed batch", payload: []byte{derive.PayloadVersionUncompressed, 0xff, 0x01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeBatch(tt.payload)
+			if !tt.valid {
+				require.Error(t, err)
+				if tt.err != nil {
+					require.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, batch.BatchV1, decoded.BatchV1)
+		})
+	}
+}
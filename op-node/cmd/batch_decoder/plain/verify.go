@@ -0,0 +1,83 @@
+package plain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// L2Client is the L2 chain that the proposed blocks are verified against.
+type L2Client interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// Verify compares the fetched blocks that are valid for the derivation against the canonical
+// blocks of the L2 chain, and reports the blocks that do not match.
+func Verify(client L2Client, dir string) (checked, mismatches int) {
+	blocks := LoadBlocks(dir)
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].BlockNumber == blocks[j].BlockNumber {
+			return blocks[i].InclusionBlock < blocks[j].InclusionBlock
+		}
+		return blocks[i].BlockNumber < blocks[j].BlockNumber
+	})
+	for _, bm := range blocks {
+		if !bm.ValidSender || bm.Reverted || !bm.ValidBatch {
+			continue
+		}
+		checked += 1
+		if err := verifyBlock(client, &bm); err != nil {
+			fmt.Printf("Mismatch for block %d proposed in transaction (%s): %v\n", bm.BlockNumber, bm.TxHash.String(), err)
+			mismatches += 1
+		}
+	}
+	return
+}
+
+// verifyBlock checks the proposed block against the canonical L2 block with the same number.
+func verifyBlock(client L2Client, bm *BlockWithMetadata) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(bm.BlockNumber))
+	if errors.Is(err, ethereum.NotFound) {
+		return errors.New("block is not in the L2 chain")
+	} else if err != nil {
+		log.Fatal(err)
+	}
+	if block.Hash() != bm.BlockHash {
+		return fmt.Errorf("proposed hash %s, canonical hash %s", bm.BlockHash, block.Hash())
+	}
+	expected, _, err := derive.BlockToBatch(block)
+	if err != nil {
+		return fmt.Errorf("canonical block can't be converted to a batch: %w", err)
+	}
+	batch := bm.Batch
+	if batch.ParentHash != expected.ParentHash {
+		return fmt.Errorf("proposed parent hash %s, canonical parent hash %s", batch.ParentHash, expected.ParentHash)
+	}
+	if batch.EpochNum != expected.EpochNum || batch.EpochHash != expected.EpochHash {
+		return fmt.Errorf("proposed epoch %s, canonical epoch %s", batch.Epoch(), expected.Epoch())
+	}
+	if batch.Timestamp != expected.Timestamp {
+		return fmt.Errorf("proposed timestamp %d, canonical timestamp %d", batch.Timestamp, expected.Timestamp)
+	}
+	if len(batch.Transactions) != len(expected.Transactions) {
+		return fmt.Errorf("proposed %d transactions, canonical block has %d", len(batch.Transactions), len(expected.Transactions))
+	}
+	for i := range batch.Transactions {
+		if !bytes.Equal(batch.Transactions[i], expected.Transactions[i]) {
+			return fmt.Errorf("transaction %d differs from the canonical block", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package plain
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+)
+
+// testL2Client serves the canonical L2 blocks by number.
+type testL2Client map[uint64]*types.Block
+
+func (c testL2Client) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	block, ok := c[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+func TestVerifyBlock(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	block, _ := derivetest.RandomL2Block(rng, 3)
+	client := testL2Client{block.NumberU64(): block}
+
+	tests := []struct {
+		name   string
+		modify func(bm *BlockWithMetadata)
+		err    string
+	}{
+		{name: "match", modify: func(bm *BlockWithMetadata) {}},
+		{name: "unknown block", modify: func(bm *BlockWithMetadata) {
+			bm.BlockNumber++
+		}, err: "not in the L2 chain"},
+		{name: "hash", modify: func(bm *BlockWithMetadata) {
+			bm.BlockHash = common.Hash{0x01}
+		}, err: "proposed hash"},
+		{name: "parent", modify: func(bm *BlockWithMetadata) {
+			bm.Batch.ParentHash = common.Hash{0x01}
+		}, err: "parent hash"},
+		{name: "epoch number", modify: func(bm *BlockWithMetadata) {
+			bm.Batch.EpochNum++
+		}, err: "epoch"},
+		{name: "epoch hash", modify: func(bm *BlockWithMetadata) {
+			bm.Batch.EpochHash = common.Hash{0x01}
+		}, err: "epoch"},
+		{name: "timestamp", modify: func(bm *BlockWithMetadata) {
+			bm.Batch.Timestamp++
+		}, err: "timestamp"},
+		{name: "missing transaction", modify: func(bm *BlockWithMetadata) {
+			bm.Batch.Transactions = bm.Batch.Transactions[1:]
+		}, err: "transactions"},
+		{name: "different transaction", modify: func(bm *BlockWithMetadata) {
+			bm.Batch.Transactions[0] = hexutil.Bytes{0x01}
+		}, err: "transaction 0 differs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, _, err := derive.BlockToBatch(block)
+			require.NoError(t, err)
+			bm := &BlockWithMetadata{
+				BlockNumber: block.NumberU64(),
+				BlockHash:   block.Hash(),
+				Batch:       &batch.BatchV1,
+			}
+			tt.modify(bm)
+			err = verifyBlock(client, bm)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -51,6 +51,11 @@ func (res *AccountResult) Verify(stateRoot common.Hash) error {
 		if err != nil {
 			return fmt.Errorf("failed to verify storage value %d with key %s (path %x) in storage trie %s: %w", i, entry.Key, path, res.StorageHash, err)
 		}
+		// Zero values are not stored, so eth_getProof proves an unset slot with an exclusion proof (see EIP-1186).
+		// The proof must show that the key is absent from the storage trie, and the claimed value must be zero.
+		if val == nil && entry.Value.ToInt().Sign() == 0 {
+			continue
+		}
 		comparison, err := rlp.EncodeToBytes(entry.Value.ToInt().Bytes())
 		if err != nil {
 			return fmt.Errorf("failed to encode storage value %d with key %s (path %x) in storage trie %s: %w", i, entry.Key, path, res.StorageHash, err)
@@ -6,6 +6,11 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -58,15 +63,84 @@ func TestAccountResult_Verify(t *testing.T) {
 	require.NotNil(t, result.Verify(goodRoot), "does not verify against bad proof")
 }
 
+// TestAccountResult_VerifyAbsentStorage checks that storage keys that are absent from the storage trie
+// are proven to hold a zero value.
+func TestAccountResult_VerifyAbsentStorage(t *testing.T) {
+	storage := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	present := common.Hash{0x01}
+	absent := common.Hash{0x02}
+	storage.Update(crypto.Keccak256(present[:]), []byte{0x82, 0x12, 0x34})
+	storageProof := func(key common.Hash) []hexutil.Bytes {
+		proof := memorydb.New()
+		require.NoError(t, storage.Prove(crypto.Keccak256(key[:]), 0, proof))
+		return proofNodes(t, proof)
+	}
+
+	result := AccountResult{
+		Address:     common.Address{0xaa},
+		Balance:     (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:    common.Hash{0xcc},
+		Nonce:       1,
+		StorageHash: storage.Hash(),
+		StorageProof: []StorageProofEntry{
+			{Key: present, Value: hexutil.Big(*big.NewInt(0x1234)), Proof: storageProof(present)},
+			{Key: absent, Value: hexutil.Big(*big.NewInt(0)), Proof: storageProof(absent)},
+		},
+	}
+	account, err := rlp.EncodeToBytes([]any{uint64(result.Nonce), result.Balance.ToInt().Bytes(), result.StorageHash, result.CodeHash})
+	require.NoError(t, err)
+	state := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	state.Update(crypto.Keccak256(result.Address[:]), account)
+	accountProof := memorydb.New()
+	require.NoError(t, state.Prove(crypto.Keccak256(result.Address[:]), 0, accountProof))
+	result.AccountProof = proofNodes(t, accountProof)
+
+	require.NoError(t, result.Verify(state.Hash()))
+	result.StorageProof[1].Value = hexutil.Big(*big.NewInt(1))
+	require.Error(t, result.Verify(state.Hash()), "an absent key does not hold a non-zero value")
+}
+
+func proofNodes(t *testing.T, db *memorydb.Database) []hexutil.Bytes {
+	var nodes []hexutil.Bytes
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		nodes = append(nodes, common.CopyBytes(it.Value()))
+	}
+	require.NoError(t, it.Error())
+	return nodes
+}
+
 func FuzzAccountResult_StorageProof(f *testing.F) {
+	// a key outside the proven path with a zero value, which the proof shows to be absent
+	f.Add(common.Hash{0x01}.Bytes(), []byte{})
 	f.Fuzz(func(t *testing.T, key []byte, value []byte) {
 		result := makeResult(t)
+		original := result.StorageProof[0]
 		result.StorageProof[0].Key = common.BytesToHash(key)
 		result.StorageProof[0].Value = hexutil.Big(*(new(big.Int).SetBytes(value)))
-		require.NotNil(t, result.Verify(goodRoot), "does not verify against bad proof")
+		entry := result.StorageProof[0]
+		switch {
+		case entry.Key == original.Key && entry.Value.ToInt().Cmp(original.Value.ToInt()) == 0:
+			require.NoError(t, result.Verify(goodRoot), "verifies the proven value")
+		case entry.Value.ToInt().Sign() == 0 && provesAbsence(t, result.StorageHash, entry):
+			require.NoError(t, result.Verify(goodRoot), "verifies the zero value of an absent key")
+		default:
+			require.NotNil(t, result.Verify(goodRoot), "does not verify against bad proof")
+		}
 	})
 }
 
+// provesAbsence reports whether the proof of the storage entry shows that its key is absent from the storage trie.
+func provesAbsence(t *testing.T, storageHash common.Hash, entry StorageProofEntry) bool {
+	db := memorydb.New()
+	for _, node := range entry.Proof {
+		require.NoError(t, db.Put(crypto.Keccak256(node), node))
+	}
+	val, err := trie.VerifyProof(storageHash, crypto.Keccak256(entry.Key[:]), db)
+	return err == nil && val == nil
+}
+
 func FuzzAccountResult_AccountProof(f *testing.F) {
 	f.Fuzz(func(t *testing.T, address []byte, balance []byte, codeHash []byte, nonce uint64, storageHash []byte) {
 		result := makeResult(t)
@@ -0,0 +1,19 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockCommitment is the signed commitment of a sequencer to the unsafe L2 block at a height.
+// Users get it as a pre-confirmation of their transactions, before the block is derived from L1.
+type BlockCommitment struct {
+	Number    hexutil.Uint64 `json:"number"`
+	Hash      common.Hash    `json:"hash"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// ID returns the ID of the committed block.
+func (c *BlockCommitment) ID() BlockID {
+	return BlockID{Hash: c.Hash, Number: uint64(c.Number)}
+}
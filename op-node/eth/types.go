@@ -45,6 +45,24 @@ func (ie InputError) Is(target error) bool {
 	return ok // we implement Unwrap, so we do not have to check the inner type now
 }
 
+type Bytes4 [4]byte
+
+func (b *Bytes4) UnmarshalJSON(text []byte) error {
+	return hexutil.UnmarshalFixedJSON(reflect.TypeOf(b), text, b[:])
+}
+
+func (b *Bytes4) UnmarshalText(text []byte) error {
+	return hexutil.UnmarshalFixedText("Bytes4", text, b[:])
+}
+
+func (b Bytes4) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b[:]).MarshalText()
+}
+
+func (b Bytes4) String() string {
+	return hexutil.Encode(b[:])
+}
+
 type Bytes32 [32]byte
 
 func (b *Bytes32) UnmarshalJSON(text []byte) error {
@@ -291,5 +309,12 @@ type SystemConfig struct {
 	Scalar Bytes32 `json:"scalar"`
 	// GasLimit identifies the L2 block gas limit
 	GasLimit uint64 `json:"gasLimit"`
+	// Proposer is the account whose propose calls are derived from the data stream.
+	// If it is empty, the batcher proposes.
+	Proposer common.Address `json:"proposer,omitempty"`
+	// NextProposer takes over from Proposer at the L1 block NextProposerBlock.
+	NextProposer common.Address `json:"nextProposer,omitempty"`
+	// NextProposerBlock is the L1 block number from which NextProposer proposes, zero if no rotation is scheduled.
+	NextProposerBlock uint64 `json:"nextProposerBlock,omitempty"`
 	// More fields can be added for future SystemConfig versions.
 }
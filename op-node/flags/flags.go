@@ -39,8 +39,10 @@ var (
 		EnvVar: prefixEnvVar("ROLLUP_CONFIG"),
 	}
 	Network = cli.StringFlag{
-		Name:   "network",
-		Usage:  fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
+		Name: "network",
+		Usage: fmt.Sprintf("Predefined network selection. Available networks: %s. "+
+			"Networks without a data stream address and propose selector are rejected, use --%s instead: %s",
+			strings.Join(chaincfg.AvailableNetworks(), ", "), RollupConfig.Name, strings.Join(chaincfg.NetworksWithoutDataStream(), ", ")),
 		EnvVar: prefixEnvVar("NETWORK"),
 	}
 	RPCListenAddr = cli.StringFlag{
@@ -70,6 +70,7 @@ type Metricer interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
+	RecordEquivocation(source string)
 }
 
 // Metrics tracks all the metrics for the op-node.
@@ -134,6 +135,8 @@ type Metrics struct {
 
 	ChannelInputBytes prometheus.Counter
 
+	EquivocationsTotal *prometheus.CounterVec
+
 	registry *prometheus.Registry
 	factory  metrics.Factory
 }
@@ -325,6 +328,13 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"type",
 		}),
+		EquivocationsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "equivocations_total",
+			Help:      "Count of sequencer equivocations, by the source that detected them: gossip or derivation",
+		}, []string{
+			"source",
+		}),
 		BandwidthTotal: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -648,6 +658,10 @@ func (m *Metrics) RecordChannelInputBytes(inputCompressedBytes int) {
 	m.ChannelInputBytes.Add(float64(inputCompressedBytes))
 }
 
+func (m *Metrics) RecordEquivocation(source string) {
+	m.EquivocationsTotal.WithLabelValues(source).Inc()
+}
+
 type noopMetricer struct{}
 
 var NoopMetrics Metricer = new(noopMetricer)
@@ -753,3 +767,6 @@ func (n *noopMetricer) PayloadsQuarantineSize(int) {
 
 func (n *noopMetricer) RecordChannelInputBytes(int) {
 }
+
+func (n *noopMetricer) RecordEquivocation(source string) {
+}
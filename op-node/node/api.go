@@ -2,8 +2,11 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	ds "github.com/ipfs/go-datastore"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 )
@@ -66,20 +70,22 @@ func (n *adminAPI) StopSequencer(ctx context.Context) (common.Hash, error) {
 }
 
 type nodeAPI struct {
-	config *rollup.Config
-	client l2EthClient
-	dr     driverClient
-	log    log.Logger
-	m      rpcMetrics
+	config      *rollup.Config
+	client      l2EthClient
+	dr          driverClient
+	commitments *p2p.BlockCommitter // optional, signs the block commitments of the sequencer
+	log         log.Logger
+	m           rpcMetrics
 }
 
-func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, m rpcMetrics) *nodeAPI {
+func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, commitments *p2p.BlockCommitter, log log.Logger, m rpcMetrics) *nodeAPI {
 	return &nodeAPI{
-		config: config,
-		client: l2Client,
-		dr:     dr,
-		log:    log,
-		m:      m,
+		config:      config,
+		client:      l2Client,
+		dr:          dr,
+		commitments: commitments,
+		log:         log,
+		m:           m,
 	}
 }
 
@@ -130,6 +136,33 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 	}, nil
 }
 
+// GetBlockCommitment returns the commitment of the sequencer to the unsafe L2 block at the given height,
+// signed with its p2p signer. Verifiers can check it against the safe block that is later derived from L1.
+// The sequencer commits to at most one block per height: the commitment is signed and persisted on the first
+// request for the height, and later requests return the same commitment, even if the unsafe block was replaced
+// since. Heights above the unsafe head are not signed.
+func (n *nodeAPI) GetBlockCommitment(ctx context.Context, number hexutil.Uint64) (*eth.BlockCommitment, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_getBlockCommitment")
+	defer recordDur()
+
+	if n.commitments == nil {
+		return nil, errors.New("block commitments are not available, the node has no p2p signer")
+	}
+	if commitment, err := n.commitments.Commitment(ctx, uint64(number)); err == nil {
+		return commitment, nil
+	} else if !errors.Is(err, ds.ErrNotFound) {
+		return nil, err
+	}
+	ref, status, err := n.dr.BlockRefWithStatus(ctx, uint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 block ref: %w", err)
+	}
+	if ref.Number > status.UnsafeL2.Number {
+		return nil, fmt.Errorf("block %d is above the unsafe head %s", ref.Number, status.UnsafeL2)
+	}
+	return n.commitments.Commit(ctx, ref.ID())
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -21,6 +23,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 )
 
+// blockCommitmentsPruneInterval is how often the block commitments of finalized heights are deleted.
+const blockCommitmentsPruneInterval = time.Minute
+
 type OpNode struct {
 	log        log.Logger
 	appVersion string
@@ -199,7 +204,7 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return err
 	}
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics)
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n, n.log, snapshotLog, n.metrics)
 
 	return nil
 }
@@ -221,7 +226,11 @@ func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
+	commitments := n.blockCommitter(cfg)
+	if commitments != nil {
+		go n.pruneBlockCommitments(commitments)
+	}
+	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, commitments, n.log, n.appVersion, n.metrics)
 	if err != nil {
 		return err
 	}
@@ -240,6 +249,44 @@ func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+// blockCommitter returns the committer that signs the block commitments of the sequencer,
+// or nil if the node has no p2p signer.
+func (n *OpNode) blockCommitter(cfg *Config) *p2p.BlockCommitter {
+	if n.p2pSigner == nil {
+		return nil
+	}
+	var store ds.Batching
+	if cfg.P2P != nil {
+		store = cfg.P2P.Datastore()
+	}
+	if store == nil {
+		n.log.Warn("Block commitments are kept in memory, heights may be signed again after a restart")
+	}
+	return p2p.NewBlockCommitter(&cfg.Rollup, n.p2pSigner, store)
+}
+
+// pruneBlockCommitments deletes the block commitments of the finalized heights periodically, until the node is closed.
+// Pruning scans the stored commitments, so it is kept off the RPC path that serves them.
+func (n *OpNode) pruneBlockCommitments(commitments *p2p.BlockCommitter) {
+	ticker := time.NewTicker(blockCommitmentsPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			status, err := n.l2Driver.SyncStatus(n.resourcesCtx)
+			if err != nil {
+				n.log.Warn("Failed to get sync status for pruning block commitments", "err", err)
+				continue
+			}
+			if err := commitments.Prune(n.resourcesCtx, status.FinalizedL2.Number); err != nil {
+				n.log.Warn("Failed to prune block commitments", "finalized", status.FinalizedL2, "err", err)
+			}
+		case <-n.resourcesCtx.Done():
+			return
+		}
+	}
+}
+
 func (n *OpNode) initMetricsServer(ctx context.Context, cfg *Config) error {
 	if !cfg.Metrics.Enabled {
 		n.log.Info("metrics disabled")
@@ -384,6 +431,14 @@ func (n *OpNode) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef)
 	return nil
 }
 
+func (n *OpNode) OnProposedBlock(ctx context.Context, unsafe eth.BlockID, proposed common.Hash) bool {
+	// only the gossiped blocks are known to be signed by the sequencer
+	if n.p2pNode == nil || n.p2pNode.Equivocations() == nil {
+		return false
+	}
+	return n.p2pNode.Equivocations().OnProposedBlock(ctx, unsafe, proposed) != nil
+}
+
 func (n *OpNode) P2P() p2p.Node {
 	return n.p2pNode
 }
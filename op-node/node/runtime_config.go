@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,12 +12,25 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
 
 var (
 	// UnsafeBlockSignerAddressSystemConfigStorageSlot is the storage slot identifier of the unsafeBlockSigner
 	// `address` storage value in the SystemConfig L1 contract. Computed as `keccak256("systemconfig.unsafeblocksigner")`
 	UnsafeBlockSignerAddressSystemConfigStorageSlot = common.HexToHash("0x65a7ed542fb37fe237fdfbdd70b31598523fe5b32879e307bae27a0bd9581c08")
+
+	// ProposerAddressSystemConfigStorageSlot is the storage slot identifier of the current proposer
+	// `address` storage value in the SystemConfig L1 contract. Computed as `keccak256("systemconfig.proposer")`
+	ProposerAddressSystemConfigStorageSlot = common.HexToHash("0x22a243a57e2e821e10e65cb61dced2efbeb42229225e851609d47be3b322b6b0")
+
+	// NextProposerAddressSystemConfigStorageSlot is the storage slot identifier of the next proposer
+	// `address` storage value in the SystemConfig L1 contract. Computed as `keccak256("systemconfig.nextproposer")`
+	NextProposerAddressSystemConfigStorageSlot = common.HexToHash("0x0a291ce792f5fb7b4642bd69bc501b758c020cc2b376a76c633ce45cf7b7d410")
+
+	// NextProposerBlockSystemConfigStorageSlot is the storage slot identifier of the L1 block number from which
+	// the next proposer takes over, in the SystemConfig L1 contract. Computed as `keccak256("systemconfig.nextproposerblock")`
+	NextProposerBlockSystemConfigStorageSlot = common.HexToHash("0xd39140e49a0c4c3e0945c08227d8f54ec36b99b43329fdd05563c31b7b292ffe")
 )
 
 type RuntimeCfgL1Source interface {
@@ -45,6 +59,8 @@ type RuntimeConfig struct {
 // runtimeConfigData is a flat bundle of configurable data, easy and light to copy around.
 type runtimeConfigData struct {
 	p2pBlockSignerAddr common.Address
+	// proposerAddr is the scheduled proposer, it is empty if the SystemConfig does not rotate proposers.
+	proposerAddr common.Address
 }
 
 var _ p2p.GossipRuntimeConfig = (*RuntimeConfig)(nil)
@@ -57,12 +73,24 @@ func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *ro
 	}
 }
 
+// P2PSequencerAddress returns the signer of the unsafe blocks: the scheduled proposer if the
+// SystemConfig rotates proposers, and the unsafe block signer otherwise.
 func (r *RuntimeConfig) P2PSequencerAddress() common.Address {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
+	if r.proposerAddr != (common.Address{}) {
+		return r.proposerAddr
+	}
 	return r.p2pBlockSignerAddr
 }
 
+// Proposer returns the scheduled proposer, or an empty address if the SystemConfig does not rotate proposers.
+func (r *RuntimeConfig) Proposer() common.Address {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.proposerAddr
+}
+
 // Load resets the runtime configuration by fetching the latest config data from L1 at the given L1 block.
 // Load is safe to call concurrently, but will lock the runtime configuration modifications only,
 // and will thus not block other Load calls with possibly alternative L1 block views.
@@ -71,10 +99,43 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch unsafe block signing address from system config: %w", err)
 	}
+	proposer, err := r.loadProposer(ctx, l1Ref)
+	if err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.l1Ref = l1Ref
 	r.p2pBlockSignerAddr = common.BytesToAddress(val[:])
-	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr)
+	r.proposerAddr = proposer
+	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr, "proposer", r.proposerAddr)
 	return nil
 }
+
+// loadProposer reads the proposer schedule from the SystemConfig L1 contract at the given L1 block,
+// the same schedule that the SystemConfig update events give to the derivation, and returns the
+// proposer at that L1 block. There is no proposer before the proposer rotation is active.
+func (r *RuntimeConfig) loadProposer(ctx context.Context, l1Ref eth.L1BlockRef) (common.Address, error) {
+	if !r.rollupCfg.IsProposerRotation(l1Ref.Time) {
+		return common.Address{}, nil
+	}
+	proposer, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, ProposerAddressSystemConfigStorageSlot, l1Ref.Hash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch proposer address from system config: %w", err)
+	}
+	next, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, NextProposerAddressSystemConfigStorageSlot, l1Ref.Hash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch next proposer address from system config: %w", err)
+	}
+	nextBlock, err := r.l1Client.ReadStorageAt(ctx, r.rollupCfg.L1SystemConfigAddress, NextProposerBlockSystemConfigStorageSlot, l1Ref.Hash)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch next proposer block from system config: %w", err)
+	}
+	sysCfg := eth.SystemConfig{
+		Proposer:          common.BytesToAddress(proposer[:]),
+		NextProposer:      common.BytesToAddress(next[:]),
+		NextProposerBlock: new(big.Int).SetBytes(nextBlock[:]).Uint64(),
+	}
+	derive.ApplyProposerSchedule(&sysCfg, l1Ref.Number)
+	return sysCfg.Proposer, nil
+}
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+// TestRuntimeConfigProposer checks that the proposer scheduled in the SystemConfig, once it takes over,
+// replaces the unsafe block signer as the P2P sequencer address.
+func TestRuntimeConfigProposer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	signer := testutils.RandomAddress(rng)
+	proposer := testutils.RandomAddress(rng)
+	a := testutils.RandomBlockRef(rng)
+	b := testutils.NextRandomRef(rng, a)
+	cfg := &rollup.Config{L1SystemConfigAddress: testutils.RandomAddress(rng), ProposerRotationTime: &a.Time}
+
+	l1 := &testutils.MockEthClient{}
+	expectSlots := func(ref eth.L1BlockRef) {
+		l1.ExpectReadStorageAt(context.Background(), cfg.L1SystemConfigAddress, UnsafeBlockSignerAddressSystemConfigStorageSlot, ref.Hash, common.BytesToHash(signer[:]), nil)
+		l1.ExpectReadStorageAt(context.Background(), cfg.L1SystemConfigAddress, ProposerAddressSystemConfigStorageSlot, ref.Hash, common.Hash{}, nil)
+		l1.ExpectReadStorageAt(context.Background(), cfg.L1SystemConfigAddress, NextProposerAddressSystemConfigStorageSlot, ref.Hash, common.BytesToHash(proposer[:]), nil)
+		l1.ExpectReadStorageAt(context.Background(), cfg.L1SystemConfigAddress, NextProposerBlockSystemConfigStorageSlot, ref.Hash, common.BigToHash(new(big.Int).SetUint64(b.Number)), nil)
+	}
+	expectSlots(a)
+	expectSlots(b)
+
+	r := NewRuntimeConfig(testlog.Logger(t, log.LvlError), l1, cfg)
+	require.NoError(t, r.Load(context.Background(), a))
+	require.Equal(t, common.Address{}, r.Proposer())
+	require.Equal(t, signer, r.P2PSequencerAddress(), "before the rotation the unsafe block signer signs")
+
+	require.NoError(t, r.Load(context.Background(), b))
+	require.Equal(t, proposer, r.Proposer())
+	require.Equal(t, proposer, r.P2PSequencerAddress(), "the scheduled proposer signs")
+	l1.AssertExpectations(t)
+}
+
+// TestRuntimeConfigProposerInactive checks that the proposer schedule is not read before the proposer rotation is active.
+func TestRuntimeConfigProposerInactive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	signer := testutils.RandomAddress(rng)
+	a := testutils.RandomBlockRef(rng)
+	rotationTime := a.Time + 1
+	cfg := &rollup.Config{L1SystemConfigAddress: testutils.RandomAddress(rng), ProposerRotationTime: &rotationTime}
+
+	l1 := &testutils.MockEthClient{}
+	l1.ExpectReadStorageAt(context.Background(), cfg.L1SystemConfigAddress, UnsafeBlockSignerAddressSystemConfigStorageSlot, a.Hash, common.BytesToHash(signer[:]), nil)
+
+	r := NewRuntimeConfig(testlog.Logger(t, log.LvlError), l1, cfg)
+	require.NoError(t, r.Load(context.Background(), a))
+	require.Equal(t, common.Address{}, r.Proposer())
+	require.Equal(t, signer, r.P2PSequencerAddress())
+	l1.AssertExpectations(t)
+}
@@ -3,10 +3,15 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -19,6 +24,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
@@ -110,7 +116,7 @@ func TestOutputAtBlock(t *testing.T) {
 	status := randomSyncStatus(rand.New(rand.NewSource(123)))
 	drClient.ExpectBlockRefWithStatus(0xdcdc89, ref, status, nil)
 
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, log, "0.0", metrics.NoopMetrics)
 	require.NoError(t, err)
 	require.NoError(t, server.Start())
 	defer server.Stop()
@@ -142,7 +148,7 @@ func TestVersion(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer server.Stop()
@@ -185,7 +191,7 @@ func TestSyncStatus(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, nil, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer server.Stop()
@@ -199,6 +205,86 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestGetBlockCommitment(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	ref := testutils.RandomL2BlockRef(rng)
+	status := randomSyncStatus(rng)
+	status.UnsafeL2 = ref
+	status.FinalizedL2 = eth.L2BlockRef{Number: ref.Number - 1}
+	drClient.ExpectBlockRefWithStatus(ref.Number, ref, status, nil)
+	above := eth.L2BlockRef{Hash: testutils.RandomHash(rng), Number: ref.Number + 1}
+	drClient.ExpectBlockRefWithStatus(above.Number, above, status, nil)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		L2ChainID: big.NewInt(901),
+	}
+	priv := testutils.RandomKey()
+	store := dssync.MutexWrap(ds.NewMapDatastore())
+	commitments := p2p.NewBlockCommitter(rollupCfg, p2p.NewLocalSigner(priv), store)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, commitments, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.BlockCommitment
+	err = client.CallContext(context.Background(), &out, "optimism_getBlockCommitment", hexutil.Uint64(ref.Number))
+	require.NoError(t, err)
+	require.Equal(t, ref.ID(), out.ID())
+	require.NoError(t, p2p.CheckBlockCommitment(rollupCfg, out, crypto.PubkeyToAddress(priv.PublicKey), ref.ID()))
+
+	// the height is signed only once, later requests get the same commitment
+	var again *eth.BlockCommitment
+	err = client.CallContext(context.Background(), &again, "optimism_getBlockCommitment", hexutil.Uint64(ref.Number))
+	require.NoError(t, err)
+	require.Equal(t, out, again)
+	drClient.Mock.AssertNumberOfCalls(t, "BlockRefWithStatus", 1)
+
+	// the commitment was persisted before it was returned
+	stored, err := p2p.NewBlockCommitter(rollupCfg, p2p.NewLocalSigner(priv), store).Commitment(context.Background(), ref.Number)
+	require.NoError(t, err)
+	require.Equal(t, out, stored)
+
+	// heights above the unsafe head are not signed
+	err = client.CallContext(context.Background(), &out, "optimism_getBlockCommitment", hexutil.Uint64(above.Number))
+	require.ErrorContains(t, err, "above the unsafe head")
+	_, err = p2p.NewBlockCommitter(rollupCfg, p2p.NewLocalSigner(priv), store).Commitment(context.Background(), above.Number)
+	require.ErrorIs(t, err, ds.ErrNotFound)
+
+	// the admin API does not serve block commitments
+	err = client.CallContext(context.Background(), &out, "admin_getBlockCommitment", hexutil.Uint64(ref.Number))
+	require.ErrorContains(t, err, "does not exist")
+}
+
+func TestGetBlockCommitmentNoSigner(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	drClient := &mockDriverClient{}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, drClient, nil, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.BlockCommitment
+	err = client.CallContext(context.Background(), &out, "optimism_getBlockCommitment", hexutil.Uint64(1))
+	require.ErrorContains(t, err, "no p2p signer")
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
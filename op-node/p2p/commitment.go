@@ -0,0 +1,183 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+var (
+	// ErrInvalidCommitmentSignature is returned for block commitments that were not signed by the sequencer.
+	ErrInvalidCommitmentSignature = errors.New("invalid block commitment signature")
+	// ErrEquivocation is returned for block commitments that were signed by the sequencer,
+	// but commit to another block than the one derived from L1 at the same height.
+	ErrEquivocation = errors.New("sequencer equivocated")
+)
+
+// SignBlockCommitment signs a commitment to the given block with the p2p signer of the sequencer.
+func SignBlockCommitment(ctx context.Context, cfg *rollup.Config, signer Signer, id eth.BlockID) (*eth.BlockCommitment, error) {
+	sig, err := signer.Sign(ctx, SigningDomainBlockCommitmentsV1, cfg.L2ChainID, BlockCommitmentMessage(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign block commitment with signer: %w", err)
+	}
+	return &eth.BlockCommitment{
+		Number:    hexutil.Uint64(id.Number),
+		Hash:      id.Hash,
+		Signature: sig[:],
+	}, nil
+}
+
+// BlockCommitmentSigner recovers the address that signed the block commitment.
+func BlockCommitmentSigner(cfg *rollup.Config, c *eth.BlockCommitment) (common.Address, error) {
+	if len(c.Signature) != 65 {
+		return common.Address{}, fmt.Errorf("%w: signature is %d bytes", ErrInvalidCommitmentSignature, len(c.Signature))
+	}
+	signingHash, err := BlockCommitmentSigningHash(cfg, c.ID())
+	if err != nil {
+		return common.Address{}, err
+	}
+	pub, err := crypto.SigToPub(signingHash[:], c.Signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", ErrInvalidCommitmentSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// CheckBlockCommitment checks a block commitment of the sequencer against the safe block
+// that was later derived from L1 at the same height. It returns ErrInvalidCommitmentSignature
+// if the commitment was not signed by the sequencer, and ErrEquivocation if the sequencer
+// committed to another block than the safe block.
+func CheckBlockCommitment(cfg *rollup.Config, c *eth.BlockCommitment, sequencer common.Address, safe eth.BlockID) error {
+	if uint64(c.Number) != safe.Number {
+		return fmt.Errorf("commitment to block %d cannot be checked against safe block %s", c.Number, safe)
+	}
+	signer, err := BlockCommitmentSigner(cfg, c)
+	if err != nil {
+		return err
+	}
+	if signer != sequencer {
+		return fmt.Errorf("%w: signed by %s, expected sequencer %s", ErrInvalidCommitmentSignature, signer, sequencer)
+	}
+	if c.Hash != safe.Hash {
+		return fmt.Errorf("%w: committed to block %s, but safe block is %s", ErrEquivocation, c.ID(), safe)
+	}
+	return nil
+}
+
+// BlockCommitter signs the block commitments of the sequencer, at most one block per height.
+// Every signed commitment is persisted before it is returned, so the sequencer does not
+// commit to another block at the same height after a restart.
+type BlockCommitter struct {
+	cfg    *rollup.Config
+	signer Signer
+	store  ds.Datastore
+
+	mu sync.Mutex
+}
+
+// NewBlockCommitter creates a committer that persists the signed commitments in the given store,
+// or in memory if the store is nil.
+func NewBlockCommitter(cfg *rollup.Config, signer Signer, store ds.Batching) *BlockCommitter {
+	if store == nil {
+		store = dssync.MutexWrap(ds.NewMapDatastore())
+	}
+	return &BlockCommitter{
+		cfg:    cfg,
+		signer: signer,
+		store:  namespace.Wrap(store, ds.NewKey("/block-commitments")),
+	}
+}
+
+// Commit returns the commitment to the given block. The commitment is signed and persisted
+// if the sequencer did not commit to any block at the height yet, otherwise the existing
+// commitment is returned, even if it is to another block.
+func (c *BlockCommitter) Commit(ctx context.Context, id eth.BlockID) (*eth.BlockCommitment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if commitment, err := c.get(ctx, id.Number); err == nil || !errors.Is(err, ds.ErrNotFound) {
+		return commitment, err
+	}
+	commitment, err := SignBlockCommitment(ctx, c.cfg, c.signer, id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(commitment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode block commitment: %w", err)
+	}
+	key := commitmentKey(id.Number)
+	if err := c.store.Put(ctx, key, data); err != nil {
+		return nil, fmt.Errorf("failed to persist block commitment %d: %w", id.Number, err)
+	}
+	if err := c.store.Sync(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to sync block commitment %d: %w", id.Number, err)
+	}
+	return commitment, nil
+}
+
+// Commitment returns the commitment that was signed at the given height,
+// or an error wrapping ds.ErrNotFound if none was signed.
+func (c *BlockCommitter) Commitment(ctx context.Context, number uint64) (*eth.BlockCommitment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(ctx, number)
+}
+
+// Prune deletes the commitments at and below the finalized height:
+// finalized blocks cannot be replaced anymore, so their commitments don't need to be kept.
+func (c *BlockCommitter) Prune(ctx context.Context, finalized uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	results, err := c.store.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to query block commitments: %w", err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return fmt.Errorf("failed to read block commitments: %w", err)
+	}
+	for _, entry := range entries {
+		key := ds.NewKey(entry.Key)
+		number, err := strconv.ParseUint(key.Name(), 10, 64)
+		if err != nil || number > finalized {
+			continue
+		}
+		if err := c.store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete block commitment %d: %w", number, err)
+		}
+	}
+	return nil
+}
+
+func (c *BlockCommitter) get(ctx context.Context, number uint64) (*eth.BlockCommitment, error) {
+	data, err := c.store.Get(ctx, commitmentKey(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block commitment %d: %w", number, err)
+	}
+	var commitment eth.BlockCommitment
+	if err := json.Unmarshal(data, &commitment); err != nil {
+		return nil, fmt.Errorf("invalid block commitment %d: %w", number, err)
+	}
+	return &commitment, nil
+}
+
+func commitmentKey(number uint64) ds.Key {
+	return ds.NewKey(strconv.FormatUint(number, 10))
+}
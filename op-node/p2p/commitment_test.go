@@ -0,0 +1,103 @@
+package p2p
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+func TestCheckBlockCommitment(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100)}
+	priv := testutils.RandomKey()
+	sequencer := crypto.PubkeyToAddress(priv.PublicKey)
+	signer := NewLocalSigner(priv)
+
+	safe := testutils.RandomBlockRef(rng).ID()
+	c, err := SignBlockCommitment(context.Background(), cfg, signer, safe)
+	require.NoError(t, err)
+	require.Equal(t, safe, c.ID())
+
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, CheckBlockCommitment(cfg, c, sequencer, safe))
+	})
+	t.Run("Equivocation", func(t *testing.T) {
+		conflicting, err := SignBlockCommitment(context.Background(), cfg, signer, eth.BlockID{Hash: testutils.RandomHash(rng), Number: safe.Number})
+		require.NoError(t, err)
+		require.ErrorIs(t, CheckBlockCommitment(cfg, conflicting, sequencer, safe), ErrEquivocation)
+	})
+	t.Run("OtherSigner", func(t *testing.T) {
+		require.ErrorIs(t, CheckBlockCommitment(cfg, c, testutils.RandomAddress(rng), safe), ErrInvalidCommitmentSignature)
+	})
+	t.Run("TamperedHash", func(t *testing.T) {
+		tampered := *c
+		tampered.Hash = testutils.RandomHash(rng)
+		// the signature no longer matches, so this is no evidence of equivocation
+		err := CheckBlockCommitment(cfg, &tampered, sequencer, safe)
+		require.ErrorIs(t, err, ErrInvalidCommitmentSignature)
+		require.NotErrorIs(t, err, ErrEquivocation)
+	})
+	t.Run("OtherChain", func(t *testing.T) {
+		otherCfg := &rollup.Config{L2ChainID: big.NewInt(101)}
+		require.ErrorIs(t, CheckBlockCommitment(otherCfg, c, sequencer, safe), ErrInvalidCommitmentSignature)
+	})
+	t.Run("InvalidSignatureLength", func(t *testing.T) {
+		short := *c
+		short.Signature = short.Signature[:64]
+		require.ErrorIs(t, CheckBlockCommitment(cfg, &short, sequencer, safe), ErrInvalidCommitmentSignature)
+	})
+	t.Run("OtherHeight", func(t *testing.T) {
+		other := eth.BlockID{Hash: safe.Hash, Number: safe.Number + 1}
+		err := CheckBlockCommitment(cfg, c, sequencer, other)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrEquivocation)
+	})
+}
+
+func TestBlockCommitter(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{L2ChainID: big.NewInt(100)}
+	priv := testutils.RandomKey()
+	sequencer := crypto.PubkeyToAddress(priv.PublicKey)
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	committer := NewBlockCommitter(cfg, NewLocalSigner(priv), store)
+	ctx := context.Background()
+
+	_, err := committer.Commitment(ctx, 10)
+	require.ErrorIs(t, err, ds.ErrNotFound)
+
+	first := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 10}
+	c, err := committer.Commit(ctx, first)
+	require.NoError(t, err)
+	require.NoError(t, CheckBlockCommitment(cfg, c, sequencer, first))
+
+	// the height is committed to once, even if another block is requested later
+	other, err := committer.Commit(ctx, eth.BlockID{Hash: testutils.RandomHash(rng), Number: 10})
+	require.NoError(t, err)
+	require.Equal(t, c, other)
+
+	// the commitment is persisted, a restarted node does not sign the height again
+	restarted := NewBlockCommitter(cfg, NewLocalSigner(priv), store)
+	stored, err := restarted.Commitment(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, c, stored)
+
+	next := eth.BlockID{Hash: testutils.RandomHash(rng), Number: 11}
+	_, err = restarted.Commit(ctx, next)
+	require.NoError(t, err)
+	require.NoError(t, restarted.Prune(ctx, 10))
+	_, err = restarted.Commitment(ctx, 10)
+	require.ErrorIs(t, err, ds.ErrNotFound)
+	_, err = restarted.Commitment(ctx, 11)
+	require.NoError(t, err)
+}
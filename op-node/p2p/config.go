@@ -41,6 +41,8 @@ type SetupP2P interface {
 	TargetPeers() uint
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	// Datastore returns the datastore to persist equivocation evidence and block commitments in, nil to keep them in memory.
+	Datastore() ds.Batching
 }
 
 // Config sets up a p2p host and discv5 service from configuration.
@@ -103,7 +105,7 @@ type Config struct {
 	TimeoutAccept      time.Duration
 	TimeoutDial        time.Duration
 
-	// Underlying store that hosts connection-gater and peerstore data.
+	// Underlying store that hosts connection-gater, peerstore, equivocation evidence and block commitment data.
 	Store ds.Batching
 
 	ConnGater func(conf *Config) (connmgr.ConnectionGater, error)
@@ -176,6 +178,10 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) Datastore() ds.Batching {
+	return conf.Store
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
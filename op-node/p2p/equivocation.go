@@ -0,0 +1,222 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+	"github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+)
+
+const (
+	// EquivocationSourceGossip labels the equivocations that were detected in the gossiped blocks.
+	EquivocationSourceGossip = "gossip"
+	// EquivocationSourceDerivation labels the equivocations that were detected in the blocks proposed on L1.
+	EquivocationSourceDerivation = "derivation"
+)
+
+// equivocationHeights is the number of recent block heights of which the first signed block is kept.
+const equivocationHeights = 1000
+
+// SignedEnvelope is a block as gossiped by the sequencer: its signature and SSZ-encoded execution payload.
+type SignedEnvelope struct {
+	BlockHash common.Hash   `json:"blockHash"`
+	Signature hexutil.Bytes `json:"signature"`
+	Payload   hexutil.Bytes `json:"payload"`
+}
+
+// Equivocation is the evidence of a sequencer that signed two different blocks at the same height.
+// The signature of each envelope can be checked against the signer with BlockSigningHash.
+// For equivocations detected in derivation, the second block is the one proposed on L1 instead,
+// and its envelope only has the block hash.
+type Equivocation struct {
+	Source string         `json:"source"`
+	Number uint64         `json:"number"`
+	Signer common.Address `json:"signer"`
+	First  SignedEnvelope `json:"first"`
+	Second SignedEnvelope `json:"second"`
+}
+
+type EquivocationMetrics interface {
+	RecordEquivocation(source string)
+}
+
+type signedBlock struct {
+	signer   common.Address
+	envelope SignedEnvelope
+}
+
+// EquivocationDetector keeps the first block that the sequencer signed at every recent height,
+// and persists the evidence of every other block that the sequencer signed at the same height.
+type EquivocationDetector struct {
+	log   log.Logger
+	m     EquivocationMetrics
+	store ds.Datastore
+
+	mu    sync.Mutex
+	first *lru.Cache // uint64 -> *signedBlock
+}
+
+// NewEquivocationDetector creates a detector that persists the evidence in the given store,
+// or in memory if the store is nil.
+func NewEquivocationDetector(log log.Logger, store ds.Batching, m EquivocationMetrics) *EquivocationDetector {
+	if store == nil {
+		store = dssync.MutexWrap(ds.NewMapDatastore())
+	}
+	if m == nil {
+		m = metrics.NoopMetrics
+	}
+	first, err := lru.New(equivocationHeights)
+	if err != nil {
+		panic(fmt.Errorf("failed to set up signed blocks LRU cache: %w", err))
+	}
+	return &EquivocationDetector{
+		log:   log,
+		m:     m,
+		store: namespace.Wrap(store, ds.NewKey("/equivocation")),
+		first: first,
+	}
+}
+
+// OnSignedBlock checks a block that was signed by the signer against the first block that it signed
+// at the same height. If the blocks differ, the evidence is persisted and returned.
+// The signature and payload are copied, the caller may reuse them.
+func (d *EquivocationDetector) OnSignedBlock(ctx context.Context, number uint64, signer common.Address, hash common.Hash, signature []byte, payload []byte) *Equivocation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prev, ok := d.first.Get(number)
+	if !ok {
+		d.first.Add(number, &signedBlock{signer: signer, envelope: newSignedEnvelope(hash, signature, payload)})
+		return nil
+	}
+	first := prev.(*signedBlock)
+	// a block signed by a new sequencer after a rotation does not conflict with the blocks of the previous one
+	if first.signer != signer || first.envelope.BlockHash == hash {
+		return nil
+	}
+
+	ev := &Equivocation{
+		Source: EquivocationSourceGossip,
+		Number: number,
+		Signer: signer,
+		First:  first.envelope,
+		Second: newSignedEnvelope(hash, signature, payload),
+	}
+	d.persist(ctx, ev)
+	return ev
+}
+
+// OnProposedBlock checks the block proposed on L1 at a height against the unsafe block that it replaces.
+// The proposal is an equivocation only if the sequencer signed the unsafe block, in which case the evidence
+// is persisted and returned. Otherwise the unsafe block is not known to be signed, e.g. because it was
+// synced from a peer or the height is not recent, and nil is returned.
+func (d *EquivocationDetector) OnProposedBlock(ctx context.Context, unsafe eth.BlockID, proposed common.Hash) *Equivocation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	signed, ok := d.signedBlock(ctx, unsafe)
+	if !ok {
+		return nil
+	}
+	ev := &Equivocation{
+		Source: EquivocationSourceDerivation,
+		Number: unsafe.Number,
+		Signer: signed.signer,
+		First:  signed.envelope,
+		Second: SignedEnvelope{BlockHash: proposed},
+	}
+	d.persist(ctx, ev)
+	return ev
+}
+
+// signedBlock returns the signed envelope of the given block, if the sequencer signed it at a recent height.
+// The block is either the first one signed at its height, or the second one of gossiped equivocation evidence.
+func (d *EquivocationDetector) signedBlock(ctx context.Context, id eth.BlockID) (*signedBlock, bool) {
+	if prev, ok := d.first.Get(id.Number); ok {
+		if first := prev.(*signedBlock); first.envelope.BlockHash == id.Hash {
+			return first, true
+		}
+	}
+	data, err := d.store.Get(ctx, evidenceKey(EquivocationSourceGossip, id.Number, id.Hash))
+	if err != nil {
+		return nil, false
+	}
+	var ev Equivocation
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, false
+	}
+	return &signedBlock{signer: ev.Signer, envelope: ev.Second}, true
+}
+
+// persist stores the evidence, once per source, height and second block hash.
+func (d *EquivocationDetector) persist(ctx context.Context, ev *Equivocation) {
+	key := evidenceKey(ev.Source, ev.Number, ev.Second.BlockHash)
+	if known, err := d.store.Has(ctx, key); err == nil && known {
+		return
+	}
+	d.log.Error("sequencer equivocated, it signed two different blocks at the same height",
+		"source", ev.Source, "number", ev.Number, "signer", ev.Signer, "first", ev.First.BlockHash, "second", ev.Second.BlockHash)
+	d.m.RecordEquivocation(ev.Source)
+	data, err := json.Marshal(ev)
+	if err != nil {
+		d.log.Error("failed to encode equivocation evidence", "number", ev.Number, "err", err)
+		return
+	}
+	if err := d.store.Put(ctx, key, data); err != nil {
+		d.log.Error("failed to persist equivocation evidence", "number", ev.Number, "err", err)
+	}
+}
+
+// Evidence returns all persisted equivocations, ordered by block height.
+func (d *EquivocationDetector) Evidence(ctx context.Context) ([]*Equivocation, error) {
+	results, err := d.store.Query(ctx, query.Query{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query equivocation evidence: %w", err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read equivocation evidence: %w", err)
+	}
+	out := make([]*Equivocation, 0, len(entries))
+	for _, entry := range entries {
+		var ev Equivocation
+		if err := json.Unmarshal(entry.Value, &ev); err != nil {
+			return nil, fmt.Errorf("invalid equivocation evidence %s: %w", entry.Key, err)
+		}
+		out = append(out, &ev)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Number != out[j].Number {
+			return out[i].Number < out[j].Number
+		}
+		return out[i].Second.BlockHash.Hex() < out[j].Second.BlockHash.Hex()
+	})
+	return out, nil
+}
+
+func evidenceKey(source string, number uint64, second common.Hash) ds.Key {
+	return ds.NewKey(source).ChildString(strconv.FormatUint(number, 10)).ChildString(second.Hex())
+}
+
+func newSignedEnvelope(hash common.Hash, signature []byte, payload []byte) SignedEnvelope {
+	return SignedEnvelope{
+		BlockHash: hash,
+		Signature: append(hexutil.Bytes(nil), signature...),
+		Payload:   append(hexutil.Bytes(nil), payload...),
+	}
+}
@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type countingEquivocationMetrics struct {
+	sources []string
+}
+
+func (m *countingEquivocationMetrics) RecordEquivocation(source string) {
+	m.sources = append(m.sources, source)
+}
+
+func TestEquivocationDetector(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlCrit)
+	cfg := &rollup.Config{L2ChainID: common.Big1}
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sequencer := crypto.PubkeyToAddress(priv.PublicKey)
+	signer := NewLocalSigner(priv)
+
+	sign := func(payload []byte) []byte {
+		sig, err := signer.Sign(ctx, SigningDomainBlocksV1, cfg.L2ChainID, payload)
+		require.NoError(t, err)
+		return sig[:]
+	}
+	payloadA, payloadB := []byte("block A"), []byte("block B")
+	sigA, sigB := sign(payloadA), sign(payloadB)
+	hashA, hashB := common.Hash{0xa}, common.Hash{0xb}
+
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	m := &countingEquivocationMetrics{}
+	d := NewEquivocationDetector(logger, store, m)
+
+	require.Nil(t, d.OnSignedBlock(ctx, 10, sequencer, hashA, sigA, payloadA), "first block at a height")
+	require.Nil(t, d.OnSignedBlock(ctx, 10, sequencer, hashA, sigA, payloadA), "same block again")
+	require.Nil(t, d.OnSignedBlock(ctx, 11, sequencer, hashB, sigB, payloadB), "block at another height")
+	require.Nil(t, d.OnSignedBlock(ctx, 10, common.Address{0x42}, hashB, sigB, payloadB), "block of another sequencer")
+	require.Empty(t, m.sources)
+
+	// the buffers of the gossip validator are reused, the evidence must not change with them
+	buf := append([]byte{}, payloadB...)
+	ev := d.OnSignedBlock(ctx, 10, sequencer, hashB, sigB, buf)
+	buf[0] = 0xff
+	require.NotNil(t, ev)
+	require.Equal(t, []string{EquivocationSourceGossip}, m.sources)
+	require.Equal(t, uint64(10), ev.Number)
+	require.Equal(t, sequencer, ev.Signer)
+	require.Equal(t, hashA, ev.First.BlockHash)
+	require.Equal(t, hashB, ev.Second.BlockHash)
+
+	// the same equivocation is only counted once
+	require.NotNil(t, d.OnSignedBlock(ctx, 10, sequencer, hashB, sigB, payloadB))
+	require.Len(t, m.sources, 1)
+
+	evidence, err := d.Evidence(ctx)
+	require.NoError(t, err)
+	require.Len(t, evidence, 1)
+	require.Equal(t, ev, evidence[0])
+
+	// both envelopes of the evidence were signed by the sequencer
+	for _, env := range []SignedEnvelope{evidence[0].First, evidence[0].Second} {
+		signingHash, err := BlockSigningHash(cfg, env.Payload)
+		require.NoError(t, err)
+		pub, err := crypto.SigToPub(signingHash[:], env.Signature)
+		require.NoError(t, err)
+		require.Equal(t, sequencer, crypto.PubkeyToAddress(*pub))
+	}
+
+	// the evidence is persisted, and survives a restart
+	restarted := NewEquivocationDetector(logger, store, m)
+	evidence, err = restarted.Evidence(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*Equivocation{ev}, evidence)
+}
+
+func TestEquivocationDetectorProposedBlock(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlCrit)
+	sequencer := common.Address{0x42}
+	hashA, hashB, hashC := common.Hash{0xa}, common.Hash{0xb}, common.Hash{0xc}
+	proposed := common.Hash{0xd}
+
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	m := &countingEquivocationMetrics{}
+	d := NewEquivocationDetector(logger, store, m)
+
+	require.Nil(t, d.OnSignedBlock(ctx, 10, sequencer, hashA, []byte{0xa}, []byte("block A")))
+	require.Nil(t, d.OnSignedBlock(ctx, 11, sequencer, hashB, []byte{0xb}, []byte("block B")))
+	require.NotNil(t, d.OnSignedBlock(ctx, 11, sequencer, hashC, []byte{0xc}, []byte("block C")))
+	m.sources = nil
+
+	// unsafe blocks that were not gossiped by the sequencer are reorged without evidence
+	require.Nil(t, d.OnProposedBlock(ctx, eth.BlockID{Number: 10, Hash: hashB}, proposed), "other block at a signed height")
+	require.Nil(t, d.OnProposedBlock(ctx, eth.BlockID{Number: 12, Hash: hashA}, proposed), "unsigned height")
+	require.Empty(t, m.sources)
+
+	ev := d.OnProposedBlock(ctx, eth.BlockID{Number: 10, Hash: hashA}, proposed)
+	require.NotNil(t, ev)
+	require.Equal(t, EquivocationSourceDerivation, ev.Source)
+	require.Equal(t, sequencer, ev.Signer)
+	require.Equal(t, hashA, ev.First.BlockHash)
+	require.Equal(t, hexutil.Bytes("block A"), ev.First.Payload)
+	require.Equal(t, SignedEnvelope{BlockHash: proposed}, ev.Second)
+	require.Equal(t, []string{EquivocationSourceDerivation}, m.sources)
+
+	// the second signed block of a gossiped equivocation is known too
+	ev = d.OnProposedBlock(ctx, eth.BlockID{Number: 11, Hash: hashC}, proposed)
+	require.NotNil(t, ev)
+	require.Equal(t, hexutil.Bytes("block C"), ev.First.Payload)
+
+	// the derivation may run again after a reset, the same equivocation is only counted once
+	require.NotNil(t, d.OnProposedBlock(ctx, eth.BlockID{Number: 10, Hash: hashA}, proposed))
+	require.Len(t, m.sources, 2)
+
+	evidence, err := d.Evidence(ctx)
+	require.NoError(t, err)
+	require.Len(t, evidence, 3)
+}
@@ -203,6 +203,18 @@ func TestP2PFull(t *testing.T) {
 	require.Nil(t, err)
 	require.Equal(t, uint(1), stats.Connected)
 
+	// the equivocations of the sequencer are persisted in the peerstore, and served over RPC
+	equivocations, err := p2pClientA.Equivocations(ctx)
+	require.NoError(t, err)
+	require.Empty(t, equivocations)
+	nodeA.Equivocations().OnSignedBlock(ctx, 10, runCfgA.P2PSeqAddress, common.Hash{1}, []byte{1}, []byte{1})
+	nodeA.Equivocations().OnSignedBlock(ctx, 10, runCfgA.P2PSeqAddress, common.Hash{2}, []byte{2}, []byte{2})
+	equivocations, err = p2pClientA.Equivocations(ctx)
+	require.NoError(t, err)
+	require.Len(t, equivocations, 1)
+	require.Equal(t, common.Hash{1}, equivocations[0].First.BlockHash)
+	require.Equal(t, common.Hash{2}, equivocations[0].Second.BlockHash)
+
 	// disconnect
 	require.NoError(t, p2pClientA.DisconnectPeer(ctx, hostB.ID()))
 	peerDump, err = p2pClientA.Peers(ctx, false)
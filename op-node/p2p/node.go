@@ -36,6 +36,8 @@ type NodeP2P struct {
 	gsOut    GossipOut        // p2p gossip application interface for publishing
 	syncCl   *SyncClient
 	syncSrv  *ReqRespServer
+
+	equivocations *EquivocationDetector // evidence of the blocks that the sequencer signed twice, nil if gossip is disabled
 }
 
 // NewNodeP2P creates a new p2p node, and returns a reference to it. If the p2p is disabled, it returns nil.
@@ -107,7 +109,8 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		if err != nil {
 			return fmt.Errorf("failed to start gossipsub router: %w", err)
 		}
-		n.gsOut, err = JoinGossip(resourcesCtx, n.host.ID(), setup.TopicScoringParams(), n.gs, log, rollupCfg, runCfg, gossipIn)
+		n.equivocations = NewEquivocationDetector(log.New("p2p", "equivocations"), setup.Datastore(), metrics)
+		n.gsOut, err = JoinGossip(resourcesCtx, n.host.ID(), setup.TopicScoringParams(), n.gs, log, rollupCfg, runCfg, gossipIn, n.equivocations)
 		if err != nil {
 			return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 		}
@@ -162,6 +165,10 @@ func (n *NodeP2P) GossipOut() GossipOut {
 	return n.gsOut
 }
 
+func (n *NodeP2P) Equivocations() *EquivocationDetector {
+	return n.equivocations
+}
+
 func (n *NodeP2P) ConnectionGater() ConnectionGater {
 	return n.gater
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	ds "github.com/ipfs/go-datastore"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/metrics"
@@ -24,6 +25,9 @@ type Prepared struct {
 	UDPv5     *discover.UDPv5
 
 	EnableReqRespSync bool
+
+	// Store persists the equivocation evidence and block commitments, they are kept in memory if nil.
+	Store ds.Batching
 }
 
 var _ SetupP2P = (*Prepared)(nil)
@@ -91,3 +95,7 @@ func (p *Prepared) Disabled() bool {
 func (p *Prepared) ReqRespSyncEnabled() bool {
 	return p.EnableReqRespSync
 }
+
+func (p *Prepared) Datastore() ds.Batching {
+	return p.Store
+}
@@ -56,4 +56,5 @@ type API interface {
 	UnprotectPeer(ctx context.Context, p peer.ID) error
 	ConnectPeer(ctx context.Context, addr string) error
 	DisconnectPeer(ctx context.Context, id peer.ID) error
+	Equivocations(ctx context.Context) ([]*Equivocation, error)
 }
@@ -107,3 +107,9 @@ func (c *Client) ConnectPeer(ctx context.Context, addr string) error {
 func (c *Client) DisconnectPeer(ctx context.Context, id peer.ID) error {
 	return c.c.CallContext(ctx, nil, prefixRPC("disconnectPeer"), id)
 }
+
+func (c *Client) Equivocations(ctx context.Context) ([]*Equivocation, error) {
+	var out []*Equivocation
+	err := c.c.CallContext(ctx, &out, prefixRPC("equivocations"))
+	return out, err
+}
@@ -33,6 +33,7 @@ var (
 	ErrDisabledDiscovery   = errors.New("discovery disabled")
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
+	ErrNoEquivocations     = errors.New("no equivocation detector, blocks are not gossiped")
 )
 
 type Node interface {
@@ -50,6 +51,8 @@ type Node interface {
 	ConnectionGater() ConnectionGater
 	// ConnectionManager returns the connection manager, to protect peers with, may be nil
 	ConnectionManager() connmgr.ConnManager
+	// Equivocations returns the detector of the blocks that the sequencer signed twice, may be nil
+	Equivocations() *EquivocationDetector
 }
 
 type APIBackend struct {
@@ -376,3 +379,15 @@ func (s *APIBackend) DisconnectPeer(_ context.Context, id peer.ID) error {
 	defer recordDur()
 	return s.node.Host().Network().ClosePeer(id)
 }
+
+// Equivocations returns the evidence of every block height at which the sequencer gossiped two different
+// signed blocks, or proposed another block on L1 than the one it gossiped, ordered by block height.
+func (s *APIBackend) Equivocations(ctx context.Context) ([]*Equivocation, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_equivocations")
+	defer recordDur()
+	equivocations := s.node.Equivocations()
+	if equivocations == nil {
+		return nil, ErrNoEquivocations
+	}
+	return equivocations.Evidence(ctx)
+}
@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/binary"
 	"errors"
 	"io"
 	"math/big"
@@ -10,11 +11,16 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 
+	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 )
 
 var SigningDomainBlocksV1 = [32]byte{}
 
+// SigningDomainBlockCommitmentsV1 is the signing domain of block commitments, which sign the number
+// and hash of a block instead of its payload, and can thus never be mistaken for a gossiped block.
+var SigningDomainBlockCommitmentsV1 = [32]byte{31: 1}
+
 type Signer interface {
 	Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error)
 	io.Closer
@@ -39,6 +45,18 @@ func BlockSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, err
 	return SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, payloadBytes)
 }
 
+// BlockCommitmentMessage encodes the number and hash of a block, as signed by a block commitment.
+func BlockCommitmentMessage(id eth.BlockID) []byte {
+	var msg [8 + 32]byte
+	binary.BigEndian.PutUint64(msg[:8], id.Number)
+	copy(msg[8:], id.Hash[:])
+	return msg[:]
+}
+
+func BlockCommitmentSigningHash(cfg *rollup.Config, id eth.BlockID) (common.Hash, error) {
+	return SigningHash(SigningDomainBlockCommitmentsV1, cfg.L2ChainID, BlockCommitmentMessage(id))
+}
+
 // LocalSigner is suitable for testing
 type LocalSigner struct {
 	priv   *ecdsa.PrivateKey
@@ -48,23 +48,26 @@ func (aq *AttributesQueue) Origin() eth.L1BlockRef {
 	return aq.prev.Origin()
 }
 
-func (aq *AttributesQueue) NextAttributes(ctx context.Context, l2SafeHead eth.L2BlockRef) (*eth.PayloadAttributes, error) {
+// NextAttributes returns the next payload attributes to build on top of the safe head,
+// along with the L2 block that was proposed for it, if any.
+func (aq *AttributesQueue) NextAttributes(ctx context.Context, l2SafeHead eth.L2BlockRef) (*eth.PayloadAttributes, *BlockProposal, error) {
 	// Get a batch if we need it
 	if aq.batch == nil {
 		batch, err := aq.prev.NextBatch(ctx, l2SafeHead)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		aq.batch = batch
 	}
 
 	// Actually generate the next attributes
 	if attrs, err := aq.createNextAttributes(ctx, aq.batch, l2SafeHead); err != nil {
-		return nil, err
+		return nil, nil, err
 	} else {
+		proposal := aq.batch.Proposal
 		// Clear out the local state once we will succeed
 		aq.batch = nil
-		return attrs, nil
+		return attrs, proposal, nil
 	}
 
 }
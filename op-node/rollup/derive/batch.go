@@ -45,6 +45,10 @@ type BatchV1 struct {
 type BatchData struct {
 	BatchV1
 	// batches may contain additional data with new upgrades
+
+	// Proposal is the L2 block announced by the propose call that carried the batch, if any.
+	// It is not part of the batch encoding.
+	Proposal *BlockProposal
 }
 
 func (b *BatchV1) Epoch() eth.BlockID {
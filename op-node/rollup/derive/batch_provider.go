@@ -4,21 +4,29 @@ import (
 	"bytes"
 	"context"
 	"io"
-	"github.com/ethereum-optimism/optimism/op-node/eth"
+
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 )
 
 type BatchProvider struct {
-	log log.Logger
+	log  log.Logger
+	cfg  *rollup.Config
 	prev *L1Retrieval
+
+	// batches decoded from a propose range call that were not returned yet
+	pending []*BatchData
 }
 
 var _ ResetableStage = (*BatchProvider)(nil)
 var _ NextBatchProvider = (*BatchProvider)(nil)
 
-func NewBatchProvider(log log.Logger, prev *L1Retrieval) *BatchProvider {
+func NewBatchProvider(log log.Logger, cfg *rollup.Config, prev *L1Retrieval) *BatchProvider {
 	return &BatchProvider{
-		log: log,
+		log:  log,
+		cfg:  cfg,
 		prev: prev,
 	}
 }
@@ -28,39 +36,75 @@ func (bp *BatchProvider) Origin() eth.L1BlockRef {
 }
 
 func (bp *BatchProvider) NextBatch(ctx context.Context) (*BatchData, error) {
+	if len(bp.pending) > 0 {
+		batch := bp.pending[0]
+		bp.pending = bp.pending[1:]
+		return batch, nil
+	}
+
 	data, err := bp.prev.NextData(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	bp.log.Info("got batch data", "data", len(data))
+	bp.log.Trace("read propose data", "len", len(data))
 
-	// At minimum, select, block number and block hash need to be removed (4 + 32 + 32 = 68 bytes)
-	if (len(data) < 68) {
+	calls, err := DecodeProposeCalls(bp.cfg, data)
+	if err != nil {
+		bp.log.Warn("failed to decode propose call", "err", err)
 		return nil, NotEnoughData
 	}
-	bp.log.Info("enough data")
-	read, err := BatchReader(bytes.NewBuffer(data[68:]), bp.Origin())
+	var batches []*BatchData
+	for _, call := range calls {
+		batch, err := bp.readBatch(call)
+		if err != nil {
+			return nil, err
+		}
+		if batch == nil {
+			// the blocks after an invalid block of a range can't be derived either
+			break
+		}
+		batches = append(batches, batch)
+	}
+	if len(batches) == 0 {
+		return nil, NotEnoughData
+	}
+	bp.pending = batches[1:]
+	return batches[0], nil
+}
+
+// readBatch reads the batch of the block proposed by the call. It returns a nil batch if the call is invalid.
+func (bp *BatchProvider) readBatch(call *ProposeCall) (*BatchData, error) {
+	proposal, err := call.Proposal()
+	if err != nil {
+		bp.log.Warn("invalid block proposal", "err", err)
+		return nil, nil
+	}
+	payload, err := DecodeBlockPayload(call.Block)
 	if err != nil {
-        bp.log.Error("Error creating batch reader from batch data", "err", err)
-        return nil, err
-    } else if read == nil {
-		// NOTE(norswap) just doing random stuff lol, not sure if this can happen
-		return nil, io.EOF
+		bp.log.Warn("invalid block payload", "err", err)
+		return nil, nil
+	}
+	read, err := BatchReader(bytes.NewBuffer(payload), bp.Origin())
+	if err != nil {
+		bp.log.Error("Error creating batch reader from batch data", "err", err)
+		return nil, err
 	}
 
-	bp.log.Info("yup my friend")
 	batch, err := read()
-    if err == io.EOF {
-        return nil, NotEnoughData
-    } else if err != nil {
-        bp.log.Warn("failed to read batch from data", "err", err)
-        return nil, NotEnoughData
-    }
-	bp.log.Info("we got batch", "epoch", batch.Batch.BatchV1.Epoch(), "num", batch.Batch.BatchV1.EpochNum)
-    return batch.Batch, nil
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		bp.log.Warn("failed to read batch from data", "err", err)
+		return nil, nil
+	}
+	bp.log.Trace("read batch", "epoch", batch.Batch.BatchV1.Epoch(), "epoch_num", batch.Batch.BatchV1.EpochNum)
+	// the announced block is checked against the safe head and the block that is derived from the batch
+	batch.Batch.Proposal = proposal
+	return batch.Batch, nil
 }
 
 func (bp *BatchProvider) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
+	bp.pending = nil
 	return io.EOF
 }
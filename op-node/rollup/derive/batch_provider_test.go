@@ -0,0 +1,84 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+// TestBatchProviderProposeRange checks that the batches of all the blocks of a propose range call are
+// returned in order, along with the proposals of the blocks.
+func TestBatchProviderProposeRange(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{
+		ProposeSelector:      eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+		ProposeRangeSelector: eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f},
+	}
+	l1Block := testutils.RandomBlockRef(rng)
+
+	call := &ProposeRangeCall{ChainID: 901, FirstBlockNumber: big.NewInt(10)}
+	var batches []BatchV1
+	for i := 0; i < 3; i++ {
+		batch := BatchV1{
+			ParentHash:   testutils.RandomHash(rng),
+			EpochNum:     rollup.Epoch(l1Block.Number),
+			EpochHash:    l1Block.Hash,
+			Timestamp:    rng.Uint64(),
+			Transactions: []hexutil.Bytes{},
+		}
+		var buf bytes.Buffer
+		require.NoError(t, rlp.Encode(&buf, &BatchData{BatchV1: batch}))
+		// mix compressed and uncompressed payloads in the range
+		payload, err := EncodeBlockPayload(byte(i%2), buf.Bytes())
+		require.NoError(t, err)
+		batches = append(batches, batch)
+		call.BlockHashes = append(call.BlockHashes, testutils.RandomHash(rng))
+		call.Blocks = append(call.Blocks, payload)
+	}
+	data, err := EncodeProposeRangeCall(cfg.ProposeRangeSelector, call)
+	require.NoError(t, err)
+
+	newProvider := func() *BatchProvider {
+		l1t := &MockL1Traversal{}
+		l1t.ExpectNextL1Block(l1Block, nil)
+		l1t.ExpectSystemConfig(eth.SystemConfig{BatcherAddr: common.Address{42}})
+		l1t.ExpectOrigin(l1Block)
+		dataSrc := &MockDataSource{}
+		iter := &fakeDataIter{data: []eth.Data{data, nil}, errs: []error{nil, io.EOF}}
+		dataSrc.ExpectOpenData(l1Block.ID(), iter, common.Address{42})
+		logger := testlog.Logger(t, log.LvlCrit)
+		return NewBatchProvider(logger, cfg, NewL1Retrieval(logger, dataSrc, l1t))
+	}
+
+	bp := newProvider()
+	for i, expected := range batches {
+		out, err := bp.NextBatch(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expected, out.BatchV1)
+		require.Equal(t, &BlockProposal{Number: uint64(10 + i), Hash: call.BlockHashes[i]}, out.Proposal)
+	}
+	_, err = bp.NextBatch(context.Background())
+	require.ErrorIs(t, err, io.EOF)
+
+	// the remaining batches of the range are dropped on a reset
+	bp = newProvider()
+	_, err = bp.NextBatch(context.Background())
+	require.NoError(t, err)
+	require.ErrorIs(t, bp.Reset(context.Background(), l1Block, eth.SystemConfig{}), io.EOF)
+	require.Empty(t, bp.pending)
+}
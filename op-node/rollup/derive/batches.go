@@ -60,6 +60,12 @@ func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l
 		return BatchDrop
 	}
 
+	// if the batch was proposed as a specific L2 block, that block must be the child of the safe head
+	if proposal := batch.Batch.Proposal; proposal != nil && proposal.Number != l2SafeHead.Number+1 {
+		log.Warn("ignoring batch with mismatching proposed block number", "proposed", proposal.ID(), "current_safe_head", l2SafeHead.ID())
+		return BatchDrop
+	}
+
 	// Filter out batches that were included too late.
 	if uint64(batch.Batch.EpochNum)+cfg.SeqWindowSize < batch.L1InclusionBlock.Number {
 		log.Warn("batch was included too late, sequence window expired")
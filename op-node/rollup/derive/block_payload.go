@@ -0,0 +1,124 @@
+package derive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// The block of a propose call starts with a payload version byte, which tells how the
+// RLP-encoded batch that follows it is compressed.
+const (
+	PayloadVersionUncompressed byte = 0
+	PayloadVersionZlib         byte = 1
+	PayloadVersionBrotli       byte = 2
+)
+
+// MaxBlockPayloadBytes caps the size of the decompressed batch of a proposed block,
+// to protect against decompression bombs.
+const MaxBlockPayloadBytes = MaxRLPBytesPerChannel
+
+var (
+	ErrEmptyBlockPayload     = errors.New("empty block payload")
+	ErrUnknownPayloadVersion = errors.New("unknown block payload version")
+	ErrBlockPayloadTooLarge  = errors.New("decompressed block payload is too large")
+)
+
+// ParsePayloadVersion returns the payload version of the named compression: none, zlib or brotli.
+func ParsePayloadVersion(compression string) (byte, error) {
+	switch compression {
+	case "", "none":
+		return PayloadVersionUncompressed, nil
+	case "zlib":
+		return PayloadVersionZlib, nil
+	case "brotli":
+		return PayloadVersionBrotli, nil
+	default:
+		return 0, fmt.Errorf("unknown compression %q, expected none, zlib or brotli", compression)
+	}
+}
+
+// EncodeBlockPayload compresses the RLP-encoded batch of a block according to the payload version,
+// and prefixes it with the version byte.
+func EncodeBlockPayload(version byte, batch []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(version)
+	switch version {
+	case PayloadVersionUncompressed:
+		buf.Write(batch)
+	case PayloadVersionZlib:
+		w, err := zlib.NewWriterLevel(&buf, zlib.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(batch); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+	case PayloadVersionBrotli:
+		w := brotli.NewWriterLevel(&buf, brotli.BestCompression)
+		if _, err := w.Write(batch); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPayloadVersion, version)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeBlockPayload returns the RLP-encoded batch of a block payload, decompressed according to
+// its version byte. The decompressed batch may be at most MaxBlockPayloadBytes long.
+func DecodeBlockPayload(payload []byte) ([]byte, error) {
+	if len(payload) == 0 {
+		return nil, ErrEmptyBlockPayload
+	}
+	version, data := payload[0], payload[1:]
+	switch version {
+	case PayloadVersionUncompressed:
+		if len(data) > MaxBlockPayloadBytes {
+			return nil, ErrBlockPayloadTooLarge
+		}
+		return data, nil
+	case PayloadVersionZlib:
+		r, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open zlib payload: %w", err)
+		}
+		defer r.Close()
+		batch, err := readCapped(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zlib payload: %w", err)
+		}
+		return batch, nil
+	case PayloadVersionBrotli:
+		batch, err := readCapped(brotli.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress brotli payload: %w", err)
+		}
+		return batch, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPayloadVersion, version)
+	}
+}
+
+// readCapped reads a decompressed batch of at most MaxBlockPayloadBytes.
+func readCapped(r io.Reader) ([]byte, error) {
+	// read one byte past the cap to tell a payload of exactly the cap from a larger one
+	batch, err := io.ReadAll(io.LimitReader(r, MaxBlockPayloadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(batch) > MaxBlockPayloadBytes {
+		return nil, ErrBlockPayloadTooLarge
+	}
+	return batch, nil
+}
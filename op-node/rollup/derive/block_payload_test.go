@@ -0,0 +1,96 @@
+package derive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"math/rand"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+func TestBlockPayloadRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batch := append(testutils.RandomData(rng, 100), make([]byte, 1000)...)
+	for _, version := range []byte{PayloadVersionUncompressed, PayloadVersionZlib, PayloadVersionBrotli} {
+		payload, err := EncodeBlockPayload(version, batch)
+		require.NoError(t, err)
+		require.Equal(t, version, payload[0])
+		out, err := DecodeBlockPayload(payload)
+		require.NoError(t, err)
+		require.Equal(t, batch, out)
+	}
+
+	zlibPayload, err := EncodeBlockPayload(PayloadVersionZlib, batch)
+	require.NoError(t, err)
+	require.Less(t, len(zlibPayload), len(batch), "zeroes compress well")
+	brotliPayload, err := EncodeBlockPayload(PayloadVersionBrotli, batch)
+	require.NoError(t, err)
+	require.Less(t, len(brotliPayload), len(batch), "zeroes compress well")
+}
+
+func TestDecodeBlockPayloadInvalid(t *testing.T) {
+	_, err := DecodeBlockPayload(nil)
+	require.ErrorIs(t, err, ErrEmptyBlockPayload)
+	_, err = DecodeBlockPayload([]byte{0xc0, 1, 2, 3})
+	require.ErrorIs(t, err, ErrUnknownPayloadVersion)
+	_, err = DecodeBlockPayload([]byte{PayloadVersionBrotli, 1, 2, 3})
+	require.Error(t, err)
+	_, err = DecodeBlockPayload([]byte{PayloadVersionZlib, 1, 2, 3})
+	require.Error(t, err)
+
+	_, err = EncodeBlockPayload(0xc0, []byte{1})
+	require.ErrorIs(t, err, ErrUnknownPayloadVersion)
+}
+
+// TestDecodeBlockPayloadBomb checks that a small zlib payload that decompresses
+// past MaxBlockPayloadBytes is rejected, and likewise for brotli.
+func TestDecodeBlockPayloadBomb(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteByte(PayloadVersionZlib)
+	w, err := zlib.NewWriterLevel(&buf, zlib.BestCompression)
+	require.NoError(t, err)
+	_, err = w.Write(make([]byte, MaxBlockPayloadBytes+1))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Less(t, buf.Len(), 100_000)
+
+	_, err = DecodeBlockPayload(buf.Bytes())
+	require.ErrorIs(t, err, ErrBlockPayloadTooLarge)
+
+	buf.Reset()
+	buf.WriteByte(PayloadVersionBrotli)
+	bw := brotli.NewWriterLevel(&buf, brotli.BestCompression)
+	_, err = bw.Write(make([]byte, MaxBlockPayloadBytes+1))
+	require.NoError(t, err)
+	require.NoError(t, bw.Close())
+	require.Less(t, buf.Len(), 100_000)
+
+	_, err = DecodeBlockPayload(buf.Bytes())
+	require.ErrorIs(t, err, ErrBlockPayloadTooLarge)
+
+	// a payload of exactly the cap is fine
+	payload, err := EncodeBlockPayload(PayloadVersionZlib, make([]byte, MaxBlockPayloadBytes))
+	require.NoError(t, err)
+	out, err := DecodeBlockPayload(payload)
+	require.NoError(t, err)
+	require.Len(t, out, MaxBlockPayloadBytes)
+}
+
+func TestParsePayloadVersion(t *testing.T) {
+	for name, version := range map[string]byte{
+		"":       PayloadVersionUncompressed,
+		"none":   PayloadVersionUncompressed,
+		"zlib":   PayloadVersionZlib,
+		"brotli": PayloadVersionBrotli,
+	} {
+		v, err := ParsePayloadVersion(name)
+		require.NoError(t, err)
+		require.Equal(t, version, v)
+	}
+	_, err := ParsePayloadVersion("gzip")
+	require.Error(t, err)
+}
@@ -1,11 +1,11 @@
 package derive
 
 import (
-	"bytes"
-"context"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,6 +22,7 @@ type DataIter interface {
 
 type L1TransactionFetcher interface {
 	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
 }
 
 // DataSourceFactory readers raw transactions from a given block & then filters for
@@ -38,8 +39,8 @@ func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Transact
 }
 
 // OpenData returns a DataIter. This struct implements the `Next` function.
-func (ds *DataSourceFactory) OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter {
-	return NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, id, batcherAddr)
+func (ds *DataSourceFactory) OpenData(ctx context.Context, id eth.BlockID, proposer common.Address) DataIter {
+	return NewDataSource(ctx, ds.log, ds.cfg, ds.fetcher, id, proposer)
 }
 
 // DataSource is a fault tolerant approach to fetching data.
@@ -55,26 +56,26 @@ type DataSource struct {
 	fetcher L1TransactionFetcher
 	log     log.Logger
 
-	batcherAddr common.Address
+	proposer common.Address
 }
 
 // NewDataSource creates a new calldata source. It suppresses errors in fetching the L1 block if they occur.
 // If there is an error, it will attempt to fetch the result on the next call to `Next`.
-func NewDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, block eth.BlockID, batcherAddr common.Address) DataIter {
-	_, txs, err := fetcher.InfoAndTxsByHash(ctx, block.Hash)
+func NewDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, block eth.BlockID, proposer common.Address) DataIter {
+	txs, receipts, err := fetchTxsAndReceipts(ctx, cfg, fetcher, block.Hash, proposer)
 	if err != nil {
 		return &DataSource{
-			open:        false,
-			id:          block,
-			cfg:         cfg,
-			fetcher:     fetcher,
-			log:         log,
-			batcherAddr: batcherAddr,
+			open:     false,
+			id:       block,
+			cfg:      cfg,
+			fetcher:  fetcher,
+			log:      log,
+			proposer: proposer,
 		}
 	} else {
 		return &DataSource{
 			open: true,
-			data: DataFromEVMTransactions(cfg, batcherAddr, txs, log.New("origin", block)),
+			data: DataFromEVMTransactions(cfg, proposer, txs, receipts, log.New("origin", block)),
 		}
 	}
 }
@@ -84,9 +85,9 @@ func NewDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetc
 // otherwise it returns a temporary error if fetching the block returns an error.
 func (ds *DataSource) Next(ctx context.Context) (eth.Data, error) {
 	if !ds.open {
-		if _, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.id.Hash); err == nil {
+		if txs, receipts, err := fetchTxsAndReceipts(ctx, ds.cfg, ds.fetcher, ds.id.Hash, ds.proposer); err == nil {
 			ds.open = true
-			ds.data = DataFromEVMTransactions(ds.cfg, ds.batcherAddr, txs, log.New("origin", ds.id))
+			ds.data = DataFromEVMTransactions(ds.cfg, ds.proposer, txs, receipts, log.New("origin", ds.id))
 		} else if errors.Is(err, ethereum.NotFound) {
 			return nil, NewResetError(fmt.Errorf("failed to open calldata source: %w", err))
 		} else {
@@ -102,32 +103,81 @@ func (ds *DataSource) Next(ctx context.Context) (eth.Data, error) {
 	}
 }
 
+// fetchTxsAndReceipts fetches the transactions of the L1 block and their receipts, which tell
+// whether the propose calls were accepted by the data stream contract. The receipts are only
+// fetched if the block contains a transaction from the proposer to the data stream contract,
+// they are nil otherwise.
+func fetchTxsAndReceipts(ctx context.Context, cfg *rollup.Config, fetcher L1TransactionFetcher, hash common.Hash, proposer common.Address) (types.Transactions, types.Receipts, error) {
+	_, txs, err := fetcher.InfoAndTxsByHash(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !hasProposerTx(cfg, proposer, txs) {
+		return txs, nil, nil
+	}
+	_, receipts, err := fetcher.FetchReceipts(ctx, hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(receipts) != len(txs) {
+		return nil, nil, fmt.Errorf("got %d receipts for %d transactions of block %s", len(receipts), len(txs), hash)
+	}
+	return txs, receipts, nil
+}
+
+// hasProposerTx returns whether any of the transactions is sent by the proposer to the data stream contract.
+func hasProposerTx(cfg *rollup.Config, proposer common.Address, txs types.Transactions) bool {
+	l1Signer := cfg.L1Signer()
+	for _, tx := range txs {
+		if to := tx.To(); to == nil || *to != cfg.DataStreamAddress {
+			continue
+		}
+		if sender, err := l1Signer.Sender(tx); err == nil && sender == proposer {
+			return true
+		}
+	}
+	return false
+}
+
 // DataFromEVMTransactions filters all of the transactions and returns the calldata from transactions
-// that are sent to the batch inbox address from the batch sender address.
+// that call propose on the data stream contract for this L2 chain, from the proposer scheduled for the
+// L1 block, which is given by the proposer schedule of the L1 system config at this L1 block (see ProposerAddr).
+// Propose calls that were reverted by the data stream contract are ignored, the receipts are those
+// of the transactions at the same index, and no data is returned if they do not match the transactions.
 // This will return an empty array if no valid transactions are found.
-func DataFromEVMTransactions(config *rollup.Config, batcherAddr common.Address, txs types.Transactions, log log.Logger) []eth.Data {
+func DataFromEVMTransactions(config *rollup.Config, proposer common.Address, txs types.Transactions, receipts types.Receipts, log log.Logger) []eth.Data {
 	var out []eth.Data
 	l1Signer := config.L1Signer()
-	// TODO(norswap): obvious hack for config value
-    dataStreamAddress := common.HexToAddress("0x99bbA657f2BbC93c02D617f8bA121cB8Fc104Acf")
 	for j, tx := range txs {
-		if to := tx.To(); to != nil && *to == dataStreamAddress {
+		if to := tx.To(); to != nil && *to == config.DataStreamAddress {
 			log.Info("found data stream transaction", "index", j)
-			_, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
-			// seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
+			seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
 			if err != nil {
-				log.Warn("tx in inbox with invalid signature", "index", j, "err", err)
+				log.Warn("tx in data stream with invalid signature", "index", j, "err", err)
 				continue // bad signature, ignore
 			}
-			// some random L1 user might have sent a transaction to our batch inbox, ignore them
-			// TODO(norswap): substitute with a check that the sender is the expected sequencer
-			//if seqDataSubmitter != batcherAddr {
-			//	log.Warn("tx in inbox with unauthorized submitter", "index", j, "err", err)
-			//	continue // not an authorized batch submitter, ignore
-			//}
-			data := tx.Data()
-			selector := []byte{0x74, 0x12, 0x3b, 0xf9}
-			if len(data) < 4 || !bytes.Equal(data[0:4], selector) {
+			// anyone can call propose on the data stream contract, only keep the calls from the
+			// proposer scheduled for this L1 block
+			if seqDataSubmitter != proposer {
+				log.Warn("tx in data stream with unauthorized submitter", "index", j, "submitter", seqDataSubmitter, "proposer", proposer)
+				continue // not the scheduled proposer, ignore
+			}
+			if len(receipts) != len(txs) {
+				log.Error("receipts do not match the transactions", "receipts", len(receipts), "txs", len(txs))
+				return nil
+			}
+			if receipts[j].Status != types.ReceiptStatusSuccessful {
+				log.Warn("propose call in data stream was reverted", "index", j)
+				continue
+			}
+			calls, err := DecodeProposeCalls(config, tx.Data())
+			if err != nil {
+				log.Warn("tx in data stream is not a valid propose call", "index", j, "err", err)
+				continue
+			}
+			// the data stream is shared by multiple rollups, ignore the blocks proposed for other chains
+			if chainID := new(big.Int).SetUint64(uint64(calls[0].ChainID)); chainID.Cmp(config.L2ChainID) != 0 {
+				log.Debug("ignoring propose call for other chain", "index", j, "chain_id", chainID)
 				continue
 			}
 			log.Info("found valid transaction", "index", j)
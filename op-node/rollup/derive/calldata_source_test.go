@@ -1,7 +1,9 @@
 package derive
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"io"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -21,11 +23,15 @@ import (
 )
 
 type testTx struct {
-	to      *common.Address
-	dataLen int
-	author  *ecdsa.PrivateKey
-	good    bool
-	value   int
+	to       *common.Address
+	dataLen  int
+	author   *ecdsa.PrivateKey
+	propose  bool // create a propose call instead of random data
+	blocks   int  // number of blocks of a propose range call, a single propose call if zero
+	chainID  int  // L2 chain ID of the propose call, the configured L2 chain if zero
+	good     bool
+	value    int
+	reverted bool // the transaction was reverted on L1
 }
 
 func (tx *testTx) Create(t *testing.T, signer types.Signer, rng *rand.Rand) *types.Transaction {
@@ -44,15 +50,26 @@ func (tx *testTx) Create(t *testing.T, signer types.Signer, rng *rand.Rand) *typ
 	return out
 }
 
-func (tx *testTx) CreateGood(t *testing.T, signer types.Signer) *types.Transaction {
+func (tx *testTx) CreatePropose(t *testing.T, signer types.Signer, cfg *rollup.Config, chainID *big.Int, rng *rand.Rand) *types.Transaction {
 	t.Helper()
-
-	// encode selector for propose: 0x74123bf9
-    selector := []byte{0x74, 0x12, 0x3b, 0xf9}
-	bytes := make([]byte, tx.dataLen)
-	copy(bytes[0:4], selector)
-	bytes[35] = 3 // block number three
-	copy(bytes[36:68], common.HexToHash("0x1234567890123456789012345678901234567890123456789012345678901234").Bytes())
+	var data []byte
+	var err error
+	if tx.blocks == 0 {
+		data, err = EncodeProposeCall(cfg.ProposeSelector, &ProposeCall{
+			ChainID:     uint32(chainID.Uint64()),
+			BlockNumber: big.NewInt(3),
+			BlockHash:   testutils.RandomHash(rng),
+			Block:       testutils.RandomData(rng, tx.dataLen),
+		})
+	} else {
+		call := &ProposeRangeCall{ChainID: uint32(chainID.Uint64()), FirstBlockNumber: big.NewInt(3)}
+		for i := 0; i < tx.blocks; i++ {
+			call.BlockHashes = append(call.BlockHashes, testutils.RandomHash(rng))
+			call.Blocks = append(call.Blocks, testutils.RandomData(rng, tx.dataLen))
+		}
+		data, err = EncodeProposeRangeCall(cfg.ProposeRangeSelector, call)
+	}
+	require.NoError(t, err)
 
 	out, err := types.SignNewTx(tx.author, signer, &types.DynamicFeeTx{
 		ChainID:   signer.ChainID(),
@@ -62,12 +79,19 @@ func (tx *testTx) CreateGood(t *testing.T, signer types.Signer) *types.Transacti
 		Gas:       100_000,
 		To:        tx.to,
 		Value:     big.NewInt(int64(tx.value)),
-		Data:      bytes,
+		Data:      data,
 	})
 	require.NoError(t, err)
 	return out
 }
 
+func testReceipt(reverted bool) *types.Receipt {
+	if reverted {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}
+}
+
 type calldataTest struct {
 	name string
 	txs  []testTx
@@ -80,22 +104,54 @@ func TestDataFromEVMTransactions(t *testing.T) {
 	inboxPriv := testutils.RandomKey()
 	batcherPriv := testutils.RandomKey()
 	cfg := &rollup.Config{
-		L1ChainID:         big.NewInt(100),
-		BatchInboxAddress: crypto.PubkeyToAddress(inboxPriv.PublicKey),
+		L1ChainID:            big.NewInt(100),
+		L2ChainID:            big.NewInt(200),
+		BatchInboxAddress:    crypto.PubkeyToAddress(inboxPriv.PublicKey),
+		DataStreamAddress:    testutils.RandomAddress(rand.New(rand.NewSource(4321))),
+		ProposeSelector:      eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+		ProposeRangeSelector: eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f},
 	}
 	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
 
 	altInbox := testutils.RandomAddress(rand.New(rand.NewSource(1234)))
 	altAuthor := testutils.RandomKey()
 
-
-	// TODO(norswap): obvious hack for config value
-	dataStreamAddress := common.HexToAddress("0x99bbA657f2BbC93c02D617f8bA121cB8Fc104Acf")
-
 	testCases := []calldataTest{
 		{
 			name: "correct empty",
-			txs:  []testTx{{to: &dataStreamAddress, dataLen: 68, author: batcherPriv, good: true}},
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 0, author: batcherPriv, propose: true, good: true}},
+		},
+		{
+			name: "correct",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 1234, author: batcherPriv, propose: true, good: true}},
+		},
+		{
+			name: "correct range",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 300, author: batcherPriv, propose: true, blocks: 3, good: true}},
+		},
+		{
+			name: "range of other chain",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 300, author: batcherPriv, propose: true, blocks: 3, chainID: 201, good: false}},
+		},
+		{
+			name: "reverted propose",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 100, author: batcherPriv, propose: true, reverted: true, good: false}},
+		},
+		{
+			name: "reverted range",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 100, author: batcherPriv, propose: true, blocks: 2, reverted: true, good: false}},
+		},
+		{
+			name: "unauthorized propose",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 100, author: altAuthor, propose: true, good: false}},
+		},
+		{
+			name: "other chain",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 100, author: batcherPriv, propose: true, chainID: 201, good: false}},
+		},
+		{
+			name: "other selector",
+			txs:  []testTx{{to: &cfg.DataStreamAddress, dataLen: 1234, author: batcherPriv, good: false}},
 		},
 		{
 			name: "simple",
@@ -132,14 +188,15 @@ func TestDataFromEVMTransactions(t *testing.T) {
 			name: "mixed txs",
 			txs: []testTx{
 				{to: &cfg.BatchInboxAddress, dataLen: 1234, value: 42, author: batcherPriv, good: false},
-				{to: &dataStreamAddress, dataLen: 68, author: batcherPriv, good: true},
+				{to: &cfg.DataStreamAddress, dataLen: 100, author: batcherPriv, propose: true, good: true},
+				{to: &cfg.DataStreamAddress, dataLen: 100, author: batcherPriv, propose: true, reverted: true, good: false},
+				{to: &cfg.DataStreamAddress, dataLen: 100, author: altAuthor, propose: true, good: false},
+				{to: &cfg.DataStreamAddress, dataLen: 100, author: batcherPriv, propose: true, chainID: 201, good: false},
 				{to: &cfg.BatchInboxAddress, dataLen: 3333, value: 32, author: altAuthor, good: false},
 				{to: &cfg.BatchInboxAddress, dataLen: 2000, value: 22, author: batcherPriv, good: false},
 				{to: &altInbox, dataLen: 2020, value: 12, author: batcherPriv, good: false},
-
 			},
 		},
-		// TODO: test with different batcher key, i.e. when it's changed from initial config value by L1 contract
 	}
 
 	for i, tc := range testCases {
@@ -148,23 +205,166 @@ func TestDataFromEVMTransactions(t *testing.T) {
 
 		var expectedData []eth.Data
 		var txs []*types.Transaction
+		var receipts []*types.Receipt
 		for i, tx := range tc.txs {
 			var newTx *types.Transaction
-			if tx.good {
-				newTx = tx.CreateGood(t, signer)
+			if tx.propose {
+				chainID := cfg.L2ChainID
+				if tx.chainID != 0 {
+					chainID = big.NewInt(int64(tx.chainID))
+				}
+				newTx = tx.CreatePropose(t, signer, cfg, chainID, rng)
 			} else {
 				newTx = tx.Create(t, signer, rng)
 			}
 
-
 			txs = append(txs, newTx)
+			receipts = append(receipts, testReceipt(tx.reverted))
 			if tx.good {
 				expectedData = append(expectedData, txs[i].Data())
 			}
 		}
 
-		out := DataFromEVMTransactions(cfg, batcherAddr, txs, testlog.Logger(t, log.LvlCrit))
+		out := DataFromEVMTransactions(cfg, batcherAddr, txs, receipts, testlog.Logger(t, log.LvlCrit))
 		require.ElementsMatch(t, expectedData, out)
 	}
+}
+
+// TestDataFromEVMTransactionsBatcherRotation rotates the batcher through a SystemConfig update log
+// and asserts that propose calls are only accepted from the batcher that is active at each L1 block.
+func TestDataFromEVMTransactionsBatcherRotation(t *testing.T) {
+	testDataFromEVMTransactionsRotation(t, SystemConfigUpdateBatcher)
+}
 
+// TestDataFromEVMTransactionsProposerRotation schedules a new proposer through a SystemConfig update log
+// and asserts that propose calls are only accepted from the proposer scheduled for each L1 block.
+func TestDataFromEVMTransactionsProposerRotation(t *testing.T) {
+	testDataFromEVMTransactionsRotation(t, SystemConfigUpdateProposer)
+}
+
+func testDataFromEVMTransactionsRotation(t *testing.T, updateType common.Hash) {
+	rng := rand.New(rand.NewSource(1234))
+	oldBatcherPriv := testutils.RandomKey()
+	newBatcherPriv := testutils.RandomKey()
+	oldBatcher := crypto.PubkeyToAddress(oldBatcherPriv.PublicKey)
+	newBatcher := crypto.PubkeyToAddress(newBatcherPriv.PublicKey)
+
+	sysCfg := eth.SystemConfig{
+		BatcherAddr: oldBatcher,
+		Overhead:    [32]byte{42},
+		Scalar:      [32]byte{69},
+	}
+	cfg := &rollup.Config{
+		Genesis:               rollup.Genesis{SystemConfig: sysCfg},
+		L1ChainID:             big.NewInt(100),
+		L2ChainID:             big.NewInt(200),
+		L1SystemConfigAddress: testutils.RandomAddress(rng),
+		DataStreamAddress:     testutils.RandomAddress(rng),
+		ProposeSelector:       eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+		ProposerRotationTime:  new(uint64),
+	}
+	signer := cfg.L1Signer()
+	oldTx := (&testTx{to: &cfg.DataStreamAddress, dataLen: 100, author: oldBatcherPriv}).CreatePropose(t, signer, cfg, cfg.L2ChainID, rng)
+	newTx := (&testTx{to: &cfg.DataStreamAddress, dataLen: 100, author: newBatcherPriv}).CreatePropose(t, signer, cfg, cfg.L2ChainID, rng)
+	txs := types.Transactions{oldTx, newTx}
+	receipts := types.Receipts{testReceipt(false), testReceipt(false)}
+
+	// The L1 block after a is the one that updates the SystemConfig. The batcher is rotated
+	// in that block, the proposer is scheduled from the block after it.
+	a := testutils.RandomBlockRef(rng)
+	b := testutils.NextRandomRef(rng, a)
+	c := testutils.NextRandomRef(rng, b)
+	rotated := b
+	var updateData []byte
+	var err error
+	if updateType == SystemConfigUpdateProposer {
+		rotated = c
+		updateData, err = proposerArgs.Pack(&newBatcher, c.Number)
+	} else {
+		updateData, err = addressArgs.Pack(&newBatcher)
+	}
+	require.NoError(t, err)
+	logData, err := bytesArgs.Pack(updateData)
+	require.NoError(t, err)
+	rotation := &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		Logs: []*types.Log{{
+			Address: cfg.L1SystemConfigAddress,
+			Topics:  []common.Hash{ConfigUpdateEventABIHash, ConfigUpdateEventVersion0, updateType},
+			Data:    logData,
+		}},
+	}
+	src := &testutils.MockL1Source{}
+	src.ExpectL1BlockRefByNumber(b.Number, b, nil)
+	src.ExpectFetchReceipts(b.Hash, &testutils.MockBlockInfo{InfoHash: b.Hash, InfoNum: b.Number}, []*types.Receipt{rotation}, nil)
+	src.ExpectL1BlockRefByNumber(c.Number, c, nil)
+	src.ExpectFetchReceipts(c.Hash, &testutils.MockBlockInfo{InfoHash: c.Hash, InfoNum: c.Number}, nil, nil)
+
+	logger := testlog.Logger(t, log.LvlCrit)
+	tr := NewL1Traversal(logger, cfg, src)
+	_ = tr.Reset(context.Background(), a, sysCfg)
+
+	for _, block := range []eth.L1BlockRef{a, b, c} {
+		if block != a {
+			require.NoError(t, tr.AdvanceL1Block(context.Background()))
+		}
+		out := DataFromEVMTransactions(cfg, ProposerAddr(tr.SystemConfig()), txs, receipts, logger)
+		if block.Number < rotated.Number {
+			require.Equal(t, []eth.Data{oldTx.Data()}, out, "before the rotation, only the old proposer is authorized")
+		} else {
+			require.Equal(t, []eth.Data{newTx.Data()}, out, "from the rotation block onwards, only the new proposer is authorized")
+		}
+	}
+
+	src.AssertExpectations(t)
+}
+
+// TestDataSourceFetchesReceipts asserts that the receipts of an L1 block are only fetched
+// if the block contains a transaction from the proposer to the data stream contract.
+func TestDataSourceFetchesReceipts(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	proposerPriv := testutils.RandomKey()
+	proposer := crypto.PubkeyToAddress(proposerPriv.PublicKey)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		L2ChainID:         big.NewInt(200),
+		DataStreamAddress: testutils.RandomAddress(rng),
+		ProposeSelector:   eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+	}
+	signer := cfg.L1Signer()
+	logger := testlog.Logger(t, log.LvlCrit)
+
+	otherTx := (&testTx{to: &cfg.DataStreamAddress, dataLen: 100, author: testutils.RandomKey()}).CreatePropose(t, signer, cfg, cfg.L2ChainID, rng)
+	proposeTx := (&testTx{to: &cfg.DataStreamAddress, dataLen: 100, author: proposerPriv}).CreatePropose(t, signer, cfg, cfg.L2ChainID, rng)
+
+	t.Run("no proposer tx", func(t *testing.T) {
+		block := testutils.RandomBlockRef(rng)
+		src := &testutils.MockEthClient{}
+		src.ExpectInfoAndTxsByHash(block.Hash, &testutils.MockBlockInfo{InfoHash: block.Hash}, types.Transactions{otherTx}, nil)
+
+		ds := NewDataSource(context.Background(), logger, cfg, src, block.ID(), proposer)
+		_, err := ds.Next(context.Background())
+		require.ErrorIs(t, err, io.EOF)
+		src.AssertExpectations(t)
+	})
+
+	t.Run("proposer tx", func(t *testing.T) {
+		block := testutils.RandomBlockRef(rng)
+		src := &testutils.MockEthClient{}
+		src.ExpectInfoAndTxsByHash(block.Hash, &testutils.MockBlockInfo{InfoHash: block.Hash}, types.Transactions{otherTx, proposeTx}, nil)
+		src.ExpectFetchReceipts(block.Hash, &testutils.MockBlockInfo{InfoHash: block.Hash}, types.Receipts{testReceipt(false), testReceipt(false)}, nil)
+
+		ds := NewDataSource(context.Background(), logger, cfg, src, block.ID(), proposer)
+		data, err := ds.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data(proposeTx.Data()), data)
+		_, err = ds.Next(context.Background())
+		require.ErrorIs(t, err, io.EOF)
+		src.AssertExpectations(t)
+	})
+
+	t.Run("missing receipts", func(t *testing.T) {
+		out := DataFromEVMTransactions(cfg, proposer, types.Transactions{otherTx, proposeTx}, types.Receipts{testReceipt(false)}, logger)
+		require.Empty(t, out)
+	})
 }
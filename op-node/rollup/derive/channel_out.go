@@ -219,7 +219,7 @@ func BlockToBatch(block *types.Block) (*BatchData, L1BlockInfo, error) {
 	}
 
 	return &BatchData{
-		BatchV1{
+		BatchV1: BatchV1{
 			ParentHash:   block.ParentHash(),
 			EpochNum:     rollup.Epoch(l1Info.Number),
 			EpochHash:    l1Info.BlockHash,
@@ -20,11 +20,13 @@ import (
 type attributesWithParent struct {
 	attributes *eth.PayloadAttributes
 	parent     eth.L2BlockRef
+	// the L2 block that the sequencer proposed for these attributes, if any
+	proposal *BlockProposal
 }
 
 type NextAttributesProvider interface {
 	Origin() eth.L1BlockRef
-	NextAttributes(context.Context, eth.L2BlockRef) (*eth.PayloadAttributes, error)
+	NextAttributes(context.Context, eth.L2BlockRef) (*eth.PayloadAttributes, *BlockProposal, error)
 }
 
 type Engine interface {
@@ -63,6 +65,14 @@ type EngineControl interface {
 	BuildingPayload() (onto eth.L2BlockRef, id eth.PayloadID, safe bool)
 }
 
+// SignedBlocks checks the blocks proposed on L1 against the unsafe blocks that the sequencer signed and gossiped.
+type SignedBlocks interface {
+	// OnProposedBlock is called when the block proposed on L1 at a height is not the unsafe block at that height.
+	// It returns true if the sequencer signed the unsafe block, which makes the proposal an equivocation,
+	// and persists the evidence.
+	OnProposedBlock(ctx context.Context, unsafe eth.BlockID, proposed common.Hash) bool
+}
+
 // Max memory used for buffering unsafe payloads
 const maxUnsafePayloadsMemory = 500 * 1024 * 1024
 
@@ -105,6 +115,8 @@ type EngineQueue struct {
 	buildingOnto eth.L2BlockRef
 	buildingID   eth.PayloadID
 	buildingSafe bool
+	// the L2 block that the payload being built must match, if it was proposed on L1
+	buildingProposal *BlockProposal
 
 	// Track when the rollup node changes the forkchoice without engine action,
 	// e.g. on a reset after a reorg, or after consolidating a block.
@@ -133,12 +145,14 @@ type EngineQueue struct {
 
 	metrics   Metrics
 	l1Fetcher L1Fetcher
+
+	signedBlocks SignedBlocks // optional, nil if the node does not receive the gossiped blocks
 }
 
 var _ EngineControl = (*EngineQueue)(nil)
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, signedBlocks SignedBlocks) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
@@ -148,6 +162,7 @@ func NewEngineQueue(log log.Logger, cfg *rollup.Config, engine Engine, metrics M
 		unsafePayloads: NewPayloadsQueue(maxUnsafePayloadsMemory, payloadMemSize),
 		prev:           prev,
 		l1Fetcher:      l1Fetcher,
+		signedBlocks:   signedBlocks,
 	}
 }
 
@@ -240,7 +255,7 @@ func (eq *EngineQueue) Step(ctx context.Context) error {
 	if err := eq.tryFinalizePastL2Blocks(ctx); err != nil {
 		return err
 	}
-	if next, err := eq.prev.NextAttributes(ctx, eq.safeHead); err == io.EOF {
+	if next, proposal, err := eq.prev.NextAttributes(ctx, eq.safeHead); err == io.EOF {
 		outOfData = true
 	} else if err != nil {
 		return err
@@ -248,6 +263,7 @@ func (eq *EngineQueue) Step(ctx context.Context) error {
 		eq.safeAttributes = &attributesWithParent{
 			attributes: next,
 			parent:     eq.safeHead,
+			proposal:   proposal,
 		}
 		eq.log.Debug("Adding next safe attributes", "safe_head", eq.safeHead, "next", next)
 		return NotEnoughData
@@ -535,6 +551,17 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 		// geth cannot wind back a chain without reorging to a new, previously non-canonical, block
 		return eq.forceNextSafeAttributes(ctx)
 	}
+	if proposal := eq.safeAttributes.proposal; proposal != nil && proposal.Hash != payload.BlockHash {
+		// Proposing another block on L1 than the unsafe block that the sequencer signed at the same height
+		// is an equivocation. The unsafe block may also not be signed, e.g. if it was synced from a peer.
+		if eq.signedBlocks != nil && eq.signedBlocks.OnProposedBlock(ctx, payload.ID(), proposal.Hash) {
+			eq.log.Error("L2 reorg: existing unsafe block signed by the sequencer does not match block proposed on L1, sequencer equivocated",
+				"proposed", proposal.ID(), "unsafe_block", payload.ID(), "unsafe", eq.unsafeHead, "safe", eq.safeHead)
+		} else {
+			eq.log.Warn("L2 reorg: existing unsafe block does not match block proposed on L1", "proposed", proposal.ID(), "unsafe", eq.unsafeHead, "safe", eq.safeHead)
+		}
+		return eq.forceNextSafeAttributes(ctx)
+	}
 	ref, err := PayloadToBlockRef(payload, &eq.cfg.Genesis)
 	if err != nil {
 		return NewResetError(fmt.Errorf("failed to decode L2 block ref from payload: %w", err))
@@ -558,6 +585,7 @@ func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 	attrs := eq.safeAttributes.attributes
 	errType, err := eq.StartPayload(ctx, eq.safeHead, attrs, true)
 	if err == nil {
+		eq.buildingProposal = eq.safeAttributes.proposal
 		_, errType, err = eq.ConfirmPayload(ctx)
 	}
 	if err != nil {
@@ -568,6 +596,13 @@ func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 		case BlockInsertPrestateErr:
 			_ = eq.CancelPayload(ctx, true)
 			return NewResetError(fmt.Errorf("need reset to resolve pre-state problem: %w", err))
+		case BlockInsertProposalErr:
+			_ = eq.CancelPayload(ctx, true)
+			// The sequencer announced a different block than the one derived from its batch:
+			// the derived block is not inserted, and the batch is dropped.
+			eq.log.Warn("derived block does not match block proposed on L1, dropping batch", "err", err)
+			eq.safeAttributes = nil
+			return nil
 		case BlockInsertPayloadErr:
 			_ = eq.CancelPayload(ctx, true)
 			eq.log.Warn("could not process payload derived from L1 data, dropping batch", "err", err)
@@ -634,7 +669,7 @@ func (eq *EngineQueue) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPa
 		SafeBlockHash:      eq.safeHead.Hash,
 		FinalizedBlockHash: eq.finalized.Hash,
 	}
-	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingID, eq.buildingSafe)
+	payload, errTyp, err := ConfirmPayload(ctx, eq.log, eq.engine, fc, eq.buildingID, eq.buildingSafe, eq.buildingProposal)
 	if err != nil {
 		return nil, errTyp, fmt.Errorf("failed to complete building on top of L2 chain %s, id: %s, error (%d): %w", eq.buildingOnto, eq.buildingID, errTyp, err)
 	}
@@ -680,6 +715,7 @@ func (eq *EngineQueue) resetBuildingState() {
 	eq.buildingID = eth.PayloadID{}
 	eq.buildingOnto = eth.L2BlockRef{}
 	eq.buildingSafe = false
+	eq.buildingProposal = nil
 }
 
 // ResetStep Walks the L2 chain backwards until it finds an L2 block whose L1 origin is canonical.
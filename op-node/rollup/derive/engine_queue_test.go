@@ -2,6 +2,7 @@ package derive
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -22,19 +23,20 @@ import (
 )
 
 type fakeAttributesQueue struct {
-	origin eth.L1BlockRef
-	attrs  *eth.PayloadAttributes
+	origin   eth.L1BlockRef
+	attrs    *eth.PayloadAttributes
+	proposal *BlockProposal
 }
 
 func (f *fakeAttributesQueue) Origin() eth.L1BlockRef {
 	return f.origin
 }
 
-func (f *fakeAttributesQueue) NextAttributes(_ context.Context, _ eth.L2BlockRef) (*eth.PayloadAttributes, error) {
+func (f *fakeAttributesQueue) NextAttributes(_ context.Context, _ eth.L2BlockRef) (*eth.PayloadAttributes, *BlockProposal, error) {
 	if f.attrs == nil {
-		return nil, io.EOF
+		return nil, nil, io.EOF
 	}
-	return f.attrs, nil
+	return f.attrs, f.proposal, nil
 }
 
 var _ NextAttributesProvider = (*fakeAttributesQueue)(nil)
@@ -246,7 +248,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 
 	prev := &fakeAttributesQueue{}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -480,7 +482,7 @@ func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refE}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -811,7 +813,7 @@ func TestVerifyNewL1Origin(t *testing.T) {
 			}, nil)
 
 			prev := &fakeAttributesQueue{origin: refE}
-			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+			eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil)
 			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 			require.Equal(t, refB1, eq.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -909,7 +911,7 @@ func TestBlockBuildingRace(t *testing.T) {
 	}
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs}
-	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics, prev, l1F, nil)
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	id := eth.PayloadID{0xff}
@@ -1079,7 +1081,7 @@ func TestResetLoop(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs}
 
-	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F)
+	eq := NewEngineQueue(logger, cfg, eng, metrics.NoopMetrics, prev, l1F, nil)
 	eq.unsafeHead = refA2
 	eq.safeHead = refA1
 	eq.finalized = refA0
@@ -1107,3 +1109,215 @@ func TestResetLoop(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+// TestEngineQueueProposal checks that a block derived from L1 is only inserted
+// if it matches the block that the sequencer proposed for it.
+func TestEngineQueueProposal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+
+	refA := testutils.RandomBlockRef(rng)
+	refA0 := eth.L2BlockRef{
+		Hash:           testutils.RandomHash(rng),
+		Number:         0,
+		ParentHash:     common.Hash{},
+		Time:           refA.Time,
+		L1Origin:       refA.ID(),
+		SequenceNumber: 0,
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     refA.ID(),
+			L2:     refA0.ID(),
+			L2Time: refA0.Time,
+			SystemConfig: eth.SystemConfig{
+				BatcherAddr: common.Address{42},
+				Overhead:    [32]byte{123},
+				Scalar:      [32]byte{42},
+				GasLimit:    20_000_000,
+			},
+		},
+		BlockTime:     1,
+		SeqWindowSize: 2,
+	}
+	refA1 := eth.L2BlockRef{
+		Hash:           testutils.RandomHash(rng),
+		Number:         refA0.Number + 1,
+		ParentHash:     refA0.Hash,
+		Time:           refA0.Time + cfg.BlockTime,
+		L1Origin:       refA.ID(),
+		SequenceNumber: 1,
+	}
+
+	gasLimit := eth.Uint64Quantity(20_000_000)
+	attrs := &eth.PayloadAttributes{
+		Timestamp:             eth.Uint64Quantity(refA1.Time),
+		PrevRandao:            eth.Bytes32{},
+		SuggestedFeeRecipient: common.Address{},
+		Transactions:          nil,
+		NoTxPool:              false,
+		GasLimit:              &gasLimit,
+	}
+	a1InfoTx, err := L1InfoDepositBytes(refA1.SequenceNumber, &testutils.MockBlockInfo{
+		InfoHash:       refA.Hash,
+		InfoParentHash: refA.ParentHash,
+		InfoNum:        refA.Number,
+		InfoTime:       refA.Time,
+		InfoBaseFee:    big.NewInt(7),
+	}, cfg.Genesis.SystemConfig, false)
+	require.NoError(t, err)
+	payloadA1 := &eth.ExecutionPayload{
+		ParentHash:    refA1.ParentHash,
+		FeeRecipient:  attrs.SuggestedFeeRecipient,
+		BlockNumber:   eth.Uint64Quantity(refA1.Number),
+		GasLimit:      gasLimit,
+		Timestamp:     eth.Uint64Quantity(refA1.Time),
+		BaseFeePerGas: *uint256.NewInt(7),
+		BlockHash:     refA1.Hash,
+		Transactions:  []eth.Data{a1InfoTx},
+	}
+
+	testCases := []struct {
+		name     string
+		proposal *BlockProposal
+		inserted bool
+	}{
+		{name: "no proposal", proposal: nil, inserted: true},
+		{name: "matching proposal", proposal: &BlockProposal{Number: refA1.Number, Hash: refA1.Hash}, inserted: true},
+		{name: "other hash", proposal: &BlockProposal{Number: refA1.Number, Hash: testutils.RandomHash(rng)}, inserted: false},
+		{name: "other number", proposal: &BlockProposal{Number: refA1.Number + 1, Hash: refA1.Hash}, inserted: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := testlog.Logger(t, log.LvlInfo)
+			eng := &testutils.MockEngine{}
+			l1F := &testutils.MockL1Source{}
+
+			eng.ExpectL2BlockRefByLabel(eth.Finalized, refA0, nil)
+			eng.ExpectL2BlockRefByLabel(eth.Safe, refA0, nil)
+			eng.ExpectL2BlockRefByLabel(eth.Unsafe, refA0, nil)
+			l1F.ExpectL1BlockRefByNumber(refA.Number, refA, nil)
+			l1F.ExpectL1BlockRefByHash(refA.Hash, refA, nil)
+			l1F.ExpectL1BlockRefByHash(refA.Hash, refA, nil)
+			eng.ExpectSystemConfigByL2Hash(refA0.Hash, cfg.Genesis.SystemConfig, nil)
+
+			prev := &fakeAttributesQueue{origin: refA, attrs: attrs, proposal: tc.proposal}
+			eq := NewEngineQueue(logger, cfg, eng, &testutils.TestDerivationMetrics{}, prev, l1F, nil)
+			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
+
+			id := eth.PayloadID{0xff}
+			preFc := &eth.ForkchoiceState{
+				HeadBlockHash:      refA0.Hash,
+				SafeBlockHash:      refA0.Hash,
+				FinalizedBlockHash: refA0.Hash,
+			}
+			preFcRes := &eth.ForkchoiceUpdatedResult{
+				PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &refA0.Hash},
+				PayloadID:     &id,
+			}
+			eng.ExpectForkchoiceUpdate(preFc, nil, preFcRes, nil)
+			require.NoError(t, eq.Step(context.Background()), "clean forkchoice state after reset")
+			require.ErrorIs(t, eq.Step(context.Background()), NotEnoughData, "queue up attributes")
+
+			eng.ExpectForkchoiceUpdate(preFc, attrs, preFcRes, nil)
+			eng.ExpectGetPayload(id, payloadA1, nil)
+			if tc.inserted {
+				eng.ExpectNewPayload(payloadA1, &eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &refA1.Hash}, nil)
+				postFc := &eth.ForkchoiceState{
+					HeadBlockHash:      refA1.Hash,
+					SafeBlockHash:      refA1.Hash,
+					FinalizedBlockHash: refA0.Hash,
+				}
+				eng.ExpectForkchoiceUpdate(postFc, nil, &eth.ForkchoiceUpdatedResult{
+					PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid, LatestValidHash: &refA1.Hash},
+				}, nil)
+			} else {
+				// the building job is wrapped up by fetching the payload again
+				eng.ExpectGetPayload(id, payloadA1, nil)
+			}
+			require.NoError(t, eq.Step(context.Background()), "process attributes")
+			require.Nil(t, eq.safeAttributes, "attributes are consumed or dropped")
+			if tc.inserted {
+				require.Equal(t, refA1, eq.SafeL2Head())
+				require.Equal(t, refA1, eq.UnsafeL2Head())
+			} else {
+				require.Equal(t, refA0, eq.SafeL2Head())
+				require.Equal(t, refA0, eq.UnsafeL2Head())
+			}
+
+			l1F.AssertExpectations(t)
+			eng.AssertExpectations(t)
+		})
+	}
+}
+
+type fakeSignedBlocks struct {
+	signed   bool
+	proposed []common.Hash
+}
+
+func (f *fakeSignedBlocks) OnProposedBlock(ctx context.Context, unsafe eth.BlockID, proposed common.Hash) bool {
+	f.proposed = append(f.proposed, proposed)
+	return f.signed
+}
+
+// TestEngineQueueProposalEquivocation checks that an unsafe block that differs from the block proposed
+// on L1 at the same height is reorged out, and checked against the blocks signed by the sequencer.
+func TestEngineQueueProposalEquivocation(t *testing.T) {
+	for _, signed := range []bool{true, false} {
+		t.Run(fmt.Sprintf("signed=%v", signed), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1234))
+			logger := testlog.Logger(t, log.LvlInfo)
+
+			refA := testutils.RandomBlockRef(rng)
+			refA0 := eth.L2BlockRef{
+				Hash:     testutils.RandomHash(rng),
+				Number:   0,
+				Time:     refA.Time,
+				L1Origin: refA.ID(),
+			}
+			refA1 := eth.L2BlockRef{
+				Hash:           testutils.RandomHash(rng),
+				Number:         refA0.Number + 1,
+				ParentHash:     refA0.Hash,
+				Time:           refA0.Time + 1,
+				L1Origin:       refA.ID(),
+				SequenceNumber: 1,
+			}
+			gasLimit := eth.Uint64Quantity(20_000_000)
+			attrs := &eth.PayloadAttributes{
+				Timestamp:    eth.Uint64Quantity(refA1.Time),
+				Transactions: []eth.Data{{0x7e, 1}},
+				GasLimit:     &gasLimit,
+			}
+			unsafeA1 := &eth.ExecutionPayload{
+				ParentHash:   refA1.ParentHash,
+				BlockNumber:  eth.Uint64Quantity(refA1.Number),
+				GasLimit:     gasLimit,
+				Timestamp:    eth.Uint64Quantity(refA1.Time),
+				BlockHash:    refA1.Hash,
+				Transactions: attrs.Transactions,
+			}
+			proposal := &BlockProposal{Number: refA1.Number, Hash: testutils.RandomHash(rng)}
+
+			eng := &testutils.MockEngine{}
+			signedBlocks := &fakeSignedBlocks{signed: signed}
+			eq := NewEngineQueue(logger, &rollup.Config{}, eng, &testutils.TestDerivationMetrics{}, &fakeAttributesQueue{origin: refA}, &testutils.MockL1Source{}, signedBlocks)
+			eq.unsafeHead = refA1
+			eq.safeHead = refA0
+			eq.finalized = refA0
+			eq.safeAttributes = &attributesWithParent{
+				attributes: attrs,
+				parent:     refA0,
+				proposal:   proposal,
+			}
+
+			eng.ExpectPayloadByNumber(refA1.Number, unsafeA1, nil)
+			// the conflicting unsafe block is reorged out, by building the derived block on the safe head
+			fc := &eth.ForkchoiceState{HeadBlockHash: refA0.Hash, SafeBlockHash: refA0.Hash, FinalizedBlockHash: refA0.Hash}
+			eng.ExpectForkchoiceUpdate(fc, attrs, nil, errors.New("engine offline"))
+			require.ErrorIs(t, eq.tryNextSafeAttributes(context.Background()), ErrTemporary)
+			require.Equal(t, []common.Hash{proposal.Hash}, signedBlocks.proposed)
+			eng.AssertExpectations(t)
+		})
+	}
+}
@@ -77,6 +77,9 @@ const (
 	BlockInsertPrestateErr
 	// BlockInsertPayloadErr indicates that the payload was invalid and cannot become canonical.
 	BlockInsertPayloadErr
+	// BlockInsertProposalErr indicates that the payload does not match the block that was proposed on L1 for it,
+	// and must not become canonical.
+	BlockInsertProposalErr
 )
 
 // StartPayload starts an execution payload building process in the provided Engine, with the given attributes.
@@ -116,8 +119,9 @@ func StartPayload(ctx context.Context, eng Engine, fc eth.ForkchoiceState, attrs
 
 // ConfirmPayload ends an execution payload building process in the provided Engine, and persists the payload as the canonical head.
 // If updateSafe is true, then the payload will also be recognized as safe-head at the same time.
+// If a proposal is given, then the payload is only inserted if it matches the proposed block.
 // The severity of the error is distinguished to determine whether the payload was valid and can become canonical.
-func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
+func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool, proposal *BlockProposal) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
 	payload, err := eng.GetPayload(ctx, id)
 	if err != nil {
 		// even if it is an input-error (unknown payload ID), it is temporary, since we will re-attempt the full payload building, not just the retrieval of the payload.
@@ -126,6 +130,9 @@ func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.Fork
 	if err := sanityCheckPayload(payload); err != nil {
 		return nil, BlockInsertPayloadErr, err
 	}
+	if proposal != nil && (proposal.Hash != payload.BlockHash || proposal.Number != uint64(payload.BlockNumber)) {
+		return nil, BlockInsertProposalErr, fmt.Errorf("payload %s does not match proposed block %s", payload.ID(), proposal.ID())
+	}
 
 	status, err := eng.NewPayload(ctx, payload)
 	if err != nil {
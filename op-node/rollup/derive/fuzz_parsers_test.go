@@ -288,3 +288,46 @@ func FuzzUnmarshallLogEvent(f *testing.F) {
 		}
 	})
 }
+
+// FuzzProposeCallRoundTrip checks that the propose call encoder round trips properly
+func FuzzProposeCallRoundTrip(f *testing.F) {
+	f.Fuzz(func(t *testing.T, chainID uint32, blockNumber, blockHash, block []byte) {
+		in := ProposeCall{
+			ChainID:     chainID,
+			BlockNumber: BytesToBigInt(blockNumber),
+			BlockHash:   common.BytesToHash(blockHash),
+			Block:       block,
+		}
+		selector := eth.Bytes4{0x74, 0x12, 0x3b, 0xf9}
+		enc, err := EncodeProposeCall(selector, &in)
+		if err != nil {
+			t.Fatalf("Failed to encode propose call: %v", err)
+		}
+		out, err := DecodeProposeCall(selector, enc)
+		if err != nil {
+			t.Fatalf("Failed to decode propose call: %v", err)
+		}
+		if !cmp.Equal(in, *out, cmp.Comparer(testutils.BigEqual), cmp.Comparer(bytes.Equal)) {
+			t.Fatalf("The data did not round trip correctly. in: %v. out: %v", in, out)
+		}
+	})
+}
+
+// FuzzDecodeProposeCall checks that decoding arbitrary calldata does not panic
+func FuzzDecodeProposeCall(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		selector := eth.Bytes4{0x74, 0x12, 0x3b, 0xf9}
+		_, _ = DecodeProposeCall(selector, append(selector[:], data...))
+	})
+}
+
+// FuzzDecodeProposeRangeCall checks that decoding arbitrary calldata does not panic
+func FuzzDecodeProposeRangeCall(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		selector := eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f}
+		call, err := DecodeProposeRangeCall(selector, append(selector[:], data...))
+		if err == nil {
+			_, _ = call.Calls()
+		}
+	})
+}
@@ -19,6 +19,9 @@ const (
 	L1InfoFuncSignature = "setL1BlockValues(uint64,uint64,uint256,bytes32,uint64,bytes32,uint256,uint256)"
 	L1InfoArguments     = 8
 	L1InfoLen           = 4 + 32*L1InfoArguments
+	// L1InfoProposerLen is the length of the L1 info with the proposer schedule appended,
+	// which the L1Block contract ignores.
+	L1InfoProposerLen = L1InfoLen + 32*3
 )
 
 var (
@@ -44,6 +47,10 @@ type L1BlockInfo struct {
 	BatcherAddr   common.Address
 	L1FeeOverhead eth.Bytes32
 	L1FeeScalar   eth.Bytes32
+	// The proposer schedule of the SystemConfig, only encoded if a proposer is set or scheduled.
+	Proposer          common.Address
+	NextProposer      common.Address
+	NextProposerBlock uint64
 }
 
 // Binary Format
@@ -59,7 +66,14 @@ type L1BlockInfo struct {
 // | 32      | BatcherAddr              |
 // | 32      | L1FeeOverhead            |
 // | 32      | L1FeeScalar              |
+// | 32      | Proposer (optional)      |
+// | 32      | NextProposer (optional)  |
+// | 32      | NextProposerBlock (opt.) |
 // +---------+--------------------------+
+//
+// The proposer schedule is appended only if a proposer is set or scheduled. Proposer updates are
+// only derived once the proposer rotation is active (see rollup.Config.ProposerRotationTime), so the
+// L1 info keeps its format before the upgrade, and on chains without proposer rotation.
 
 func (info *L1BlockInfo) MarshalBinary() ([]byte, error) {
 	w := bytes.NewBuffer(make([]byte, 0, L1InfoLen))
@@ -90,11 +104,23 @@ func (info *L1BlockInfo) MarshalBinary() ([]byte, error) {
 	if err := solabi.WriteEthBytes32(w, info.L1FeeScalar); err != nil {
 		return nil, err
 	}
+	if info.Proposer == (common.Address{}) && info.NextProposerBlock == 0 {
+		return w.Bytes(), nil
+	}
+	if err := solabi.WriteAddress(w, info.Proposer); err != nil {
+		return nil, err
+	}
+	if err := solabi.WriteAddress(w, info.NextProposer); err != nil {
+		return nil, err
+	}
+	if err := solabi.WriteUint64(w, info.NextProposerBlock); err != nil {
+		return nil, err
+	}
 	return w.Bytes(), nil
 }
 
 func (info *L1BlockInfo) UnmarshalBinary(data []byte) error {
-	if len(data) != L1InfoLen {
+	if len(data) != L1InfoLen && len(data) != L1InfoProposerLen {
 		return fmt.Errorf("data is unexpected length: %d", len(data))
 	}
 	reader := bytes.NewReader(data)
@@ -127,6 +153,17 @@ func (info *L1BlockInfo) UnmarshalBinary(data []byte) error {
 	if info.L1FeeScalar, err = solabi.ReadEthBytes32(reader); err != nil {
 		return err
 	}
+	if len(data) == L1InfoProposerLen {
+		if info.Proposer, err = solabi.ReadAddress(reader); err != nil {
+			return err
+		}
+		if info.NextProposer, err = solabi.ReadAddress(reader); err != nil {
+			return err
+		}
+		if info.NextProposerBlock, err = solabi.ReadUint64(reader); err != nil {
+			return err
+		}
+	}
 	if !solabi.EmptyReader(reader) {
 		return errors.New("too many bytes")
 	}
@@ -152,6 +189,10 @@ func L1InfoDeposit(seqNumber uint64, block eth.BlockInfo, sysCfg eth.SystemConfi
 		BatcherAddr:    sysCfg.BatcherAddr,
 		L1FeeOverhead:  sysCfg.Overhead,
 		L1FeeScalar:    sysCfg.Scalar,
+
+		Proposer:          sysCfg.Proposer,
+		NextProposer:      sysCfg.NextProposer,
+		NextProposerBlock: sysCfg.NextProposerBlock,
 	}
 	data, err := infoDat.MarshalBinary()
 	if err != nil {
@@ -32,6 +32,14 @@ func randomL1Cfg(rng *rand.Rand, l1Info eth.BlockInfo) eth.SystemConfig {
 	}
 }
 
+func proposerL1Cfg(rng *rand.Rand, l1Info eth.BlockInfo) eth.SystemConfig {
+	cfg := randomL1Cfg(rng, l1Info)
+	cfg.Proposer = testutils.RandomAddress(rng)
+	cfg.NextProposer = testutils.RandomAddress(rng)
+	cfg.NextProposerBlock = l1Info.NumberU64() + 10
+	return cfg
+}
+
 var MockDepositContractAddr = common.HexToAddress("0xdeadbeefdeadbeefdeadbeefdeadbeef00000000")
 
 func TestParseL1InfoDepositTxData(t *testing.T) {
@@ -58,6 +66,7 @@ func TestParseL1InfoDepositTxData(t *testing.T) {
 		}, randomL1Cfg, func(rng *rand.Rand) uint64 {
 			return 0
 		}},
+		{"proposer schedule", testutils.MakeBlockInfo(nil), proposerL1Cfg, randomSeqNr},
 	}
 	for i, testCase := range cases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -78,8 +87,18 @@ func TestParseL1InfoDepositTxData(t *testing.T) {
 			assert.Equal(t, res.BatcherAddr, l1Cfg.BatcherAddr)
 			assert.Equal(t, res.L1FeeOverhead, l1Cfg.Overhead)
 			assert.Equal(t, res.L1FeeScalar, l1Cfg.Scalar)
+			assert.Equal(t, res.Proposer, l1Cfg.Proposer)
+			assert.Equal(t, res.NextProposer, l1Cfg.NextProposer)
+			assert.Equal(t, res.NextProposerBlock, l1Cfg.NextProposerBlock)
 		})
 	}
+	t.Run("no proposer schedule", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1234))
+		info := testutils.MakeBlockInfo(nil)(rng)
+		depTx, err := L1InfoDeposit(randomSeqNr(rng), info, randomL1Cfg(rng, info), false)
+		require.NoError(t, err)
+		require.Len(t, depTx.Data, L1InfoLen, "the L1 info is unchanged without proposer rotation")
+	})
 	t.Run("no data", func(t *testing.T) {
 		_, err := L1InfoDepositTxData(nil)
 		assert.Error(t, err)
@@ -11,7 +11,7 @@ import (
 )
 
 type DataAvailabilitySource interface {
-	OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter
+	OpenData(ctx context.Context, id eth.BlockID, proposer common.Address) DataIter
 }
 
 type NextBlockProvider interface {
@@ -53,7 +53,7 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		} else if err != nil {
 			return nil, err
 		}
-		l1r.datas = l1r.dataSrc.OpenData(ctx, next.ID(), l1r.prev.SystemConfig().BatcherAddr)
+		l1r.datas = l1r.dataSrc.OpenData(ctx, next.ID(), ProposerAddr(l1r.prev.SystemConfig()))
 	}
 
 	l1r.log.Debug("fetching next piece of data")
@@ -73,7 +73,7 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 // Note that we open up the `l1r.datas` here because it is requires to maintain the
 // internal invariants that later propagate up the derivation pipeline.
 func (l1r *L1Retrieval) Reset(ctx context.Context, base eth.L1BlockRef, sysCfg eth.SystemConfig) error {
-	l1r.datas = l1r.dataSrc.OpenData(ctx, base.ID(), sysCfg.BatcherAddr)
+	l1r.datas = l1r.dataSrc.OpenData(ctx, base.ID(), ProposerAddr(sysCfg))
 	l1r.log.Info("Reset of L1Retrieval done", "origin", base)
 	return io.EOF
 }
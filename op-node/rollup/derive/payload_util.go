@@ -75,6 +75,10 @@ func PayloadToSystemConfig(payload *eth.ExecutionPayload, cfg *rollup.Config) (e
 			Overhead:    info.L1FeeOverhead,
 			Scalar:      info.L1FeeScalar,
 			GasLimit:    uint64(payload.GasLimit),
+
+			Proposer:          info.Proposer,
+			NextProposer:      info.NextProposer,
+			NextProposerBlock: info.NextProposerBlock,
 		}, err
 	}
 }
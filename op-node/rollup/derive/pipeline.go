@@ -75,7 +75,8 @@ type DerivationPipeline struct {
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
-func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics) *DerivationPipeline {
+// signedBlocks is optional, it flags the blocks proposed on L1 that conflict with signed unsafe blocks.
+func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, signedBlocks SignedBlocks) *DerivationPipeline {
 
 	// Pull stages
 	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher)
@@ -84,13 +85,13 @@ func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetch
 	// frameQueue := NewFrameQueue(log, l1Src)
 	// bank := NewChannelBank(log, cfg, frameQueue, l1Fetcher)
 	// chInReader := NewChannelInReader(log, bank, metrics)
-	batchProvider := NewBatchProvider(log, l1Src)
+	batchProvider := NewBatchProvider(log, cfg, l1Src)
 	batchQueue := NewBatchQueue(log, cfg, batchProvider)
 	attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, engine)
 	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue)
 
 	// Step stages
-	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher)
+	eng := NewEngineQueue(log, cfg, engine, metrics, attributesQueue, l1Fetcher, signedBlocks)
 
 	// Reset from engine queue then up from L1 Traversal. The stages do not talk to each other during
 	// the reset, but after the engine queue, this is the order in which the stages could talk to each other.
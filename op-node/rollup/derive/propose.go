@@ -0,0 +1,193 @@
+package derive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+const (
+	ProposeFuncSignature      = "propose(uint32,uint256,bytes32,bytes)"
+	ProposeRangeFuncSignature = "proposeRange(uint32,uint256,bytes32[],bytes[])"
+)
+
+var (
+	ProposeFuncBytes4      = crypto.Keccak256([]byte(ProposeFuncSignature))[:4]
+	ProposeRangeFuncBytes4 = crypto.Keccak256([]byte(ProposeRangeFuncSignature))[:4]
+
+	ErrInvalidProposeSelector = errors.New("invalid propose function selector")
+	ErrInvalidProposeRange    = errors.New("invalid propose range")
+
+	proposeArguments = func() abi.Arguments {
+		uint32T, _ := abi.NewType("uint32", "", nil)
+		uint256T, _ := abi.NewType("uint256", "", nil)
+		bytes32T, _ := abi.NewType("bytes32", "", nil)
+		bytesT, _ := abi.NewType("bytes", "", nil)
+		return abi.Arguments{
+			{Name: "chainID", Type: uint32T},
+			{Name: "blockNumber", Type: uint256T},
+			{Name: "blockHash", Type: bytes32T},
+			{Name: "block", Type: bytesT},
+		}
+	}()
+
+	proposeRangeArguments = func() abi.Arguments {
+		uint32T, _ := abi.NewType("uint32", "", nil)
+		uint256T, _ := abi.NewType("uint256", "", nil)
+		bytes32ArrayT, _ := abi.NewType("bytes32[]", "", nil)
+		bytesArrayT, _ := abi.NewType("bytes[]", "", nil)
+		return abi.Arguments{
+			{Name: "chainID", Type: uint32T},
+			{Name: "firstBlockNumber", Type: uint256T},
+			{Name: "blockHashes", Type: bytes32ArrayT},
+			{Name: "blocks", Type: bytesArrayT},
+		}
+	}()
+)
+
+// ProposeCall presents the arguments of a call to the data stream contract:
+//
+//	function propose(uint32 chainID, uint256 blockNumber, bytes32 blockHash, bytes calldata block)
+//
+// The block is the encoded batch of the proposed L2 block.
+type ProposeCall struct {
+	ChainID     uint32
+	BlockNumber *big.Int
+	BlockHash   common.Hash
+	Block       []byte
+}
+
+// Proposal returns the L2 block that the propose call announced.
+func (c *ProposeCall) Proposal() (*BlockProposal, error) {
+	if !c.BlockNumber.IsUint64() {
+		return nil, fmt.Errorf("proposed block number %v does not fit in uint64", c.BlockNumber)
+	}
+	return &BlockProposal{Number: c.BlockNumber.Uint64(), Hash: c.BlockHash}, nil
+}
+
+// BlockProposal is the L2 block that the sequencer announced along with a batch in a propose call.
+// The block derived from the batch must match it.
+type BlockProposal struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+func (p *BlockProposal) ID() eth.BlockID {
+	return eth.BlockID{Hash: p.Hash, Number: p.Number}
+}
+
+// EncodeProposeCall returns the calldata of the propose call, prefixed with the given function selector.
+func EncodeProposeCall(selector eth.Bytes4, call *ProposeCall) ([]byte, error) {
+	args, err := proposeArguments.Pack(call.ChainID, call.BlockNumber, call.BlockHash, call.Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode propose call: %w", err)
+	}
+	return append(selector[:], args...), nil
+}
+
+// DecodeProposeCall decodes the calldata of a propose call, which must start with the given function selector.
+func DecodeProposeCall(selector eth.Bytes4, data []byte) (*ProposeCall, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], selector[:]) {
+		return nil, ErrInvalidProposeSelector
+	}
+	values, err := proposeArguments.UnpackValues(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode propose call: %w", err)
+	}
+	return &ProposeCall{
+		ChainID:     values[0].(uint32),
+		BlockNumber: values[1].(*big.Int),
+		BlockHash:   values[2].([32]byte),
+		Block:       values[3].([]byte),
+	}, nil
+}
+
+// ProposeRangeCall presents the arguments of a call to the data stream contract that proposes
+// a contiguous range of L2 blocks at once:
+//
+//	function proposeRange(uint32 chainID, uint256 firstBlockNumber, bytes32[] calldata blockHashes, bytes[] calldata blocks)
+//
+// The blocks are the encoded batches of the proposed L2 blocks, starting at firstBlockNumber.
+type ProposeRangeCall struct {
+	ChainID          uint32
+	FirstBlockNumber *big.Int
+	BlockHashes      []common.Hash
+	Blocks           [][]byte
+}
+
+// Calls returns the propose calls of the individual blocks of the range.
+func (c *ProposeRangeCall) Calls() ([]*ProposeCall, error) {
+	if len(c.Blocks) == 0 || len(c.BlockHashes) != len(c.Blocks) {
+		return nil, fmt.Errorf("%w: %d block hashes for %d blocks", ErrInvalidProposeRange, len(c.BlockHashes), len(c.Blocks))
+	}
+	calls := make([]*ProposeCall, len(c.Blocks))
+	for i := range c.Blocks {
+		calls[i] = &ProposeCall{
+			ChainID:     c.ChainID,
+			BlockNumber: new(big.Int).Add(c.FirstBlockNumber, big.NewInt(int64(i))),
+			BlockHash:   c.BlockHashes[i],
+			Block:       c.Blocks[i],
+		}
+	}
+	return calls, nil
+}
+
+// EncodeProposeRangeCall returns the calldata of the propose range call, prefixed with the given function selector.
+func EncodeProposeRangeCall(selector eth.Bytes4, call *ProposeRangeCall) ([]byte, error) {
+	hashes := make([][32]byte, len(call.BlockHashes))
+	for i, h := range call.BlockHashes {
+		hashes[i] = h
+	}
+	args, err := proposeRangeArguments.Pack(call.ChainID, call.FirstBlockNumber, hashes, call.Blocks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode propose range call: %w", err)
+	}
+	return append(selector[:], args...), nil
+}
+
+// DecodeProposeRangeCall decodes the calldata of a propose range call, which must start with the given function selector.
+func DecodeProposeRangeCall(selector eth.Bytes4, data []byte) (*ProposeRangeCall, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], selector[:]) {
+		return nil, ErrInvalidProposeSelector
+	}
+	values, err := proposeRangeArguments.UnpackValues(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode propose range call: %w", err)
+	}
+	hashes := values[2].([][32]byte)
+	call := &ProposeRangeCall{
+		ChainID:          values[0].(uint32),
+		FirstBlockNumber: values[1].(*big.Int),
+		BlockHashes:      make([]common.Hash, len(hashes)),
+		Blocks:           values[3].([][]byte),
+	}
+	for i, h := range hashes {
+		call.BlockHashes[i] = h
+	}
+	return call, nil
+}
+
+// DecodeProposeCalls decodes the calldata of a propose call, or of a propose range call if the rollup
+// accepts them, into the propose calls of the individual blocks. The calls are for the same L2 chain.
+func DecodeProposeCalls(cfg *rollup.Config, data []byte) ([]*ProposeCall, error) {
+	call, err := DecodeProposeCall(cfg.ProposeSelector, data)
+	if err == nil {
+		return []*ProposeCall{call}, nil
+	}
+	if !errors.Is(err, ErrInvalidProposeSelector) || !cfg.ProposeRangeEnabled() {
+		return nil, err
+	}
+	rangeCall, err := DecodeProposeRangeCall(cfg.ProposeRangeSelector, data)
+	if err != nil {
+		return nil, err
+	}
+	return rangeCall.Calls()
+}
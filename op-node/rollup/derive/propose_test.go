@@ -0,0 +1,179 @@
+package derive
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+func TestProposeFuncBytes4(t *testing.T) {
+	require.Equal(t, []byte{0x74, 0x12, 0x3b, 0xf9}, ProposeFuncBytes4)
+}
+
+func TestProposeCallRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	selector := eth.Bytes4{0x74, 0x12, 0x3b, 0xf9}
+	for _, size := range []int{0, 1, 31, 32, 33, 1000} {
+		in := &ProposeCall{
+			ChainID:     rng.Uint32(),
+			BlockNumber: big.NewInt(rng.Int63()),
+			BlockHash:   testutils.RandomHash(rng),
+			Block:       testutils.RandomData(rng, size),
+		}
+		data, err := EncodeProposeCall(selector, in)
+		require.NoError(t, err)
+		// selector, three static words, offset and length of the block, and the padded block
+		require.Equal(t, 4+5*32+(size+31)/32*32, len(data))
+
+		out, err := DecodeProposeCall(selector, data)
+		require.NoError(t, err)
+		require.Equal(t, in.ChainID, out.ChainID)
+		require.Zero(t, in.BlockNumber.Cmp(out.BlockNumber))
+		require.Equal(t, in.BlockHash, out.BlockHash)
+		require.Equal(t, in.Block, out.Block)
+	}
+}
+
+func TestDecodeProposeCallInvalid(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	selector := eth.Bytes4{0x74, 0x12, 0x3b, 0xf9}
+	data, err := EncodeProposeCall(selector, &ProposeCall{
+		ChainID:     1,
+		BlockNumber: big.NewInt(2),
+		BlockHash:   testutils.RandomHash(rng),
+		Block:       testutils.RandomData(rng, 100),
+	})
+	require.NoError(t, err)
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := DecodeProposeCall(selector, nil)
+		require.ErrorIs(t, err, ErrInvalidProposeSelector)
+	})
+	t.Run("other selector", func(t *testing.T) {
+		_, err := DecodeProposeCall(eth.Bytes4{1, 2, 3, 4}, data)
+		require.ErrorIs(t, err, ErrInvalidProposeSelector)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		_, err := DecodeProposeCall(selector, data[:len(data)-32])
+		require.Error(t, err)
+	})
+	t.Run("no arguments", func(t *testing.T) {
+		_, err := DecodeProposeCall(selector, data[:4])
+		require.Error(t, err)
+	})
+}
+
+func TestProposeCallProposal(t *testing.T) {
+	call := &ProposeCall{BlockNumber: big.NewInt(42), BlockHash: common.Hash{0xaa}}
+	proposal, err := call.Proposal()
+	require.NoError(t, err)
+	require.Equal(t, &BlockProposal{Number: 42, Hash: common.Hash{0xaa}}, proposal)
+
+	call.BlockNumber = new(big.Int).Lsh(big.NewInt(1), 64)
+	_, err = call.Proposal()
+	require.Error(t, err)
+}
+
+func TestProposeRangeFuncBytes4(t *testing.T) {
+	require.Equal(t, []byte{0x7a, 0xbd, 0xdb, 0x0f}, ProposeRangeFuncBytes4)
+}
+
+func randomProposeRangeCall(rng *rand.Rand, sizes ...int) *ProposeRangeCall {
+	call := &ProposeRangeCall{
+		ChainID:          rng.Uint32(),
+		FirstBlockNumber: big.NewInt(rng.Int63n(1 << 32)),
+	}
+	for _, size := range sizes {
+		call.BlockHashes = append(call.BlockHashes, testutils.RandomHash(rng))
+		call.Blocks = append(call.Blocks, testutils.RandomData(rng, size))
+	}
+	return call
+}
+
+func TestProposeRangeCallRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	selector := eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f}
+	in := randomProposeRangeCall(rng, 0, 1, 32, 33, 1000)
+	data, err := EncodeProposeRangeCall(selector, in)
+	require.NoError(t, err)
+
+	out, err := DecodeProposeRangeCall(selector, data)
+	require.NoError(t, err)
+	require.Equal(t, in.ChainID, out.ChainID)
+	require.Zero(t, in.FirstBlockNumber.Cmp(out.FirstBlockNumber))
+	require.Equal(t, in.BlockHashes, out.BlockHashes)
+	require.Equal(t, in.Blocks, out.Blocks)
+
+	_, err = DecodeProposeRangeCall(eth.Bytes4{0x74, 0x12, 0x3b, 0xf9}, data)
+	require.ErrorIs(t, err, ErrInvalidProposeSelector)
+	_, err = DecodeProposeRangeCall(selector, data[:len(data)-32])
+	require.Error(t, err)
+}
+
+func TestProposeRangeCallCalls(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	call := randomProposeRangeCall(rng, 10, 20, 30)
+	calls, err := call.Calls()
+	require.NoError(t, err)
+	require.Len(t, calls, 3)
+	for i, c := range calls {
+		require.Equal(t, call.ChainID, c.ChainID)
+		require.Equal(t, call.FirstBlockNumber.Int64()+int64(i), c.BlockNumber.Int64())
+		require.Equal(t, call.BlockHashes[i], c.BlockHash)
+		require.Equal(t, call.Blocks[i], c.Block)
+	}
+
+	call.BlockHashes = call.BlockHashes[:2]
+	_, err = call.Calls()
+	require.ErrorIs(t, err, ErrInvalidProposeRange)
+	_, err = (&ProposeRangeCall{FirstBlockNumber: big.NewInt(1)}).Calls()
+	require.ErrorIs(t, err, ErrInvalidProposeRange)
+}
+
+func TestDecodeProposeCalls(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{
+		ProposeSelector:      eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+		ProposeRangeSelector: eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f},
+	}
+	single := &ProposeCall{
+		ChainID:     1,
+		BlockNumber: big.NewInt(2),
+		BlockHash:   testutils.RandomHash(rng),
+		Block:       testutils.RandomData(rng, 100),
+	}
+	singleData, err := EncodeProposeCall(cfg.ProposeSelector, single)
+	require.NoError(t, err)
+	rangeCall := randomProposeRangeCall(rng, 100, 200)
+	rangeData, err := EncodeProposeRangeCall(cfg.ProposeRangeSelector, rangeCall)
+	require.NoError(t, err)
+
+	calls, err := DecodeProposeCalls(cfg, singleData)
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Equal(t, single.BlockHash, calls[0].BlockHash)
+
+	calls, err = DecodeProposeCalls(cfg, rangeData)
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+	require.Equal(t, rangeCall.BlockHashes[1], calls[1].BlockHash)
+
+	_, err = DecodeProposeCalls(cfg, append([]byte{1, 2, 3, 4}, rangeData[4:]...))
+	require.ErrorIs(t, err, ErrInvalidProposeSelector)
+
+	// range calls are rejected if the rollup does not accept them
+	cfg.ProposeRangeSelector = eth.Bytes4{}
+	_, err = DecodeProposeCalls(cfg, rangeData)
+	require.ErrorIs(t, err, ErrInvalidProposeSelector)
+	// including calls with the zero selector that disables them
+	_, err = DecodeProposeCalls(cfg, append([]byte{0, 0, 0, 0}, rangeData[4:]...))
+	require.ErrorIs(t, err, ErrInvalidProposeSelector)
+}
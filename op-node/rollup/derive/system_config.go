@@ -20,6 +20,10 @@ var (
 	SystemConfigUpdateGasConfig         = common.Hash{31: 1}
 	SystemConfigUpdateGasLimit          = common.Hash{31: 2}
 	SystemConfigUpdateUnsafeBlockSigner = common.Hash{31: 3}
+	// SystemConfigUpdateProposer schedules a new proposer of the data stream, which takes over from a given
+	// L1 block on. The proposer is the only account whose propose calls are derived, and it signs the
+	// unsafe blocks on the P2P network.
+	SystemConfigUpdateProposer = common.Hash{31: 4}
 )
 
 var (
@@ -28,8 +32,9 @@ var (
 	ConfigUpdateEventVersion0 = common.Hash{}
 )
 
-// UpdateSystemConfigWithL1Receipts filters all L1 receipts to find config updates and applies the config updates to the given sysCfg
-func UpdateSystemConfigWithL1Receipts(sysCfg *eth.SystemConfig, receipts []*types.Receipt, cfg *rollup.Config) error {
+// UpdateSystemConfigWithL1Receipts filters all L1 receipts to find config updates and applies the config updates to the given sysCfg.
+// l1Time is the timestamp of the L1 block of the receipts.
+func UpdateSystemConfigWithL1Receipts(sysCfg *eth.SystemConfig, receipts []*types.Receipt, cfg *rollup.Config, l1Time uint64) error {
 	var result error
 	for i, rec := range receipts {
 		if rec.Status != types.ReceiptStatusSuccessful {
@@ -37,7 +42,7 @@ func UpdateSystemConfigWithL1Receipts(sysCfg *eth.SystemConfig, receipts []*type
 		}
 		for j, log := range rec.Logs {
 			if log.Address == cfg.L1SystemConfigAddress && len(log.Topics) > 0 && log.Topics[0] == ConfigUpdateEventABIHash {
-				if err := ProcessSystemConfigUpdateLogEvent(sysCfg, log); err != nil {
+				if err := ProcessSystemConfigUpdateLogEvent(sysCfg, log, cfg, l1Time); err != nil {
 					result = multierror.Append(result, fmt.Errorf("malformatted L1 system sysCfg log in receipt %d, log %d: %w", i, j, err))
 				}
 			}
@@ -46,7 +51,27 @@ func UpdateSystemConfigWithL1Receipts(sysCfg *eth.SystemConfig, receipts []*type
 	return result
 }
 
+// ApplyProposerSchedule starts the scheduled proposer rotation if the L1 block with the given number
+// is at or after its start block. It is applied to the system config of each L1 block before its updates.
+func ApplyProposerSchedule(sysCfg *eth.SystemConfig, l1Number uint64) {
+	if sysCfg.NextProposerBlock != 0 && l1Number >= sysCfg.NextProposerBlock {
+		sysCfg.Proposer = sysCfg.NextProposer
+		sysCfg.NextProposer = common.Address{}
+		sysCfg.NextProposerBlock = 0
+	}
+}
+
+// ProposerAddr returns the account whose propose calls are derived with the given system config:
+// the scheduled proposer, or the batcher if no proposer is set.
+func ProposerAddr(sysCfg eth.SystemConfig) common.Address {
+	if sysCfg.Proposer != (common.Address{}) {
+		return sysCfg.Proposer
+	}
+	return sysCfg.BatcherAddr
+}
+
 // ProcessSystemConfigUpdateLogEvent decodes an EVM log entry emitted by the system config contract and applies it as a system config change.
+// Proposer updates are ignored in L1 blocks before the proposer rotation is active at l1Time.
 //
 // parse log data for:
 //
@@ -55,7 +80,7 @@ func UpdateSystemConfigWithL1Receipts(sysCfg *eth.SystemConfig, receipts []*type
 //	    UpdateType indexed updateType,
 //	    bytes data
 //	);
-func ProcessSystemConfigUpdateLogEvent(destSysCfg *eth.SystemConfig, ev *types.Log) error {
+func ProcessSystemConfigUpdateLogEvent(destSysCfg *eth.SystemConfig, ev *types.Log, rollupCfg *rollup.Config, l1Time uint64) error {
 	if len(ev.Topics) != 3 {
 		return fmt.Errorf("expected 3 event topics (event identity, indexed version, indexed updateType), got %d", len(ev.Topics))
 	}
@@ -129,6 +154,31 @@ func ProcessSystemConfigUpdateLogEvent(destSysCfg *eth.SystemConfig, ev *types.L
 		}
 		destSysCfg.GasLimit = gasLimit
 		return nil
+	case SystemConfigUpdateProposer:
+		if !rollupCfg.IsProposerRotation(l1Time) {
+			// Not derived before the upgrade, the schedule and the L1 info deposit stay unchanged.
+			return nil
+		}
+		if pointer, err := solabi.ReadUint64(reader); err != nil || pointer != 32 {
+			return NewCriticalError(errors.New("invalid pointer field"))
+		}
+		if length, err := solabi.ReadUint64(reader); err != nil || length != 64 {
+			return NewCriticalError(errors.New("invalid length field"))
+		}
+		proposer, err := solabi.ReadAddress(reader)
+		if err != nil {
+			return NewCriticalError(errors.New("could not read proposer"))
+		}
+		startBlock, err := solabi.ReadUint64(reader)
+		if err != nil {
+			return NewCriticalError(errors.New("could not read start block"))
+		}
+		if !solabi.EmptyReader(reader) {
+			return NewCriticalError(errors.New("too many bytes"))
+		}
+		destSysCfg.NextProposer = proposer
+		destSysCfg.NextProposerBlock = startBlock
+		return nil
 	case SystemConfigUpdateUnsafeBlockSigner:
 		// Ignored in derivation. This configurable applies to runtime configuration outside of the derivation.
 		return nil
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -16,6 +17,7 @@ var (
 	dynBytes, _ = abi.NewType("bytes", "", nil)
 	address, _  = abi.NewType("address", "", nil)
 	uint256T, _ = abi.NewType("uint256", "", nil)
+	uint64T, _  = abi.NewType("uint64", "", nil)
 	addressArgs = abi.Arguments{
 		{Type: address},
 	}
@@ -29,6 +31,10 @@ var (
 	oneUint256 = abi.Arguments{
 		{Type: uint256T},
 	}
+	proposerArgs = abi.Arguments{
+		{Type: address},
+		{Type: uint64T},
+	}
 )
 
 // TestProcessSystemConfigUpdateLogEvent tests the parsing of an event and mutating the
@@ -36,9 +42,22 @@ var (
 // a new SystemConfig and apply a log against it and then assert that the mutated system
 // config is equal to the defined system config in the test.
 func TestProcessSystemConfigUpdateLogEvent(t *testing.T) {
+	proposerRotationTime := uint64(1000)
+	rollupCfg := &rollup.Config{ProposerRotationTime: &proposerRotationTime}
+	proposerUpdate := func(t *testing.T, log *types.Log) *types.Log {
+		addr := common.Address{19: 0xbb}
+		proposerData, err := proposerArgs.Pack(&addr, uint64(100))
+		require.NoError(t, err)
+		data, err := bytesArgs.Pack(proposerData)
+		require.NoError(t, err)
+		log.Data = data
+		return log
+	}
+
 	tests := []struct {
 		name   string
 		log    *types.Log
+		l1Time uint64
 		config eth.SystemConfig
 		hook   func(*testing.T, *types.Log) *types.Log
 		err    bool
@@ -88,6 +107,39 @@ func TestProcessSystemConfigUpdateLogEvent(t *testing.T) {
 			},
 			err: false,
 		},
+		{
+			// The new proposer is scheduled, the batcher address is unchanged.
+			name: "SystemConfigUpdateProposer",
+			log: &types.Log{
+				Topics: []common.Hash{
+					ConfigUpdateEventABIHash,
+					ConfigUpdateEventVersion0,
+					SystemConfigUpdateProposer,
+				},
+			},
+			l1Time: proposerRotationTime,
+			hook:   proposerUpdate,
+			config: eth.SystemConfig{
+				NextProposer:      common.Address{19: 0xbb},
+				NextProposerBlock: 100,
+			},
+			err: false,
+		},
+		{
+			// The proposer update is ignored before the proposer rotation is active.
+			name: "SystemConfigUpdateProposerInactive",
+			log: &types.Log{
+				Topics: []common.Hash{
+					ConfigUpdateEventABIHash,
+					ConfigUpdateEventVersion0,
+					SystemConfigUpdateProposer,
+				},
+			},
+			l1Time: proposerRotationTime - 1,
+			hook:   proposerUpdate,
+			config: eth.SystemConfig{},
+			err:    false,
+		},
 		{
 			// The overhead and the scalar should be updated.
 			name: "SystemConfigUpdateGasConfig",
@@ -158,7 +210,7 @@ func TestProcessSystemConfigUpdateLogEvent(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			config := eth.SystemConfig{}
 
-			err := ProcessSystemConfigUpdateLogEvent(&config, test.hook(t, test.log))
+			err := ProcessSystemConfigUpdateLogEvent(&config, test.hook(t, test.log), rollupCfg, test.l1Time)
 			if test.err {
 				require.Error(t, err)
 			} else {
@@ -168,3 +220,28 @@ func TestProcessSystemConfigUpdateLogEvent(t *testing.T) {
 		})
 	}
 }
+
+// TestApplyProposerSchedule checks that the scheduled proposer takes over at its start block,
+// and that the batcher proposes while no proposer is set.
+func TestApplyProposerSchedule(t *testing.T) {
+	batcher := common.Address{19: 0xaa}
+	proposer := common.Address{19: 0xbb}
+	config := eth.SystemConfig{
+		BatcherAddr:       batcher,
+		NextProposer:      proposer,
+		NextProposerBlock: 100,
+	}
+	require.Equal(t, batcher, ProposerAddr(config))
+
+	ApplyProposerSchedule(&config, 99)
+	require.Equal(t, batcher, ProposerAddr(config), "the rotation has not started")
+
+	ApplyProposerSchedule(&config, 100)
+	require.Equal(t, proposer, ProposerAddr(config))
+	require.Equal(t, eth.SystemConfig{BatcherAddr: batcher, Proposer: proposer}, config)
+
+	// the zero address hands the proposals back to the batcher
+	config.NextProposerBlock = 120
+	ApplyProposerSchedule(&config, 121)
+	require.Equal(t, batcher, ProposerAddr(config))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"time"
 
@@ -29,11 +30,15 @@ var (
 	ErrMissingGasLimit               = errors.New("missing genesis system config gas limit")
 	ErrMissingBatchInboxAddress      = errors.New("missing batch inbox address")
 	ErrMissingDepositContractAddress = errors.New("missing deposit contract address")
+	ErrMissingDataStreamAddress      = errors.New("missing data stream address")
+	ErrMissingProposeSelector        = errors.New("missing data stream propose selector")
+	ErrProposeSelectorsSame          = errors.New("propose and propose range selectors must be different")
 	ErrMissingL1ChainID              = errors.New("L1 chain ID must not be nil")
 	ErrMissingL2ChainID              = errors.New("L2 chain ID must not be nil")
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrL2ChainIDNotUint32            = errors.New("L2 chain ID must fit in 32 bits, like the chain ID of data stream proposals")
 )
 
 type Genesis struct {
@@ -75,6 +80,12 @@ type Config struct {
 	// Active if RegolithTime != nil && L2 block timestamp >= *RegolithTime, inactive otherwise.
 	RegolithTime *uint64 `json:"regolith_time,omitempty"`
 
+	// ProposerRotationTime sets the activation time of the proposer rotation of the data stream:
+	// the SystemConfig proposer schedule updates, and the schedule that the L1 info deposit carries.
+	// Active if ProposerRotationTime != nil && L1 block timestamp >= *ProposerRotationTime, inactive otherwise.
+	// The schedule is updated per L1 block, so the activation is checked against the L1 timestamp.
+	ProposerRotationTime *uint64 `json:"proposer_rotation_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -84,6 +95,13 @@ type Config struct {
 	DepositContractAddress common.Address `json:"deposit_contract_address"`
 	// L1 System Config Address
 	L1SystemConfigAddress common.Address `json:"l1_system_config_address"`
+	// L1 data stream contract that the sequencer proposes L2 blocks to.
+	DataStreamAddress common.Address `json:"data_stream_address"`
+	// Function selector of the data stream contract's propose method.
+	ProposeSelector eth.Bytes4 `json:"propose_selector"`
+	// Function selector of the data stream contract's proposeRange method, which proposes several blocks at once.
+	// The zero selector disables propose range calls, it is also what a config without the field decodes to.
+	ProposeRangeSelector eth.Bytes4 `json:"propose_range_selector"`
 }
 
 // ValidateL1Config checks L1 config variables for errors.
@@ -229,6 +247,15 @@ func (cfg *Config) Check() error {
 	if cfg.DepositContractAddress == (common.Address{}) {
 		return ErrMissingDepositContractAddress
 	}
+	if cfg.DataStreamAddress == (common.Address{}) {
+		return ErrMissingDataStreamAddress
+	}
+	if cfg.ProposeSelector == (eth.Bytes4{}) {
+		return ErrMissingProposeSelector
+	}
+	if cfg.ProposeRangeSelector == cfg.ProposeSelector {
+		return ErrProposeSelectorsSame
+	}
 	if cfg.L1ChainID == nil {
 		return ErrMissingL1ChainID
 	}
@@ -244,9 +271,18 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	if !cfg.L2ChainID.IsUint64() || cfg.L2ChainID.Uint64() > math.MaxUint32 {
+		return ErrL2ChainIDNotUint32
+	}
 	return nil
 }
 
+// ProposeRangeEnabled returns whether the data stream accepts propose range calls,
+// which is the case if the propose range selector is not zero.
+func (c *Config) ProposeRangeEnabled() bool {
+	return c.ProposeRangeSelector != (eth.Bytes4{})
+}
+
 func (c *Config) L1Signer() types.Signer {
 	return types.NewLondonSigner(c.L1ChainID)
 }
@@ -256,6 +292,11 @@ func (c *Config) IsRegolith(timestamp uint64) bool {
 	return c.RegolithTime != nil && timestamp >= *c.RegolithTime
 }
 
+// IsProposerRotation returns true if the proposer rotation is active at or past the given L1 timestamp.
+func (c *Config) IsProposerRotation(l1Timestamp uint64) bool {
+	return c.ProposerRotationTime != nil && l1Timestamp >= *c.ProposerRotationTime
+}
+
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
 // Optionally provide a mapping of L2 chain IDs to network names to label the L2 chain with if not unknown.
 // The config should be config.Check()-ed before creating a description.
@@ -283,6 +324,7 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	// Report the upgrade configuration
 	banner += "Post-Bedrock Network Upgrades (timestamp based):\n"
 	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
+	banner += fmt.Sprintf("  - Proposer rotation (L1 timestamp): %s\n", fmtForkTimeOrUnset(c.ProposerRotationTime))
 	return banner
 }
 
@@ -305,7 +347,8 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 	log.Info("Rollup Config", "l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
 		"l1_network", networkL1, "l2_start_time", c.Genesis.L2Time, "l2_block_hash", c.Genesis.L2.Hash.String(),
 		"l2_block_number", c.Genesis.L2.Number, "l1_block_hash", c.Genesis.L1.Hash.String(),
-		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime))
+		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime),
+		"proposer_rotation_time", fmtForkTimeOrUnset(c.ProposerRotationTime))
 }
 
 func fmtForkTimeOrUnset(v *uint64) string {
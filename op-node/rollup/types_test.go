@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -48,6 +49,8 @@ func randConfig() *Config {
 		BatchInboxAddress:      randAddr(),
 		DepositContractAddress: randAddr(),
 		L1SystemConfigAddress:  randAddr(),
+		DataStreamAddress:      randAddr(),
+		ProposeSelector:        eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
 	}
 }
 
@@ -60,6 +63,22 @@ func TestConfigJSON(t *testing.T) {
 	assert.Equal(t, &roundTripped, config)
 }
 
+// TestProposeRangeDisabled checks that a config without a propose range selector round-trips
+// through JSON with the zero selector, which disables propose range calls.
+func TestProposeRangeDisabled(t *testing.T) {
+	config := randConfig()
+	require.False(t, config.ProposeRangeEnabled())
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"propose_range_selector":"0x00000000"`)
+	var roundTripped Config
+	require.NoError(t, json.Unmarshal(data, &roundTripped))
+	require.False(t, roundTripped.ProposeRangeEnabled())
+
+	config.ProposeRangeSelector = eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f}
+	require.True(t, config.ProposeRangeEnabled())
+}
+
 type mockL1Client struct {
 	chainID *big.Int
 	Hash    common.Hash
@@ -351,6 +370,21 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.DepositContractAddress = common.Address{} },
 			expectedErr: ErrMissingDepositContractAddress,
 		},
+		{
+			name:        "NoDataStreamAddress",
+			modifier:    func(cfg *Config) { cfg.DataStreamAddress = common.Address{} },
+			expectedErr: ErrMissingDataStreamAddress,
+		},
+		{
+			name:        "NoProposeSelector",
+			modifier:    func(cfg *Config) { cfg.ProposeSelector = eth.Bytes4{} },
+			expectedErr: ErrMissingProposeSelector,
+		},
+		{
+			name:        "ProposeSelectorsEqual",
+			modifier:    func(cfg *Config) { cfg.ProposeRangeSelector = cfg.ProposeSelector },
+			expectedErr: ErrProposeSelectorsSame,
+		},
 		{
 			name:        "NoL1ChainId",
 			modifier:    func(cfg *Config) { cfg.L1ChainID = nil },
@@ -386,6 +420,16 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.L2ChainID = big.NewInt(0) },
 			expectedErr: ErrL2ChainIDNotPositive,
 		},
+		{
+			name:        "L2ChainIdAboveUint32",
+			modifier:    func(cfg *Config) { cfg.L2ChainID = new(big.Int).SetUint64(math.MaxUint32 + 1) },
+			expectedErr: ErrL2ChainIDNotUint32,
+		},
+		{
+			name:        "L2ChainIdAboveUint64",
+			modifier:    func(cfg *Config) { cfg.L2ChainID = new(big.Int).Lsh(big.NewInt(1), 64) },
+			expectedErr: ErrL2ChainIDNotUint32,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -41,3 +41,9 @@ func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	err := r.rpc.CallContext(ctx, &output, "optimism_version")
 	return output, err
 }
+
+func (r *RollupClient) GetBlockCommitment(ctx context.Context, blockNum uint64) (*eth.BlockCommitment, error) {
+	var output *eth.BlockCommitment
+	err := r.rpc.CallContext(ctx, &output, "optimism_getBlockCommitment", hexutil.Uint64(blockNum))
+	return output, err
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/preimage"
 )
 
+// The derivation of blocks proposed to the data stream contract reads the transactions of every
+// L1 block, and their receipts to skip reverted propose calls, so both are hinted per block hash.
 const (
 	HintL1BlockHeader  = "l1-block-header"
 	HintL1Transactions = "l1-transactions"
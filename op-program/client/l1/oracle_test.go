@@ -90,6 +90,7 @@ func testBlock(t *testing.T, block *types.Block, receipts []*types.Receipt) {
 	require.Equal(t, len(receipts), len(gotReceipts), "expecting equal tx list length")
 	for i, r := range gotReceipts {
 		require.Equalf(t, r.TxHash, expectedTxs[i].Hash(), "expecting receipt to match tx %d", i)
+		require.Equalf(t, receipts[i].Status, r.Status, "expecting receipt %d status to match", i)
 	}
 }
 
@@ -2,11 +2,14 @@ package main
 
 import (
 	"encoding/json"
+	"math/big"
 	"os"
 	"strconv"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,6 +26,29 @@ var (
 	l2ClaimBlockNumber = uint64(1203)
 	l2Genesis          = core.DefaultGoerliGenesisBlock()
 	l2GenesisConfig    = l2Genesis.Config
+	rollupConfig       = rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     eth.BlockID{Hash: common.Hash{0x01}, Number: 100},
+			L2:     eth.BlockID{Hash: common.Hash{0x02}, Number: 0},
+			L2Time: 1673550516,
+			SystemConfig: eth.SystemConfig{
+				BatcherAddr: common.Address{0x03},
+				Overhead:    eth.Bytes32{31: 0x08},
+				Scalar:      eth.Bytes32{31: 0x40},
+				GasLimit:    30_000_000,
+			},
+		},
+		BlockTime:              2,
+		MaxSequencerDrift:      600,
+		SeqWindowSize:          3600,
+		ChannelTimeout:         300,
+		L1ChainID:              big.NewInt(5),
+		L2ChainID:              big.NewInt(420),
+		BatchInboxAddress:      common.Address{0x04},
+		DepositContractAddress: common.Address{0x05},
+		DataStreamAddress:      common.Address{0x06},
+		ProposeSelector:        eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+	}
 )
 
 func TestLogLevel(t *testing.T) {
@@ -43,7 +69,7 @@ func TestLogLevel(t *testing.T) {
 func TestDefaultCLIOptionsMatchDefaultConfig(t *testing.T) {
 	cfg := configForArgs(t, addRequiredArgs(t))
 	defaultCfg := config.NewConfig(
-		&chaincfg.Goerli,
+		&rollupConfig,
 		l2GenesisConfig,
 		common.HexToHash(l1HeadValue),
 		common.HexToHash(l2HeadValue),
@@ -54,34 +80,36 @@ func TestDefaultCLIOptionsMatchDefaultConfig(t *testing.T) {
 
 func TestNetwork(t *testing.T) {
 	t.Run("Unknown", func(t *testing.T) {
-		verifyArgsInvalid(t, "invalid network bar", replaceRequiredArg(t, "--network", "bar"))
+		verifyArgsInvalid(t, "invalid network bar", addRequiredArgsExcept(t, "--rollup.config", "--network", "bar"))
 	})
 
 	t.Run("Required", func(t *testing.T) {
-		verifyArgsInvalid(t, "flag rollup.config or network is required", addRequiredArgsExcept(t, "--network"))
+		verifyArgsInvalid(t, "flag rollup.config or network is required", addRequiredArgsExcept(t, "--rollup.config"))
 	})
 
 	t.Run("DisallowNetworkAndRollupConfig", func(t *testing.T) {
-		verifyArgsInvalid(t, "cannot specify both rollup.config and network", addRequiredArgs(t, "--rollup.config=foo"))
+		verifyArgsInvalid(t, "cannot specify both rollup.config and network", addRequiredArgs(t, "--network=goerli"))
 	})
 
 	t.Run("RollupConfig", func(t *testing.T) {
-		dir := t.TempDir()
-		configJson, err := json.Marshal(chaincfg.Goerli)
-		require.NoError(t, err)
-		configFile := dir + "/config.json"
-		err = os.WriteFile(configFile, configJson, os.ModePerm)
-		require.NoError(t, err)
-
-		cfg := configForArgs(t, addRequiredArgsExcept(t, "--network", "--rollup.config", configFile))
-		require.Equal(t, chaincfg.Goerli, *cfg.Rollup)
+		cfg := configForArgs(t, addRequiredArgs(t))
+		require.Equal(t, rollupConfig, *cfg.Rollup)
 	})
 
+	withoutDataStream := make(map[string]bool)
+	for _, name := range chaincfg.NetworksWithoutDataStream() {
+		withoutDataStream[name] = true
+	}
 	for name, cfg := range chaincfg.NetworksByName {
 		name := name
 		expected := cfg
 		t.Run("Network_"+name, func(t *testing.T) {
-			cfg := configForArgs(t, replaceRequiredArg(t, "--network", name))
+			args := addRequiredArgsExcept(t, "--rollup.config", "--network", name)
+			if withoutDataStream[name] {
+				verifyArgsInvalid(t, "no data stream address or propose selector", args)
+				return
+			}
+			cfg := configForArgs(t, args)
 			require.Equal(t, expected, *cfg.Rollup)
 		})
 	}
@@ -259,8 +287,9 @@ func replaceRequiredArg(t *testing.T, name string, value string) []string {
 // to create a valid Config
 func requiredArgs(t *testing.T) map[string]string {
 	genesisFile := writeValidGenesis(t)
+	rollupConfigFile := writeValidRollupConfig(t)
 	return map[string]string{
-		"--network":        "goerli",
+		"--rollup.config":  rollupConfigFile,
 		"--l1.head":        l1HeadValue,
 		"--l2.head":        l2HeadValue,
 		"--l2.claim":       l2ClaimValue,
@@ -278,6 +307,15 @@ func writeValidGenesis(t *testing.T) string {
 	return genesisFile
 }
 
+func writeValidRollupConfig(t *testing.T) string {
+	dir := t.TempDir()
+	j, err := json.Marshal(&rollupConfig)
+	require.NoError(t, err)
+	cfgFile := dir + "/rollup.json"
+	require.NoError(t, os.WriteFile(cfgFile, j, 0666))
+	return cfgFile
+}
+
 func toArgList(req map[string]string) []string {
 	var combined []string
 	for name, value := range req {
@@ -1,9 +1,10 @@
 package config
 
 import (
+	"math/big"
 	"testing"
 
-	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
@@ -11,7 +12,29 @@ import (
 )
 
 var (
-	validRollupConfig    = &chaincfg.Goerli
+	validRollupConfig = &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     eth.BlockID{Hash: common.Hash{0x01}, Number: 100},
+			L2:     eth.BlockID{Hash: common.Hash{0x02}, Number: 0},
+			L2Time: 1673550516,
+			SystemConfig: eth.SystemConfig{
+				BatcherAddr: common.Address{0x03},
+				Overhead:    eth.Bytes32{31: 0x08},
+				Scalar:      eth.Bytes32{31: 0x40},
+				GasLimit:    30_000_000,
+			},
+		},
+		BlockTime:              2,
+		MaxSequencerDrift:      600,
+		SeqWindowSize:          3600,
+		ChannelTimeout:         300,
+		L1ChainID:              big.NewInt(5),
+		L2ChainID:              big.NewInt(420),
+		BatchInboxAddress:      common.Address{0x04},
+		DepositContractAddress: common.Address{0x05},
+		DataStreamAddress:      common.Address{0x06},
+		ProposeSelector:        eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+	}
 	validL2Genesis       = params.GoerliChainConfig
 	validL1Head          = common.Hash{0xaa}
 	validL2Head          = common.Hash{0xbb}
@@ -22,8 +22,10 @@ var (
 		EnvVar: service.PrefixEnvVar(envVarPrefix, "ROLLUP_CONFIG"),
 	}
 	Network = cli.StringFlag{
-		Name:   "network",
-		Usage:  fmt.Sprintf("Predefined network selection. Available networks: %s", strings.Join(chaincfg.AvailableNetworks(), ", ")),
+		Name: "network",
+		Usage: fmt.Sprintf("Predefined network selection. Available networks: %s. "+
+			"Networks without a data stream address and propose selector are rejected, use --%s instead: %s",
+			strings.Join(chaincfg.AvailableNetworks(), ", "), RollupConfig.Name, strings.Join(chaincfg.NetworksWithoutDataStream(), ", ")),
 		EnvVar: service.PrefixEnvVar(envVarPrefix, "NETWORK"),
 	}
 	DataDir = cli.StringFlag{
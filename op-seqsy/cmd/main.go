@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+
+	op_seqsy "github.com/ethereum-optimism/optimism/op-seqsy"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const envVarPrefix = "OP_SEQSY"
+
+var (
+	Version   = "v0.0.0"
+	GitCommit = ""
+	GitDate   = ""
+)
+
+var (
+	ChainsFlag = cli.IntFlag{
+		Name:   "chains",
+		Usage:  "Number of L2 chains that share the L1 chain and its data stream contract",
+		Value:  1,
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "CHAINS"),
+	}
+	VerifiersFlag = cli.IntFlag{
+		Name:   "verifiers",
+		Usage:  "Number of verifiers of every L2 chain",
+		Value:  1,
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "VERIFIERS"),
+	}
+	L1BlockTimeFlag = cli.Uint64Flag{
+		Name:   "l1.block-time",
+		Usage:  "Block time of the L1 chain in seconds",
+		Value:  2,
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "L1_BLOCK_TIME"),
+	}
+	L2BlockTimeFlag = cli.Uint64Flag{
+		Name:   "l2.block-time",
+		Usage:  "Block time of the L2 chains in seconds",
+		Value:  1,
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "L2_BLOCK_TIME"),
+	}
+	DataDirFlag = cli.StringFlag{
+		Name:   "datadir",
+		Usage:  "Directory of the devnet files, a temporary directory is used if empty",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "DATADIR"),
+	}
+	OutputFlag = cli.StringFlag{
+		Name:   "output",
+		Usage:  "File to write the JSON with all endpoints and keys of the devnet to, or - for stdout",
+		Value:  "-",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "OUTPUT"),
+	}
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	app.Name = "op-seqsy"
+	app.Usage = "Local seqsy stack"
+	app.Description = "Runs a complete local stack of L1 and L2 nodes, batchers and proposers in a single process"
+	app.Commands = []cli.Command{
+		{
+			Name:        "devnet",
+			Usage:       "Start a local devnet",
+			Description: "Starts one L1 chain and one or more L2 chains, and prints the endpoints and keys as JSON",
+			Flags: append([]cli.Flag{
+				ChainsFlag,
+				VerifiersFlag,
+				L1BlockTimeFlag,
+				L2BlockTimeFlag,
+				DataDirFlag,
+				OutputFlag,
+			}, oplog.CLIFlags(envVarPrefix)...),
+			Action: Devnet,
+		},
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+// Devnet runs a devnet until it is interrupted.
+func Devnet(ctx *cli.Context) error {
+	logCfg := oplog.ReadLocalCLIConfig(ctx)
+	if err := logCfg.Check(); err != nil {
+		return err
+	}
+	logger := oplog.NewLogger(logCfg)
+	// the geth nodes log to the root logger
+	log.Root().SetHandler(logger.GetHandler())
+
+	dir := ctx.String(DataDirFlag.Name)
+	if dir == "" {
+		tmp, err := os.MkdirTemp("", "op-seqsy-devnet")
+		if err != nil {
+			return fmt.Errorf("failed to create devnet directory: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+		dir = tmp
+	} else if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create devnet directory: %w", err)
+	}
+
+	devnet, err := op_seqsy.StartDevnet(op_seqsy.DevnetConfig{
+		Dir:         dir,
+		Chains:      ctx.Int(ChainsFlag.Name),
+		Verifiers:   ctx.Int(VerifiersFlag.Name),
+		L1BlockTime: ctx.Uint64(L1BlockTimeFlag.Name),
+		L2BlockTime: ctx.Uint64(L2BlockTimeFlag.Name),
+		Log:         logger,
+	})
+	if err != nil {
+		return err
+	}
+	defer devnet.Close()
+
+	if err := writeInfo(ctx.String(OutputFlag.Name), devnet.Info()); err != nil {
+		return err
+	}
+	logger.Info("Devnet started", "chains", len(devnet.Systems))
+
+	return opservice.CloseAction(func(ctx context.Context, shutdown <-chan struct{}) error {
+		<-shutdown
+		logger.Info("Stopping devnet")
+		return nil
+	})
+}
+
+func writeInfo(output string, info *op_seqsy.DevnetInfo) error {
+	out := os.Stdout
+	if output != "-" {
+		f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open devnet info file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(info); err != nil {
+		return fmt.Errorf("failed to write devnet info: %w", err)
+	}
+	return nil
+}
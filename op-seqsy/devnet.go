@@ -0,0 +1,244 @@
+package op_seqsy
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-seqsy/e2eutils"
+)
+
+// devnetBatcherIndex is the first account index of the test mnemonic that is used for the batchers
+// of the additional chains of a devnet. The accounts before it are used by the default roles and tooling.
+const devnetBatcherIndex = 20
+
+// DevnetConfig describes the topology of a local devnet: one L1 chain with the data stream contract,
+// and one or more rollups that propose their blocks to it.
+type DevnetConfig struct {
+	// Dir is the directory of the devnet files, like the JWT secret of the engine API.
+	Dir string
+
+	// Chains is the number of rollups. The first rollup owns the L1 chain and runs the proposer,
+	// the other rollups share its L1 contracts and use consecutive L2 chain IDs.
+	Chains int
+
+	// Verifiers is the number of verifiers of every rollup.
+	Verifiers int
+
+	// L1BlockTime and L2BlockTime are the block times in seconds, the defaults are used if zero.
+	L1BlockTime uint64
+	L2BlockTime uint64
+
+	Log log.Logger
+}
+
+func (c *DevnetConfig) Check() error {
+	if c.Dir == "" {
+		return errors.New("devnet directory must be set")
+	}
+	if c.Chains < 1 {
+		return errors.New("devnet must have at least one chain")
+	}
+	if c.Verifiers < 0 {
+		return errors.New("number of verifiers cannot be negative")
+	}
+	return nil
+}
+
+// Devnet is a running local devnet.
+type Devnet struct {
+	// Systems has one system per rollup, the first system owns the L1 chain.
+	Systems []*System
+}
+
+// verifierName returns the node name of the i-th verifier, the first verifier is named like in the tests.
+func verifierName(i int) string {
+	if i == 0 {
+		return "verifier"
+	}
+	return fmt.Sprintf("verifier%d", i)
+}
+
+// StartDevnet starts all the chains of the devnet. The devnet should be closed when done.
+func StartDevnet(cfg DevnetConfig) (*Devnet, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid devnet config: %w", err)
+	}
+	if cfg.Log == nil {
+		cfg.Log = log.Root()
+	}
+
+	cfgs := make([]SystemConfig, cfg.Chains)
+	for i := range cfgs {
+		sysCfg, err := NewSystemConfig(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.L1BlockTime != 0 {
+			sysCfg.DeployConfig.L1BlockTime = cfg.L1BlockTime
+		}
+		if cfg.L2BlockTime != 0 {
+			sysCfg.DeployConfig.L2BlockTime = cfg.L2BlockTime
+		}
+		sysCfg.DeployConfig.L2ChainID += uint64(i)
+
+		delete(sysCfg.Nodes, "verifier")
+		for j := 0; j < cfg.Verifiers; j++ {
+			sysCfg.AddVerifier(verifierName(j))
+		}
+		chainLog := cfg.Log.New("chain", sysCfg.DeployConfig.L2ChainID)
+		sysCfg.Loggers = make(map[string]log.Logger)
+		for name := range sysCfg.Nodes {
+			sysCfg.Loggers[name] = chainLog.New("role", name)
+		}
+		sysCfg.Loggers["batcher"] = chainLog.New("role", "batcher")
+		sysCfg.Loggers["proposer"] = chainLog.New("role", "proposer")
+
+		if i > 0 {
+			// Every rollup needs its own batcher account, to not share the L1 nonces with another batcher.
+			secrets := *sysCfg.Secrets
+			batcher, err := secrets.Wallet.PrivateKey(accounts.Account{
+				URL: accounts.URL{Path: fmt.Sprintf("m/44'/60'/0'/0/%d", devnetBatcherIndex+i)},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to derive batcher key of chain %d: %w", i, err)
+			}
+			secrets.Batcher = batcher
+			sysCfg.Secrets = &secrets
+			sysCfg.DeployConfig.BatchSenderAddress = crypto.PubkeyToAddress(batcher.PublicKey)
+			sysCfg.DeployConfig.L1GenesisBlockTimestamp = cfgs[0].DeployConfig.L1GenesisBlockTimestamp
+			// The L2 output oracle is owned by the first rollup
+			sysCfg.DisableProposer = true
+			// The L1 genesis is built by the first rollup, it funds the batchers of all rollups
+			cfgs[0].Premine[sysCfg.DeployConfig.BatchSenderAddress] = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(1e18))
+		}
+		cfgs[i] = sysCfg
+	}
+
+	d := &Devnet{}
+	for i, sysCfg := range cfgs {
+		if i > 0 {
+			sysCfg.SharedL1 = d.Systems[0]
+		}
+		sys, err := sysCfg.Start()
+		if err != nil {
+			d.Close()
+			return nil, fmt.Errorf("failed to start chain %d: %w", sysCfg.DeployConfig.L2ChainID, err)
+		}
+		d.Systems = append(d.Systems, sys)
+	}
+	return d, nil
+}
+
+// Close stops all chains, the rollups that share the L1 chain are stopped before the L1 chain itself.
+func (d *Devnet) Close() {
+	for i := len(d.Systems) - 1; i >= 0; i-- {
+		d.Systems[i].Close()
+	}
+}
+
+// KeyInfo is an account of the devnet.
+type KeyInfo struct {
+	Address    common.Address `json:"address"`
+	PrivateKey hexutil.Bytes  `json:"privateKey"`
+}
+
+func keyInfo(key *ecdsa.PrivateKey) KeyInfo {
+	return KeyInfo{
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: e2eutils.EncodePrivKey(key),
+	}
+}
+
+// NodeInfo are the endpoints of a node. The auth and rollup endpoints are only set for L2 nodes.
+type NodeInfo struct {
+	HTTP   string `json:"http"`
+	WS     string `json:"ws"`
+	Auth   string `json:"auth,omitempty"`
+	Rollup string `json:"rollup,omitempty"`
+}
+
+// L1Info describes the L1 chain of the devnet, and the L1 contracts of the rollups.
+type L1Info struct {
+	ChainID                uint64         `json:"chainID"`
+	Node                   NodeInfo       `json:"node"`
+	L2OutputOracle         common.Address `json:"l2OutputOracle"`
+	OptimismPortal         common.Address `json:"optimismPortal"`
+	SystemConfig           common.Address `json:"systemConfig"`
+	DataStream             common.Address `json:"dataStream"`
+	L1CrossDomainMessenger common.Address `json:"l1CrossDomainMessenger"`
+	L1StandardBridge       common.Address `json:"l1StandardBridge"`
+}
+
+// ChainInfo describes a rollup of the devnet.
+type ChainInfo struct {
+	ChainID uint64              `json:"chainID"`
+	Rollup  *rollup.Config      `json:"rollup"`
+	Nodes   map[string]NodeInfo `json:"nodes"`
+	Batcher KeyInfo             `json:"batcher"`
+}
+
+// DevnetInfo has all the endpoints and keys of a devnet, to connect external tooling to it.
+type DevnetInfo struct {
+	L1        L1Info             `json:"l1"`
+	Chains    []ChainInfo        `json:"chains"`
+	Keys      map[string]KeyInfo `json:"keys"`
+	JWTSecret hexutil.Bytes      `json:"jwtSecret"`
+}
+
+// Info returns the endpoints and keys of the devnet.
+func (d *Devnet) Info() *DevnetInfo {
+	l1Sys := d.Systems[0]
+	l1 := l1Sys.Nodes["l1"]
+	secrets := l1Sys.cfg.Secrets
+	info := &DevnetInfo{
+		L1: L1Info{
+			ChainID:                l1Sys.cfg.DeployConfig.L1ChainID,
+			Node:                   NodeInfo{HTTP: l1.HTTPEndpoint(), WS: l1.WSEndpoint()},
+			L2OutputOracle:         predeploys.DevL2OutputOracleAddr,
+			OptimismPortal:         predeploys.DevOptimismPortalAddr,
+			SystemConfig:           predeploys.DevSystemConfigAddr,
+			DataStream:             l1Sys.RollupConfig.DataStreamAddress,
+			L1CrossDomainMessenger: predeploys.DevL1CrossDomainMessengerAddr,
+			L1StandardBridge:       predeploys.DevL1StandardBridgeAddr,
+		},
+		Keys: map[string]KeyInfo{
+			"deployer":     keyInfo(secrets.Deployer),
+			"sysCfgOwner":  keyInfo(secrets.SysCfgOwner),
+			"proposer":     keyInfo(secrets.Proposer),
+			"sequencerP2P": keyInfo(secrets.SequencerP2P),
+			"alice":        keyInfo(secrets.Alice),
+			"bob":          keyInfo(secrets.Bob),
+			"mallory":      keyInfo(secrets.Mallory),
+		},
+		JWTSecret: l1Sys.cfg.JWTSecret[:],
+	}
+	for _, sys := range d.Systems {
+		chain := ChainInfo{
+			ChainID: sys.cfg.DeployConfig.L2ChainID,
+			Rollup:  sys.RollupConfig,
+			Nodes:   make(map[string]NodeInfo),
+			Batcher: keyInfo(sys.cfg.Secrets.Batcher),
+		}
+		for name, rollupNode := range sys.RollupNodes {
+			n := sys.Nodes[name]
+			chain.Nodes[name] = NodeInfo{
+				HTTP:   n.HTTPEndpoint(),
+				WS:     n.WSEndpoint(),
+				Auth:   n.HTTPAuthEndpoint(),
+				Rollup: rollupNode.HTTPEndpoint(),
+			}
+		}
+		info.Chains = append(info.Chains, chain)
+	}
+	return info
+}
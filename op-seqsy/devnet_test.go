@@ -0,0 +1,52 @@
+package op_seqsy
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// TestDevnet starts a devnet with two chains of two verifiers each, and checks that a transaction
+// on the second chain reaches all of its verifiers, and that the devnet info has all endpoints.
+func TestDevnet(t *testing.T) {
+	InitParallel(t)
+
+	devnet, err := StartDevnet(DevnetConfig{
+		Dir:       t.TempDir(),
+		Chains:    2,
+		Verifiers: 2,
+		Log:       testlog.Logger(t, log.LvlInfo),
+	})
+	require.NoError(t, err)
+	defer devnet.Close()
+	require.Len(t, devnet.Systems, 2)
+
+	sys := devnet.Systems[1]
+	require.Equal(t, devnet.Systems[0].Nodes["l1"], sys.Nodes["l1"], "chains share the L1 node")
+	require.NotEqual(t, devnet.Systems[0].cfg.Secrets.Batcher, sys.cfg.Secrets.Batcher, "chains have their own batcher")
+	SendL2Tx(t, sys.cfg, sys.Clients["sequencer"], sys.cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(sys.Clients["verifier"], sys.Clients["verifier1"])
+	})
+
+	info := devnet.Info()
+	require.Equal(t, sys.cfg.DeployConfig.L1ChainID, info.L1.ChainID)
+	require.Len(t, info.Chains, 2)
+	for i, chain := range info.Chains {
+		require.Equal(t, devnet.Systems[0].cfg.DeployConfig.L2ChainID+uint64(i), chain.ChainID)
+		require.Len(t, chain.Nodes, 3)
+		for name, node := range chain.Nodes {
+			require.Equal(t, devnet.Systems[i].RollupNodes[name].HTTPEndpoint(), node.Rollup)
+			require.Equal(t, devnet.Systems[i].Nodes[name].WSEndpoint(), node.WS)
+		}
+	}
+	_, err = json.Marshal(info)
+	require.NoError(t, err)
+}
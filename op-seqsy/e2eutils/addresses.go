@@ -44,6 +44,7 @@ func CollectAddresses(sd *SetupData, dp *DeployParams) (out []common.Address) {
 		dp.Addresses.SequencerP2P,
 		predeploys.SequencerFeeVaultAddr,
 		sd.RollupCfg.BatchInboxAddress,
+		sd.RollupCfg.DataStreamAddress,
 		sd.RollupCfg.Genesis.SystemConfig.BatcherAddr,
 		sd.RollupCfg.DepositContractAddress,
 	)
@@ -61,12 +61,15 @@ func MakeDeployParams(t require.TestingT, tp *TestParams) *DeployParams {
 		L2ChainID:   902,
 		L2BlockTime: 2,
 
-		MaxSequencerDrift:   tp.MaxSequencerDrift,
-		SequencerWindowSize: tp.SequencerWindowSize,
-		ChannelTimeout:      tp.ChannelTimeout,
-		P2PSequencerAddress: addresses.SequencerP2P,
-		BatchInboxAddress:   common.Address{0: 0x42, 19: 0xff}, // tbd
-		BatchSenderAddress:  addresses.Batcher,
+		MaxSequencerDrift:    tp.MaxSequencerDrift,
+		SequencerWindowSize:  tp.SequencerWindowSize,
+		ChannelTimeout:       tp.ChannelTimeout,
+		P2PSequencerAddress:  addresses.SequencerP2P,
+		BatchInboxAddress:    common.Address{0: 0x42, 19: 0xff}, // tbd
+		BatchSenderAddress:   addresses.Batcher,
+		DataStreamAddress:    common.Address{0: 0x43, 19: 0xff}, // tbd
+		ProposeSelector:      eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+		ProposeRangeSelector: eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f},
 
 		L2OutputOracleSubmissionInterval: 6,
 		L2OutputOracleStartingTimestamp:  -1,
@@ -135,6 +138,7 @@ type DeploymentsL1 struct {
 	L2OutputOracleProxy         common.Address
 	OptimismPortalProxy         common.Address
 	SystemConfigProxy           common.Address
+	DataStream                  common.Address
 }
 
 // SetupData bundles the L1, L2, rollup and deployment configuration data: everything for a full test setup.
@@ -214,6 +218,9 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		BatchInboxAddress:      deployConf.BatchInboxAddress,
 		DepositContractAddress: predeploys.DevOptimismPortalAddr,
 		L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
+		DataStreamAddress:      deployConf.DataStreamAddress,
+		ProposeSelector:        deployConf.ProposeSelector,
+		ProposeRangeSelector:   deployConf.ProposeRangeSelector,
 		RegolithTime:           deployConf.RegolithTime(uint64(deployConf.L1GenesisBlockTimestamp)),
 	}
 
@@ -223,6 +230,7 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		L2OutputOracleProxy:         predeploys.DevL2OutputOracleAddr,
 		OptimismPortalProxy:         predeploys.DevOptimismPortalAddr,
 		SystemConfigProxy:           predeploys.DevSystemConfigAddr,
+		DataStream:                  deployConf.DataStreamAddress,
 	}
 
 	return &SetupData{
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
 )
 
@@ -33,5 +34,9 @@ func TestSetup(t *testing.T) {
 	require.Equal(t, sd.L2Cfg.Alloc[dp.Addresses.Alice].Balance, Ether(1e12))
 
 	require.Contains(t, sd.L1Cfg.Alloc, predeploys.DevOptimismPortalAddr)
+	require.Equal(t, sd.RollupCfg.DataStreamAddress, sd.DeploymentsL1.DataStream)
+	dataStreamCode, err := bindings.GetDeployedBytecode("DataStream")
+	require.NoError(t, err)
+	require.Equal(t, dataStreamCode, sd.L1Cfg.Alloc[sd.DeploymentsL1.DataStream].Code)
 	require.Contains(t, sd.L2Cfg.Alloc, predeploys.L1BlockAddr)
 }
@@ -0,0 +1,295 @@
+package op_seqsy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ProposeTxFault is what happens to a propose transaction of the batcher on its way to L1.
+// The zero value sends the transaction to L1 unchanged.
+type ProposeTxFault struct {
+	// Drop the transaction: the batcher is told that it was sent, but L1 never sees it.
+	Drop bool
+	// Delay the transaction: the batcher is told that it was sent, and L1 sees it after the delay.
+	Delay time.Duration
+	// Hold the transaction until the next transaction of the batcher, and send it to L1 after that one.
+	Hold bool
+}
+
+// ProposeTxFaultFn decides the fault of every transaction that the batcher sends to L1, including the
+// resubmissions of the transaction manager, which have the same nonce as the transaction they replace.
+type ProposeTxFaultFn func(tx *types.Transaction) ProposeTxFault
+
+// WithProposeTxFault lets the batcher send its transactions to L1 through a proxy, that applies the faults of fn.
+func WithProposeTxFault(fn ProposeTxFaultFn) SystemConfigOption {
+	return SystemConfigOption{
+		key:  "proposeTxFault",
+		role: "batcher",
+		action: func(_ *SystemConfig, s *System) {
+			s.proposeTxFault = fn
+		},
+	}
+}
+
+// WithP2PPartition starts the system with the given nodes partitioned from the other nodes of the P2P topology.
+// The partition can be healed with System.HealP2P.
+func WithP2PPartition(nodes ...string) SystemConfigOption {
+	return SystemConfigOption{
+		key:  "p2pPartition",
+		role: "p2p",
+		action: func(_ *SystemConfig, s *System) {
+			s.partitioned = s.partitionLinks(nodes)
+		},
+	}
+}
+
+// WithL1Stall stops the production of L1 blocks for the given duration, once the L1 head reaches the given number.
+func WithL1Stall(after uint64, duration time.Duration) SystemConfigOption {
+	return SystemConfigOption{
+		key:  "l1Stall",
+		role: "l1",
+		action: func(_ *SystemConfig, s *System) {
+			s.l1PoS.stallAfter = &after
+			s.l1PoS.stallFor = duration
+		},
+	}
+}
+
+// WithL1Reorg replaces the latest depth L1 blocks with a fork, once the L1 head reaches the given number.
+// Finalized L1 blocks, eight blocks behind the head, cannot be reorged.
+func WithL1Reorg(at uint64, depth uint64) SystemConfigOption {
+	return SystemConfigOption{
+		key:  "l1Reorg",
+		role: "l1",
+		action: func(_ *SystemConfig, s *System) {
+			s.l1PoS.reorgAt = &at
+			s.l1PoS.reorgDepth = depth
+		},
+	}
+}
+
+// StallL1 stops the production of L1 blocks, until ResumeL1 is called.
+func (sys *System) StallL1() {
+	sys.l1PoS.Stall()
+}
+
+// ResumeL1 continues the production of L1 blocks after a stall.
+func (sys *System) ResumeL1() {
+	sys.l1PoS.Resume()
+}
+
+// ReorgL1 replaces the latest depth L1 blocks with a fork.
+// Finalized L1 blocks, eight blocks behind the head, cannot be reorged.
+func (sys *System) ReorgL1(ctx context.Context, depth uint64) error {
+	return sys.l1PoS.Reorg(ctx, depth)
+}
+
+// p2pLink is a link of the P2P topology.
+type p2pLink struct {
+	a, b      string
+	connected bool
+}
+
+// partitionLinks returns the links of the P2P topology between the given nodes and all other nodes.
+func (sys *System) partitionLinks(nodes []string) []p2pLink {
+	inside := make(map[string]bool)
+	for _, n := range nodes {
+		inside[n] = true
+	}
+	var links []p2pLink
+	for k, vs := range sys.cfg.P2PTopology {
+		for _, v := range vs {
+			connected := v[0] != '~'
+			if !connected {
+				v = v[1:]
+			}
+			if inside[k] != inside[v] {
+				links = append(links, p2pLink{a: k, b: v, connected: connected})
+			}
+		}
+	}
+	return links
+}
+
+func (sys *System) isPartitioned(a, b string) bool {
+	for _, l := range sys.partitioned {
+		if (l.a == a && l.b == b) || (l.a == b && l.b == a) {
+			return true
+		}
+	}
+	return false
+}
+
+// PartitionP2P disconnects and unlinks the given nodes from the other nodes of the P2P topology,
+// until HealP2P is called.
+func (sys *System) PartitionP2P(nodes ...string) error {
+	if len(sys.partitioned) > 0 {
+		return fmt.Errorf("p2p network is already partitioned")
+	}
+	links := sys.partitionLinks(nodes)
+	for _, l := range links {
+		a, b := sys.p2pNodes[l.a].HostP2P.ID(), sys.p2pNodes[l.b].HostP2P.ID()
+		if err := sys.Mocknet.DisconnectPeers(a, b); err != nil {
+			return fmt.Errorf("failed to disconnect %s and %s: %w", l.a, l.b, err)
+		}
+		if err := sys.Mocknet.UnlinkPeers(a, b); err != nil {
+			return fmt.Errorf("failed to unlink %s and %s: %w", l.a, l.b, err)
+		}
+	}
+	sys.partitioned = links
+	return nil
+}
+
+// HealP2P links and connects the partitioned nodes again, like in the P2P topology.
+func (sys *System) HealP2P() error {
+	for _, l := range sys.partitioned {
+		a, b := sys.p2pNodes[l.a].HostP2P.ID(), sys.p2pNodes[l.b].HostP2P.ID()
+		// links that were partitioned at startup were never unlinked
+		if links := sys.Mocknet.LinksBetweenPeers(a, b); len(links) == 0 {
+			if _, err := sys.Mocknet.LinkPeers(a, b); err != nil {
+				return fmt.Errorf("failed to link %s and %s: %w", l.a, l.b, err)
+			}
+		}
+		if l.connected {
+			if _, err := sys.Mocknet.ConnectPeers(a, b); err != nil {
+				return fmt.Errorf("failed to connect %s and %s: %w", l.a, l.b, err)
+			}
+		}
+	}
+	sys.partitioned = nil
+	return nil
+}
+
+// proposeTxProxy is a JSON-RPC proxy in front of the L1 node, which applies faults to the
+// transactions that are sent through it, and passes all other requests on to L1.
+type proposeTxProxy struct {
+	log   log.Logger
+	fault ProposeTxFaultFn
+	l1    *ethclient.Client
+	proxy *httputil.ReverseProxy
+
+	listener net.Listener
+	srv      *http.Server
+
+	mu   sync.Mutex
+	held *types.Transaction
+}
+
+func newProposeTxProxy(log log.Logger, l1Endpoint string, l1 *ethclient.Client, fault ProposeTxFaultFn) (*proposeTxProxy, error) {
+	target, err := url.Parse(l1Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &proposeTxProxy{
+		log:      log,
+		fault:    fault,
+		l1:       l1,
+		proxy:    httputil.NewSingleHostReverseProxy(target),
+		listener: listener,
+	}
+	p.srv = &http.Server{Handler: p}
+	go func() {
+		if err := p.srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Error("propose tx fault proxy failed", "err", err)
+		}
+	}()
+	return p, nil
+}
+
+func (p *proposeTxProxy) Endpoint() string {
+	return "http://" + p.listener.Addr().String()
+}
+
+func (p *proposeTxProxy) Close() {
+	_ = p.srv.Close()
+}
+
+type jsonrpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+func (p *proposeTxProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var req jsonrpcRequest
+	if err := json.Unmarshal(body, &req); err == nil && req.Method == "eth_sendRawTransaction" && len(req.Params) == 1 {
+		var raw hexutil.Bytes
+		var tx types.Transaction
+		if err := json.Unmarshal(req.Params[0], &raw); err == nil && tx.UnmarshalBinary(raw) == nil && p.intercept(&tx) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": tx.Hash()})
+			return
+		}
+	}
+	p.proxy.ServeHTTP(w, r)
+}
+
+// intercept applies the fault to the transaction, and returns whether the transaction was handled,
+// or should be passed on to L1.
+func (p *proposeTxProxy) intercept(tx *types.Transaction) bool {
+	fault := p.fault(tx)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	held := p.held
+	switch {
+	case fault.Drop:
+		p.log.Warn("dropping propose tx", "tx", tx.Hash(), "nonce", tx.Nonce())
+		return true
+	case fault.Hold:
+		p.log.Warn("holding propose tx", "tx", tx.Hash(), "nonce", tx.Nonce())
+		p.held = tx
+		if held != nil {
+			p.send(held)
+		}
+		return true
+	case fault.Delay > 0:
+		p.log.Warn("delaying propose tx", "tx", tx.Hash(), "nonce", tx.Nonce(), "delay", fault.Delay)
+		time.AfterFunc(fault.Delay, func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.send(tx)
+		})
+		return true
+	case held != nil:
+		p.log.Warn("sending held propose tx after next tx", "held", held.Hash(), "tx", tx.Hash())
+		p.held = nil
+		p.send(tx)
+		p.send(held)
+		return true
+	}
+	return false
+}
+
+func (p *proposeTxProxy) send(tx *types.Transaction) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := p.l1.SendTransaction(ctx, tx); err != nil {
+		p.log.Warn("failed to send propose tx to L1", "tx", tx.Hash(), "nonce", tx.Nonce(), "err", err)
+	}
+}
@@ -0,0 +1,187 @@
+package op_seqsy
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-seqsy/e2eutils"
+)
+
+// TestSeqsyProposeTxFaults drops, holds back and delays the first propose transaction of every nonce,
+// and checks that the verifier still derives the L2 chain from the resubmitted transactions.
+func TestSeqsyProposeTxFaults(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	// propose ranges of blocks, so the batcher catches up after the resubmissions
+	cfg.BatcherProposeRange = true
+	var mu sync.Mutex
+	faults := make(map[uint64]int)
+	sys, err := cfg.Start(WithProposeTxFault(func(tx *types.Transaction) ProposeTxFault {
+		mu.Lock()
+		defer mu.Unlock()
+		faults[tx.Nonce()]++
+		if faults[tx.Nonce()] > 1 || tx.Nonce() >= 6 {
+			return ProposeTxFault{}
+		}
+		switch tx.Nonce() % 3 {
+		case 0:
+			return ProposeTxFault{Drop: true}
+		case 1:
+			return ProposeTxFault{Hold: true}
+		default:
+			return ProposeTxFault{Delay: 500 * time.Millisecond}
+		}
+	}))
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	for i := 0; i < 3; i++ {
+		SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+			opts.Nonce = uint64(i)
+			opts.Value = big.NewInt(1_000_000_000)
+			opts.ToAddr = &common.Address{0xff, 0xff}
+			opts.VerifyOnClients(sys.Clients["verifier"])
+		})
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	require.GreaterOrEqual(t, len(faults), 3, "the propose transactions passed the faults")
+}
+
+// TestSeqsyL1Reorg reorgs the L1 chain under the rollup, and checks that the verifier follows the
+// L2 chain that the batcher proposes again on the new L1 chain.
+func TestSeqsyL1Reorg(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	// propose ranges of blocks, so the batcher catches up after proposing the reorged blocks again
+	cfg.BatcherProposeRange = true
+	sys, err := cfg.Start(WithL1Reorg(8, 4))
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l1Client := sys.Clients["l1"]
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	require.NoError(t, e2eutils.WaitBlock(ctx, l1Client, 10))
+
+	// the blocks after the reorg are built on a new fork, with a different prevrandao value
+	reorged, err := l1Client.HeaderByNumber(ctx, big.NewInt(6))
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(common.Big1), reorged.MixDigest, "block 6 was replaced")
+	kept, err := l1Client.HeaderByNumber(ctx, big.NewInt(4))
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{}, kept.MixDigest, "block 4 was kept")
+
+	SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(sys.Clients["verifier"])
+	})
+
+	// reorg again at runtime, below the safe head of the verifier
+	require.NoError(t, sys.ReorgL1(ctx, 3))
+	receipt := SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Nonce = 1
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+	})
+	// the sequencer may reorg the unsafe block of the transaction when it adopts the new L1 chain,
+	// so compare the blocks after the verifier derived the transaction
+	verified, err := waitForTransaction(receipt.TxHash, sys.Clients["verifier"], 40*time.Second)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		seq, err := sys.Clients["sequencer"].BlockByNumber(ctx, verified.BlockNumber)
+		return err == nil && seq.Hash() == verified.BlockHash
+	}, 10*time.Second, 100*time.Millisecond, "sequencer and verifier agree on the block of the transaction")
+}
+
+// TestSeqsyL1Stall stalls the L1 block production, first scheduled and then at runtime,
+// and checks that the L1 chain does not progress during the stalls.
+func TestSeqsyL1Stall(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start(WithL1Stall(4, 8*time.Second))
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l1Client := sys.Clients["l1"]
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	requireStalled := func() uint64 {
+		time.Sleep(time.Second)
+		head, err := l1Client.BlockNumber(ctx)
+		require.NoError(t, err)
+		time.Sleep(3 * time.Duration(cfg.DeployConfig.L1BlockTime) * time.Second)
+		after, err := l1Client.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, head, after, "L1 chain is stalled")
+		return head
+	}
+
+	require.NoError(t, e2eutils.WaitBlock(ctx, l1Client, 4))
+	head := requireStalled()
+	require.NoError(t, e2eutils.WaitBlock(ctx, l1Client, head+1), "L1 chain resumes after the stall")
+
+	sys.StallL1()
+	head = requireStalled()
+	sys.ResumeL1()
+	require.NoError(t, e2eutils.WaitBlock(ctx, l1Client, head+1), "L1 chain resumes after the stall")
+}
+
+// TestSeqsyP2PPartition starts the verifier partitioned from the sequencer, and checks that it only
+// syncs the unsafe blocks of the sequencer after the partition is healed.
+func TestSeqsyP2PPartition(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	// Only unsafe blocks: the verifier cannot learn about the blocks from L1
+	cfg.DisableBatcher = true
+	cfg.P2PTopology = map[string][]string{
+		"verifier": {"sequencer"},
+	}
+	cfg.P2PReqRespSync = true
+	sys, err := cfg.Start(WithP2PPartition("verifier"))
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	receipt := SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+	})
+	requireNotSynced := func(client *ethclient.Client) {
+		time.Sleep(3 * time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		head, err := client.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Less(t, head, receipt.BlockNumber.Uint64(), "partitioned verifier does not sync")
+	}
+	requireNotSynced(sys.Clients["verifier"])
+
+	require.NoError(t, sys.HealP2P())
+	_, err = waitForTransaction(receipt.TxHash, sys.Clients["verifier"], 40*time.Second)
+	require.NoError(t, err, "verifier syncs after healing the partition")
+
+	// partition again at runtime
+	require.NoError(t, sys.PartitionP2P("sequencer"))
+	receipt = SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Nonce = 1
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+	})
+	requireNotSynced(sys.Clients["verifier"])
+	require.NoError(t, sys.HealP2P())
+	_, err = waitForTransaction(receipt.TxHash, sys.Clients["verifier"], 40*time.Second)
+	require.NoError(t, err, "verifier syncs after healing the partition")
+}
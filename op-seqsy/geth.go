@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -110,7 +111,7 @@ func waitForBlock(number *big.Int, client *ethclient.Client, timeout time.Durati
 	}
 }
 
-func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*node.Node, *eth.Ethereum, error) {
+func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*node.Node, *eth.Ethereum, *fakePoS, error) {
 	ethConfig := &ethconfig.Config{
 		NetworkId: cfg.DeployConfig.L1ChainID,
 		Genesis:   genesis,
@@ -127,13 +128,13 @@ func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*
 
 	l1Node, l1Eth, err := createGethNode(false, nodeConfig, ethConfig, []*ecdsa.PrivateKey{cfg.Secrets.CliqueSigner}, opts...)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	// Activate merge
 	l1Eth.Merger().FinalizePoS()
 
 	// Instead of running a whole beacon node, we run this fake-proof-of-stake sidecar that sequences L1 blocks using the Engine API.
-	l1Node.RegisterLifecycle(&fakePoS{
+	pos := &fakePoS{
 		eth:       l1Eth,
 		log:       log.Root(), // geth logger is global anyway. Would be nice to replace with a local logger though.
 		blockTime: cfg.DeployConfig.L1BlockTime,
@@ -141,9 +142,11 @@ func initL1Geth(cfg *SystemConfig, genesis *core.Genesis, opts ...GethOption) (*
 		finalizedDistance: 8,
 		safeDistance:      4,
 		engineAPI:         catalyst.NewConsensusAPI(l1Eth),
-	})
+		reorgCh:           make(chan uint64),
+	}
+	l1Node.RegisterLifecycle(pos)
 
-	return l1Node, l1Eth, nil
+	return l1Node, l1Eth, pos, nil
 }
 
 // fakePoS is a testing-only utility to attach to Geth,
@@ -158,6 +161,94 @@ type fakePoS struct {
 
 	engineAPI *catalyst.ConsensusAPI
 	sub       ethereum.Subscription
+
+	// Faults of the block production, to test the handling of L1 stalls and reorgs.
+	// The scheduled faults are set before the node starts, and happen once.
+	stalled    atomic.Bool
+	stallAfter *uint64
+	stallFor   time.Duration
+	reorgAt    *uint64
+	reorgDepth uint64
+	reorgCh    chan uint64
+	fork       uint64
+}
+
+// Stall stops the production of blocks, until Resume is called.
+func (f *fakePoS) Stall() {
+	f.stalled.Store(true)
+}
+
+// Resume continues the production of blocks after a stall.
+func (f *fakePoS) Resume() {
+	f.stalled.Store(false)
+}
+
+// Reorg replaces the latest depth blocks with blocks of a new fork.
+// Finalized blocks cannot be reorged.
+func (f *fakePoS) Reorg(ctx context.Context, depth uint64) error {
+	select {
+	case f.reorgCh <- depth:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reorg rewinds the chain by depth blocks. The blocks that are built next have a different
+// prevrandao value than the blocks that they replace, so they have a different hash.
+func (f *fakePoS) reorg(depth uint64) {
+	chain := f.eth.BlockChain()
+	head := chain.CurrentBlock()
+	finalized := chain.CurrentFinalBlock()
+	if finalized == nil {
+		finalized = chain.Genesis().Header()
+	}
+	if depth == 0 || head.Number.Uint64() < finalized.Number.Uint64()+depth {
+		f.log.Error("cannot reorg finalized L1 blocks", "head", head.Number, "finalized", finalized.Number, "depth", depth)
+		return
+	}
+	target := head.Number.Uint64() - depth
+	// Rewinding deletes the blocks, so the transaction pool cannot put their transactions back in the pool
+	// like in a regular reorg. Collect them first, so they can be included again in the new fork.
+	var txs []*types.Transaction
+	for n := target + 1; n <= head.Number.Uint64(); n++ {
+		txs = append(txs, chain.GetBlockByNumber(n).Transactions()...)
+	}
+	if err := chain.SetHead(target); err != nil {
+		f.log.Error("failed to rewind L1 chain", "target", target, "err", err)
+		return
+	}
+	f.reinject(txs)
+	f.fork++
+	f.log.Warn("reorged L1 chain", "old_head", head.Number, "new_head", target, "fork", f.fork, "txs", len(txs))
+}
+
+// reinject adds the transactions of the reorged blocks to the transaction pool, once the pool has
+// been reset to the new head. The pool rejects transactions with a nonce below that of its head state.
+func (f *fakePoS) reinject(txs []*types.Transaction) {
+	pool := f.eth.TxPool()
+	signer := types.LatestSignerForChainID(f.eth.BlockChain().Config().ChainID)
+	lowest := make(map[common.Address]uint64)
+	for _, tx := range txs {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if n, ok := lowest[from]; !ok || tx.Nonce() < n {
+			lowest[from] = tx.Nonce()
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for addr, nonce := range lowest {
+		for pool.Nonce(addr) > nonce && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	for i, err := range pool.AddRemotesSync(txs) {
+		if err != nil {
+			f.log.Warn("failed to reinject reorged transaction", "tx", txs[i].Hash(), "err", err)
+		}
+	}
 }
 
 func (f *fakePoS) Start() error {
@@ -166,9 +257,26 @@ func (f *fakePoS) Start() error {
 		t := time.NewTicker(time.Second / 2)
 		for {
 			select {
+			case depth := <-f.reorgCh:
+				f.reorg(depth)
 			case now := <-t.C:
+				if f.stalled.Load() {
+					continue
+				}
 				chain := f.eth.BlockChain()
 				head := chain.CurrentBlock()
+				if f.stallAfter != nil && head.Number.Uint64() >= *f.stallAfter {
+					f.stallAfter = nil
+					f.log.Warn("stalling L1 block production", "head", head.Number, "duration", f.stallFor)
+					f.Stall()
+					time.AfterFunc(f.stallFor, f.Resume)
+					continue
+				}
+				if f.reorgAt != nil && head.Number.Uint64() >= *f.reorgAt {
+					f.reorgAt = nil
+					f.reorg(f.reorgDepth)
+					continue
+				}
 				finalized := chain.CurrentFinalBlock()
 				if finalized == nil { // fallback to genesis if nothing is finalized
 					finalized = chain.Genesis().Header()
@@ -193,7 +301,7 @@ func (f *fakePoS) Start() error {
 					FinalizedBlockHash: finalized.Hash(),
 				}, &engine.PayloadAttributes{
 					Timestamp:             head.Time + f.blockTime,
-					Random:                common.Hash{},
+					Random:                common.BigToHash(new(big.Int).SetUint64(f.fork)),
 					SuggestedFeeRecipient: head.Coinbase,
 				})
 				if err != nil {
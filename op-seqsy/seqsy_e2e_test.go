@@ -1,16 +1,31 @@
 package op_seqsy
+
 import (
 	"context"
-	"github.com/ethereum/go-ethereum/rpc"
-"math/big"
+	"math/big"
+	"net"
 	"testing"
 	"time"
 
+	batcherrpc "github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,34 +66,34 @@ func TestSeqsyE2E(t *testing.T) {
 	value := big.NewInt(1_000_000_000)
 
 	// Submit TX to L2 sequencer node
-    receipt := SendL2Tx(t, cfg, l2Seq, ethPrivKey, func(opts *TxOpts) {
-        opts.Value = value
-        // opts.Nonce = 1 // Already have deposit
-        opts.ToAddr = &common.Address{0xff, 0xff}
-        opts.VerifyOnClients(l2Verif)
-    })
+	receipt := SendL2Tx(t, cfg, l2Seq, ethPrivKey, func(opts *TxOpts) {
+		opts.Value = value
+		// opts.Nonce = 1 // Already have deposit
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(l2Verif)
+	})
 
 	// Verify blocks match after batch submission on verifiers and sequencers
 	seqBlock, err := l2Seq.BlockByNumber(context.Background(), receipt.BlockNumber)
-    require.Nil(t, err)
+	require.Nil(t, err)
 	verifBlock, err := l2Verif.BlockByNumber(context.Background(), receipt.BlockNumber)
-    require.Nil(t, err)
+	require.Nil(t, err)
 	require.Equal(t, verifBlock.NumberU64(), seqBlock.NumberU64(), "Verifier and sequencer blocks not the same after including a batch tx")
-    require.Equal(t, verifBlock.ParentHash(), seqBlock.ParentHash(), "Verifier and sequencer blocks parent hashes not the same after including a batch tx")
-    require.Equal(t, verifBlock.Hash(), seqBlock.Hash(), "Verifier and sequencer blocks not the same after including a batch tx")
+	require.Equal(t, verifBlock.ParentHash(), seqBlock.ParentHash(), "Verifier and sequencer blocks parent hashes not the same after including a batch tx")
+	require.Equal(t, verifBlock.Hash(), seqBlock.Hash(), "Verifier and sequencer blocks not the same after including a batch tx")
 
 	// get end balance
-    ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
-    defer cancel()
-    endBalance, err := l2Verif.BalanceAt(ctx, fromAddr, nil)
-    require.Nil(t, err)
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	endBalance, err := l2Verif.BalanceAt(ctx, fromAddr, nil)
+	require.Nil(t, err)
 
 	// check difference
 	diff := new(big.Int)
-    diff = diff.Sub(endBalance, startBalance)
+	diff = diff.Sub(endBalance, startBalance)
 	diff = diff.Sub(diff, value)
 	tmp := new(big.Int)
-    tmp.SetUint64(receipt.GasUsed) // only 21k, not accurate!
+	tmp.SetUint64(receipt.GasUsed) // only 21k, not accurate!
 	diff = diff.Sub(diff, tmp)
 	tmp.SetUint64(50_000) // 50k, real diff is more like 28k
 	// condition: we get the correct balance change up to 50k
@@ -96,3 +111,518 @@ func TestSeqsyE2E(t *testing.T) {
 	require.Nil(t, err)
 	require.NotEqual(t, "", seqVersion)
 }
+
+// TestSeqsyMultiChain starts two rollups that share one L1 chain, and thus one data stream contract,
+// and confirms that the verifiers of each rollup only derive the blocks proposed for their own chain.
+func TestSeqsyMultiChain(t *testing.T) {
+	InitParallel(t)
+
+	cfgA := DefaultSystemConfig(t)
+	sysA, err := cfgA.Start()
+	require.Nil(t, err, "Error starting up system A")
+	defer sysA.Close()
+
+	// The second rollup has its own chain ID and batcher, but shares the L1 contracts of the first.
+	cfgB := DefaultSystemConfig(t)
+	secretsB := *cfgB.Secrets
+	secretsB.Batcher = secretsB.Bob
+	cfgB.Secrets = &secretsB
+	cfgB.DeployConfig.L2ChainID = cfgA.DeployConfig.L2ChainID + 1
+	cfgB.DeployConfig.BatchSenderAddress = secretsB.Addresses().Batcher
+	cfgB.DeployConfig.L1GenesisBlockTimestamp = cfgA.DeployConfig.L1GenesisBlockTimestamp
+	cfgB.DisableProposer = true
+	cfgB.SharedL1 = sysA
+	sysB, err := cfgB.Start()
+	require.Nil(t, err, "Error starting up system B")
+	defer sysB.Close()
+	require.Equal(t, sysA.RollupConfig.DataStreamAddress, sysB.RollupConfig.DataStreamAddress)
+
+	sendAndVerify := func(cfg SystemConfig, sys *System) *types.Receipt {
+		return SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+			opts.Value = big.NewInt(1_000_000_000)
+			opts.ToAddr = &common.Address{0xff, 0xff}
+			opts.VerifyOnClients(sys.Clients["verifier"])
+		})
+	}
+	receiptA := sendAndVerify(cfgA, sysA)
+	receiptB := sendAndVerify(cfgB, sysB)
+
+	// Each verifier only knows about the transaction of its own chain
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = sysA.Clients["verifier"].TransactionReceipt(ctx, receiptB.TxHash)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	_, err = sysB.Clients["verifier"].TransactionReceipt(ctx, receiptA.TxHash)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// Both batchers proposed their blocks to the shared data stream
+	l1Client := sysA.Clients["l1"]
+	head, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+	proposers := make(map[common.Address]bool)
+	for i := uint64(0); i <= head; i++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		require.Nil(t, err)
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to != nil && *to == sysA.RollupConfig.DataStreamAddress {
+				from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+				require.Nil(t, err)
+				proposers[from] = true
+			}
+		}
+	}
+	require.True(t, proposers[cfgA.DeployConfig.BatchSenderAddress], "batcher of chain A proposes to the data stream")
+	require.True(t, proposers[cfgB.DeployConfig.BatchSenderAddress], "batcher of chain B proposes to the data stream")
+}
+
+// TestSeqsyBatcherRestart restarts the batcher with a journal, and checks that every L2 block
+// is proposed exactly once across the restart.
+func TestSeqsyBatcherRestart(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.BatcherJournalDir = t.TempDir()
+	// The batcher starts from the safe head of the sequencer, make it lag behind the proposed blocks
+	cfg.Nodes["sequencer"].Driver.VerifierConfDepth = 5
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	nonce := uint64(0)
+	sendAndVerify := func() *types.Receipt {
+		defer func() { nonce++ }()
+		return SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+			opts.Nonce = nonce
+			opts.Value = big.NewInt(1_000_000_000)
+			opts.ToAddr = &common.Address{0xff, 0xff}
+			opts.VerifyOnClients(sys.Clients["verifier"])
+		})
+	}
+	sendAndVerify()
+
+	// Restart while the safe head of the sequencer lags behind the proposed blocks
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(t, sys.BatchSubmitter.Stop(ctx))
+	require.NoError(t, sys.BatchSubmitter.Start())
+
+	receipt := sendAndVerify()
+
+	// Every L2 block up to the last verified one was proposed exactly once
+	l1Client := sys.Clients["l1"]
+	head, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+	proposals := make(map[uint64]int)
+	for i := uint64(0); i <= head; i++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		require.Nil(t, err)
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != sys.RollupConfig.DataStreamAddress {
+				continue
+			}
+			call, err := derive.DecodeProposeCall(sys.RollupConfig.ProposeSelector, tx.Data())
+			require.Nil(t, err)
+			proposals[call.BlockNumber.Uint64()]++
+		}
+	}
+	for n := uint64(1); n <= receipt.BlockNumber.Uint64(); n++ {
+		require.Equal(t, 1, proposals[n], "block %d must be proposed once", n)
+	}
+}
+
+// TestSeqsyPipelinedProposals lets the batcher have several propose transactions in flight,
+// and checks that the L2 blocks are proposed in order, several per L1 block, and derived by the verifier.
+func TestSeqsyPipelinedProposals(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.BatcherMaxPendingTransactions = 4
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	// Let a backlog of L2 blocks build up before a transaction is verified
+	time.Sleep(4 * time.Duration(cfg.DeployConfig.L1BlockTime) * time.Second)
+	receipt := SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(sys.Clients["verifier"])
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	l1Client := sys.Clients["l1"]
+	head, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+	var last uint64
+	maxPerBlock := 0
+	for i := uint64(0); i <= head; i++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		require.Nil(t, err)
+		count := 0
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != sys.RollupConfig.DataStreamAddress {
+				continue
+			}
+			call, err := derive.DecodeProposeCall(sys.RollupConfig.ProposeSelector, tx.Data())
+			require.Nil(t, err)
+			require.Greater(t, call.BlockNumber.Uint64(), last, "blocks must be proposed in order")
+			last = call.BlockNumber.Uint64()
+			count++
+		}
+		if count > maxPerBlock {
+			maxPerBlock = count
+		}
+	}
+	require.GreaterOrEqual(t, last, receipt.BlockNumber.Uint64())
+	require.Greater(t, maxPerBlock, 1, "proposals must be pipelined")
+}
+
+// TestSeqsyProposeRange lets the batcher propose contiguous L2 blocks together, and checks that
+// the verifier derives the blocks of the propose range calls.
+func TestSeqsyProposeRange(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.BatcherProposeRange = true
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	// Let a backlog of L2 blocks build up before a transaction is verified
+	time.Sleep(4 * time.Duration(cfg.DeployConfig.L1BlockTime) * time.Second)
+	receipt := SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(sys.Clients["verifier"])
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	l1Client := sys.Clients["l1"]
+	head, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+	var last uint64
+	maxPerTx := 0
+	for i := uint64(0); i <= head; i++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		require.Nil(t, err)
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != sys.RollupConfig.DataStreamAddress {
+				continue
+			}
+			calls, err := derive.DecodeProposeCalls(sys.RollupConfig, tx.Data())
+			require.Nil(t, err)
+			for _, call := range calls {
+				require.Equal(t, last+1, call.BlockNumber.Uint64(), "blocks must be proposed in order")
+				last = call.BlockNumber.Uint64()
+			}
+			if len(calls) > maxPerTx {
+				maxPerTx = len(calls)
+			}
+		}
+	}
+	require.GreaterOrEqual(t, last, receipt.BlockNumber.Uint64())
+	require.Greater(t, maxPerTx, 1, "blocks must be proposed together")
+}
+
+// TestSeqsyCompressedProposals checks that the verifier derives the L2 chain from zlib compressed proposals.
+func TestSeqsyCompressedProposals(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.BatcherCompression = "zlib"
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	receipt := SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(sys.Clients["verifier"])
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	l1Client := sys.Clients["l1"]
+	head, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+	proposals := 0
+	for i := uint64(0); i <= head; i++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		require.Nil(t, err)
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != sys.RollupConfig.DataStreamAddress {
+				continue
+			}
+			calls, err := derive.DecodeProposeCalls(sys.RollupConfig, tx.Data())
+			require.Nil(t, err)
+			for _, call := range calls {
+				require.Equal(t, derive.PayloadVersionZlib, call.Block[0], "blocks must be proposed compressed")
+				proposals++
+			}
+		}
+	}
+	require.Greater(t, proposals, 0)
+	require.NotNil(t, receipt)
+}
+
+// TestSeqsyRemoteSigner checks that the verifier derives the L2 chain from the proposals of a batcher
+// that signs its transactions with a remote signer over mutual TLS.
+func TestSeqsyRemoteSigner(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.BatcherRemoteSigner = true
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	receipt := SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(sys.Clients["verifier"])
+	})
+	require.NotNil(t, receipt)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	l1Client := sys.Clients["l1"]
+	head, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+	signer := types.LatestSignerForChainID(cfg.L1ChainIDBig())
+	proposals := 0
+	for i := uint64(0); i <= head; i++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		require.Nil(t, err)
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != sys.RollupConfig.DataStreamAddress {
+				continue
+			}
+			sender, err := types.Sender(signer, tx)
+			require.Nil(t, err)
+			require.Equal(t, cfg.Secrets.Addresses().Batcher, sender)
+			proposals++
+		}
+	}
+	require.Greater(t, proposals, 0)
+}
+
+// TestSeqsyDataStreamContract checks that the batcher proposes blocks to the predeployed data stream
+// contract, which records them by sender, and that the contract reverts invalid proposals without
+// stalling the derivation of the chain.
+func TestSeqsyDataStreamContract(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l1Client := sys.Clients["l1"]
+	dataStream := sys.RollupConfig.DataStreamAddress
+	chainID := uint32(cfg.DeployConfig.L2ChainID)
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	code, err := l1Client.CodeAt(ctx, dataStream, nil)
+	require.Nil(t, err)
+	expectedCode, err := bindings.GetDeployedBytecode("DataStream")
+	require.Nil(t, err)
+	require.Equal(t, expectedCode, code)
+
+	caller, err := bindings.NewDataStreamCaller(dataStream, l1Client)
+	require.Nil(t, err)
+	latestOf := func(sender common.Address) uint64 {
+		out, err := caller.LatestBlockNumber(&bind.CallOpts{Context: ctx}, sender, chainID)
+		require.Nil(t, err)
+		return out.Uint64()
+	}
+	latest := func() uint64 {
+		return latestOf(cfg.DeployConfig.BatchSenderAddress)
+	}
+	nonce := uint64(0)
+	sendAndVerify := func() *types.Receipt {
+		defer func() { nonce++ }()
+		return SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+			opts.Nonce = nonce
+			opts.Value = big.NewInt(1_000_000_000)
+			opts.ToAddr = &common.Address{0xff, 0xff}
+			opts.VerifyOnClients(sys.Clients["verifier"])
+		})
+	}
+
+	receipt := sendAndVerify()
+	require.GreaterOrEqual(t, latest(), receipt.BlockNumber.Uint64(), "the data stream records the proposed blocks")
+
+	// Invalid proposals are included on L1 but reverted by the contract
+	signer := types.LatestSignerForChainID(cfg.L1ChainIDBig())
+	alice := cfg.Secrets.Addresses().Alice
+	sendPropose := func(number uint64, value *big.Int) *types.Receipt {
+		data, err := derive.EncodeProposeCall(sys.RollupConfig.ProposeSelector, &derive.ProposeCall{
+			ChainID:     chainID,
+			BlockNumber: new(big.Int).SetUint64(number),
+			BlockHash:   common.Hash{0xaa},
+			Block:       []byte{derive.PayloadVersionUncompressed},
+		})
+		require.Nil(t, err)
+		nonce, err := l1Client.PendingNonceAt(ctx, alice)
+		require.Nil(t, err)
+		tx := types.MustSignNewTx(cfg.Secrets.Alice, signer, &types.DynamicFeeTx{
+			ChainID:   cfg.L1ChainIDBig(),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(1 * params.GWei),
+			GasFeeCap: big.NewInt(10 * params.GWei),
+			Gas:       100_000,
+			To:        &dataStream,
+			Value:     value,
+			Data:      data,
+		})
+		require.Nil(t, l1Client.SendTransaction(ctx, tx))
+		r, err := waitForTransaction(tx.Hash(), l1Client, 10*time.Duration(cfg.DeployConfig.L1BlockTime)*time.Second)
+		require.Nil(t, err)
+		return r
+	}
+	batcherLatest := latest()
+	require.Equal(t, types.ReceiptStatusSuccessful, sendPropose(batcherLatest+100, nil).Status, "senders are tracked separately")
+	require.Equal(t, batcherLatest+100, latestOf(alice))
+	require.Equal(t, types.ReceiptStatusFailed, sendPropose(batcherLatest+200, nil).Status, "proposals must not leave a gap")
+	require.Equal(t, types.ReceiptStatusFailed, sendPropose(batcherLatest+101, big.NewInt(1)).Status, "proposals must not send value")
+
+	// The batcher proposals keep succeeding, and the chain keeps being derived
+	receipt = sendAndVerify()
+	require.GreaterOrEqual(t, latest(), receipt.BlockNumber.Uint64())
+	head, err := l1Client.BlockNumber(ctx)
+	require.Nil(t, err)
+	proposals := 0
+	for i := uint64(0); i <= head; i++ {
+		block, err := l1Client.BlockByNumber(ctx, new(big.Int).SetUint64(i))
+		require.Nil(t, err)
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != dataStream {
+				continue
+			}
+			from, err := types.Sender(signer, tx)
+			require.Nil(t, err)
+			if from != cfg.DeployConfig.BatchSenderAddress {
+				continue
+			}
+			r, err := l1Client.TransactionReceipt(ctx, tx.Hash())
+			require.Nil(t, err)
+			require.Equal(t, types.ReceiptStatusSuccessful, r.Status, "batcher proposals must succeed")
+			proposals++
+		}
+	}
+	require.Greater(t, proposals, 0)
+}
+
+// TestSeqsySubmitBlock checks that the seqsy API of the batcher requires JWT authentication,
+// accepts the block that it last stored again, and rejects blocks that do not extend the last
+// stored block or whose body does not match their header.
+func TestSeqsySubmitBlock(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.Nil(t, listener.Close())
+	secret := [32]byte{0x42}
+	server := oprpc.NewServer("127.0.0.1", port, "test", oprpc.WithJWTSecret(secret[:]))
+	server.AddAPI(rpc.API{
+		Namespace: "seqsy",
+		Service:   batcherrpc.NewSeqsyAPI(sys.BatchSubmitter),
+	})
+	require.Nil(t, server.Start())
+	defer func() { _ = server.Stop() }()
+	endpoint := "http://" + server.Endpoint()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	submit := func(c *rpc.Client, block *types.Block) error {
+		data, err := rlp.EncodeToBytes(block)
+		require.Nil(t, err)
+		return c.CallContext(ctx, nil, "seqsy_submitBlock", hexutil.Bytes(data))
+	}
+
+	// Wait for the batcher to propose blocks, then stop the sequencer so that its head
+	// is the last block stored by the batcher
+	SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+		opts.VerifyOnClients(sys.Clients["verifier"])
+	})
+	rollupRPCClient, err := rpc.DialContext(ctx, sys.RollupNodes["sequencer"].HTTPEndpoint())
+	require.Nil(t, err)
+	var headHash common.Hash
+	require.Nil(t, rollupRPCClient.CallContext(ctx, &headHash, "admin_stopSequencer"))
+	head, err := sys.Clients["sequencer"].BlockByHash(ctx, headHash)
+	require.Nil(t, err)
+
+	unauthenticated, err := rpc.DialContext(ctx, endpoint)
+	require.Nil(t, err)
+	require.Error(t, submit(unauthenticated, head), "requests must be authenticated")
+
+	authenticated, err := rpc.DialOptions(ctx, endpoint, rpc.WithHTTPAuth(node.NewJWTAuth(secret)))
+	require.Nil(t, err)
+	require.Nil(t, submit(authenticated, head), "the last stored block can be submitted again")
+
+	header := types.CopyHeader(head.Header())
+	header.Number = new(big.Int).Add(header.Number, common.Big1)
+	header.ParentHash = common.Hash{0xde, 0xad}
+	unlinked := types.NewBlockWithHeader(header).WithBody(head.Transactions(), nil)
+	err = submit(authenticated, unlinked)
+	require.ErrorContains(t, err, "does not extend the last stored block")
+
+	noBody := types.NewBlockWithHeader(head.Header())
+	err = submit(authenticated, noBody)
+	require.ErrorContains(t, err, "body does not match its header")
+}
+
+// TestSeqsyBlockCommitment gets the commitment of the sequencer to the unsafe block of a user transaction,
+// and checks it against the safe block that the verifier later derives from L1.
+func TestSeqsyBlockCommitment(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	// the p2p signer signs the commitments, even when the blocks are not gossiped
+	cfg.Nodes["sequencer"].P2PSigner = &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(cfg.Secrets.SequencerP2P)}
+	sys, err := cfg.Start()
+	require.Nil(t, err, "Error starting up system")
+	defer sys.Close()
+
+	ctx := context.Background()
+	receipt := SendL2Tx(t, cfg, sys.Clients["sequencer"], cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+	})
+
+	seqRPC, err := rpc.DialContext(ctx, sys.RollupNodes["sequencer"].HTTPEndpoint())
+	require.Nil(t, err)
+	seqClient := sources.NewRollupClient(client.NewBaseRPCClient(seqRPC))
+	commitment, err := seqClient.GetBlockCommitment(ctx, receipt.BlockNumber.Uint64())
+	require.Nil(t, err)
+	require.Equal(t, receipt.BlockHash, commitment.Hash)
+	// the height is signed once, later requests get the same commitment
+	again, err := seqClient.GetBlockCommitment(ctx, receipt.BlockNumber.Uint64())
+	require.Nil(t, err)
+	require.Equal(t, commitment, again)
+
+	verifRPC, err := rpc.DialContext(ctx, sys.RollupNodes["verifier"].HTTPEndpoint())
+	require.Nil(t, err)
+	verifClient := sources.NewRollupClient(client.NewBaseRPCClient(verifRPC))
+	require.Nil(t, waitForSafeHead(ctx, uint64(commitment.Number), verifClient))
+	safe, err := sys.Clients["verifier"].BlockByNumber(ctx, receipt.BlockNumber)
+	require.Nil(t, err)
+	require.Nil(t, p2p.CheckBlockCommitment(sys.RollupConfig, commitment, cfg.Secrets.Addresses().SequencerP2P, eth.ToBlockID(safe)))
+
+	// a commitment of the sequencer to another block at the same height is evidence of equivocation
+	conflicting, err := p2p.SignBlockCommitment(ctx, sys.RollupConfig, p2p.NewLocalSigner(cfg.Secrets.SequencerP2P),
+		eth.BlockID{Hash: common.Hash{0xde, 0xad}, Number: uint64(commitment.Number)})
+	require.Nil(t, err)
+	err = p2p.CheckBlockCommitment(sys.RollupConfig, conflicting, cfg.Secrets.Addresses().SequencerP2P, eth.ToBlockID(safe))
+	require.ErrorIs(t, err, p2p.ErrEquivocation)
+}
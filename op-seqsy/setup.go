@@ -7,9 +7,9 @@ import (
 	"math/big"
 	"os"
 	"path"
+	"path/filepath"
 	"sort"
 	"strings"
-	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -21,7 +21,6 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
-	"github.com/stretchr/testify/require"
 
 	bss "github.com/ethereum-optimism/optimism/op-batcher/batcher"
 	batchermetrics "github.com/ethereum-optimism/optimism/op-batcher/metrics"
@@ -36,11 +35,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
-	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	proposermetrics "github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	l2os "github.com/ethereum-optimism/optimism/op-proposer/proposer"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	signer "github.com/ethereum-optimism/optimism/op-signer/client"
+	"github.com/ethereum-optimism/optimism/op-signer/signertest"
 )
 
 var (
@@ -60,9 +60,13 @@ func newTxMgrConfig(l1Addr string, privKey *ecdsa.PrivateKey) txmgr.CLIConfig {
 	}
 }
 
-func DefaultSystemConfig(t *testing.T) SystemConfig {
+// NewSystemConfig returns the default system configuration: one L1 chain, and one rollup with a sequencer,
+// a verifier, a batcher and a proposer. The JWT secret of the engine API is written to the given directory.
+func NewSystemConfig(dir string) (SystemConfig, error) {
 	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
-	require.NoError(t, err)
+	if err != nil {
+		return SystemConfig{}, err
+	}
 	addresses := secrets.Addresses()
 
 	deployConfig := &genesis.DeployConfig{
@@ -77,6 +81,9 @@ func DefaultSystemConfig(t *testing.T) SystemConfig {
 		P2PSequencerAddress:       addresses.SequencerP2P,
 		BatchInboxAddress:         common.Address{0: 0x52, 19: 0xff}, // tbd
 		BatchSenderAddress:        addresses.Batcher,
+		DataStreamAddress:         common.Address{0: 0x53, 19: 0xff}, // tbd
+		ProposeSelector:           eth.Bytes4{0x74, 0x12, 0x3b, 0xf9},
+		ProposeRangeSelector:      eth.Bytes4{0x7a, 0xbd, 0xdb, 0x0f},
 
 		L2OutputOracleSubmissionInterval: 4,
 		L2OutputOracleStartingTimestamp:  -1,
@@ -123,7 +130,11 @@ func DefaultSystemConfig(t *testing.T) SystemConfig {
 	}
 
 	if err := deployConfig.InitDeveloperDeployedAddresses(); err != nil {
-		panic(err)
+		return SystemConfig{}, err
+	}
+	jwtPath, err := writeJWT(dir, testingJWTSecret)
+	if err != nil {
+		return SystemConfig{}, err
 	}
 
 	return SystemConfig{
@@ -133,7 +144,7 @@ func DefaultSystemConfig(t *testing.T) SystemConfig {
 
 		DeployConfig:           deployConfig,
 		L1InfoPredeployAddress: predeploys.L1BlockAddr,
-		JWTFilePath:            writeDefaultJWT(t),
+		JWTFilePath:            jwtPath,
 		JWTSecret:              testingJWTSecret,
 		Nodes: map[string]*rollupNode.Config{
 			"sequencer": {
@@ -150,34 +161,48 @@ func DefaultSystemConfig(t *testing.T) SystemConfig {
 				},
 				L1EpochPollInterval: time.Second * 4,
 			},
-			"verifier": {
-				Driver: driver.Config{
-					VerifierConfDepth:  0,
-					SequencerConfDepth: 0,
-					SequencerEnabled:   false,
-				},
-				L1EpochPollInterval: time.Second * 4,
-			},
+			"verifier": verifierConfig(),
 		},
 		Loggers: map[string]log.Logger{
-			"verifier":  testlog.Logger(t, log.LvlInfo).New("role", "verifier"),
-			"sequencer": testlog.Logger(t, log.LvlInfo).New("role", "sequencer"),
-			"batcher":   testlog.Logger(t, log.LvlInfo).New("role", "batcher"),
-			"proposer":  testlog.Logger(t, log.LvlCrit).New("role", "proposer"),
+			"verifier":  log.New("role", "verifier"),
+			"sequencer": log.New("role", "sequencer"),
+			"batcher":   log.New("role", "batcher"),
+			"proposer":  log.New("role", "proposer"),
+		},
+		GethOptions:                   map[string][]GethOption{},
+		P2PTopology:                   nil, // no P2P connectivity by default
+		NonFinalizedProposals:         false,
+		BatcherMaxPendingTransactions: 1,
+	}, nil
+}
+
+func verifierConfig() *rollupNode.Config {
+	return &rollupNode.Config{
+		Driver: driver.Config{
+			VerifierConfDepth:  0,
+			SequencerConfDepth: 0,
+			SequencerEnabled:   false,
+		},
+		RPC: rollupNode.RPCConfig{
+			ListenAddr: "127.0.0.1",
+			ListenPort: 0,
 		},
-		GethOptions:           map[string][]GethOption{},
-		P2PTopology:           nil, // no P2P connectivity by default
-		NonFinalizedProposals: false,
+		L1EpochPollInterval: time.Second * 4,
 	}
 }
 
-func writeDefaultJWT(t *testing.T) string {
+// AddVerifier adds a verifier rollup node, with its own L2 geth node, to the system.
+func (cfg *SystemConfig) AddVerifier(name string) {
+	cfg.Nodes[name] = verifierConfig()
+}
+
+func writeJWT(dir string, secret [32]byte) (string, error) {
 	// Sadly the geth node config cannot load JWT secret from memory, it has to be a file
-	jwtPath := path.Join(t.TempDir(), "jwt_secret")
-	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(testingJWTSecret[:])), 0600); err != nil {
-		t.Fatalf("failed to prepare jwt file for geth: %v", err)
+	jwtPath := path.Join(dir, "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		return "", fmt.Errorf("failed to prepare jwt file for geth: %w", err)
 	}
-	return jwtPath
+	return jwtPath, nil
 }
 
 type DepositContractConfig struct {
@@ -214,6 +239,29 @@ type SystemConfig struct {
 
 	// Explicitly disable batcher, for tests that rely on unsafe L2 payloads
 	DisableBatcher bool
+
+	// Explicitly disable proposer, e.g. for rollups that share the L1 contracts of another system
+	DisableProposer bool
+
+	// Directory of the batcher journal of proposed blocks, the journal is disabled if empty
+	BatcherJournalDir string
+
+	// Maximum number of propose transactions that the batcher has in flight at the same time
+	BatcherMaxPendingTransactions uint64
+
+	// Let the batcher propose contiguous L2 blocks together in propose range calls
+	BatcherProposeRange bool
+
+	// Compression of the batches of the blocks the batcher proposes: none, zlib or brotli
+	BatcherCompression string
+
+	// Let the batcher sign its transactions with an in-process remote signer over mutual TLS,
+	// instead of with its private key
+	BatcherRemoteSigner bool
+
+	// Run the rollup on top of the L1 chain of an already started system, instead of starting a new L1 chain.
+	// The rollups then share the L1 contracts, including the data stream, of the system that owns the L1.
+	SharedL1 *System
 }
 
 type System struct {
@@ -221,6 +269,7 @@ type System struct {
 
 	RollupConfig *rollup.Config
 
+	L1GenesisCfg *core.Genesis
 	L2GenesisCfg *core.Genesis
 
 	// Connections to running nodes
@@ -231,6 +280,15 @@ type System struct {
 	L2OutputSubmitter *l2os.L2OutputSubmitter
 	BatchSubmitter    *bss.BatchSubmitter
 	Mocknet           mocknet.Mocknet
+
+	// Fault injection, see faults.go
+	l1PoS          *fakePoS
+	p2pNodes       map[string]*p2p.Prepared
+	partitioned    []p2pLink
+	proposeTxFault ProposeTxFaultFn
+	proposeTxProxy *proposeTxProxy
+
+	batcherSigner *signertest.Server
 }
 
 func (sys *System) NodeEndpoint(name string) string {
@@ -246,14 +304,25 @@ func (sys *System) Close() {
 		defer cancel()
 		sys.BatchSubmitter.StopIfRunning(ctx)
 	}
+	if sys.proposeTxProxy != nil {
+		sys.proposeTxProxy.Close()
+	}
+	if sys.batcherSigner != nil {
+		_ = sys.batcherSigner.Close()
+	}
 
 	for _, node := range sys.RollupNodes {
 		node.Close()
 	}
-	for _, node := range sys.Nodes {
+	for name, node := range sys.Nodes {
+		if name == "l1" && sys.cfg.SharedL1 != nil {
+			continue // owned by the system that shares its L1
+		}
 		node.Close()
 	}
-	sys.Mocknet.Close()
+	if sys.Mocknet != nil {
+		sys.Mocknet.Close()
+	}
 }
 
 type systemConfigHook func(sCfg *SystemConfig, s *System)
@@ -300,129 +369,175 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 		Clients:     make(map[string]*ethclient.Client),
 		RollupNodes: make(map[string]*rollupNode.OpNode),
 	}
-	didErrAfterStart := false
-	defer func() {
-		if didErrAfterStart {
-			for _, node := range sys.RollupNodes {
-				node.Close()
-			}
-			for _, node := range sys.Nodes {
-				node.Close()
-			}
-		}
-	}()
-
-	l1Genesis, err := genesis.BuildL1DeveloperGenesis(cfg.DeployConfig)
-	if err != nil {
+	if err := sys.start(opts); err != nil {
+		sys.Close()
 		return nil, err
 	}
+	return sys, nil
+}
 
-	for addr, amount := range cfg.Premine {
-		if existing, ok := l1Genesis.Alloc[addr]; ok {
-			l1Genesis.Alloc[addr] = core.GenesisAccount{
-				Code:    existing.Code,
-				Storage: existing.Storage,
-				Balance: amount,
-				Nonce:   existing.Nonce,
-			}
-		} else {
-			l1Genesis.Alloc[addr] = core.GenesisAccount{
-				Balance: amount,
-				Nonce:   0,
-			}
+// start brings up the system step by step. The caller closes the system if any step fails.
+func (sys *System) start(opts SystemConfigOptions) error {
+	if err := sys.initGenesis(); err != nil {
+		return err
+	}
+	if err := sys.startGethNodes(opts); err != nil {
+		return err
+	}
+	if err := sys.connectClients(); err != nil {
+		return err
+	}
+	if err := sys.initP2P(); err != nil {
+		return err
+	}
+	if err := sys.startRollupNodes(opts); err != nil {
+		return err
+	}
+	if err := sys.connectP2P(opts); err != nil {
+		return err
+	}
+	if !sys.cfg.DisableProposer {
+		if err := sys.startProposer(); err != nil {
+			return err
 		}
 	}
+	return sys.startBatcher(opts)
+}
 
-	l1Block := l1Genesis.ToBlock()
-	l2Genesis, err := genesis.BuildL2DeveloperGenesis(cfg.DeployConfig, l1Block)
-	if err != nil {
-		return nil, err
+// logger returns the logger of the given role, or a root logger tagged with the role if none is configured.
+func (sys *System) logger(role string) log.Logger {
+	if l, ok := sys.cfg.Loggers[role]; ok && l != nil {
+		return l
 	}
-	sys.L2GenesisCfg = l2Genesis
-	for addr, amount := range cfg.Premine {
-		if existing, ok := l2Genesis.Alloc[addr]; ok {
-			l2Genesis.Alloc[addr] = core.GenesisAccount{
+	return log.New("role", role)
+}
+
+func premine(alloc core.GenesisAlloc, amounts map[common.Address]*big.Int) {
+	for addr, amount := range amounts {
+		if existing, ok := alloc[addr]; ok {
+			alloc[addr] = core.GenesisAccount{
 				Code:    existing.Code,
 				Storage: existing.Storage,
 				Balance: amount,
 				Nonce:   existing.Nonce,
 			}
 		} else {
-			l2Genesis.Alloc[addr] = core.GenesisAccount{
+			alloc[addr] = core.GenesisAccount{
 				Balance: amount,
 				Nonce:   0,
 			}
 		}
 	}
+}
 
-	makeRollupConfig := func() rollup.Config {
-		return rollup.Config{
-			Genesis: rollup.Genesis{
-				L1: eth.BlockID{
-					Hash:   l1Block.Hash(),
-					Number: 0,
-				},
-				L2: eth.BlockID{
-					Hash:   l2Genesis.ToBlock().Hash(),
-					Number: 0,
-				},
-				L2Time:       uint64(cfg.DeployConfig.L1GenesisBlockTimestamp),
-				SystemConfig: e2eutils.SystemConfigFromDeployConfig(cfg.DeployConfig),
-			},
-			BlockTime:              cfg.DeployConfig.L2BlockTime,
-			MaxSequencerDrift:      cfg.DeployConfig.MaxSequencerDrift,
-			SeqWindowSize:          cfg.DeployConfig.SequencerWindowSize,
-			ChannelTimeout:         cfg.DeployConfig.ChannelTimeout,
-			L1ChainID:              cfg.L1ChainIDBig(),
-			L2ChainID:              cfg.L2ChainIDBig(),
-			BatchInboxAddress:      cfg.DeployConfig.BatchInboxAddress,
-			DepositContractAddress: predeploys.DevOptimismPortalAddr,
-			L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
-			RegolithTime:           cfg.DeployConfig.RegolithTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+// initGenesis builds the L1 and L2 genesis, and the rollup config that links them.
+func (sys *System) initGenesis() error {
+	cfg := &sys.cfg
+	if cfg.SharedL1 != nil {
+		// the L1 state is owned by the shared system, premines only apply to L2
+		sys.L1GenesisCfg = cfg.SharedL1.L1GenesisCfg
+	} else {
+		l1Genesis, err := genesis.BuildL1DeveloperGenesis(cfg.DeployConfig)
+		if err != nil {
+			return err
 		}
+		premine(l1Genesis.Alloc, cfg.Premine)
+		sys.L1GenesisCfg = l1Genesis
 	}
-	defaultConfig := makeRollupConfig()
-	sys.RollupConfig = &defaultConfig
 
-	// Initialize nodes
-	l1Node, l1Backend, err := initL1Geth(&cfg, l1Genesis, cfg.GethOptions["l1"]...)
+	l2Genesis, err := genesis.BuildL2DeveloperGenesis(cfg.DeployConfig, sys.L1GenesisCfg.ToBlock())
 	if err != nil {
-		return nil, err
+		return err
+	}
+	premine(l2Genesis.Alloc, cfg.Premine)
+	sys.L2GenesisCfg = l2Genesis
+
+	rollupCfg := sys.makeRollupConfig()
+	sys.RollupConfig = &rollupCfg
+	return nil
+}
+
+// makeRollupConfig creates a new rollup config, every rollup node gets its own copy.
+func (sys *System) makeRollupConfig() rollup.Config {
+	cfg := &sys.cfg
+	return rollup.Config{
+		Genesis: rollup.Genesis{
+			L1: eth.BlockID{
+				Hash:   sys.L1GenesisCfg.ToBlock().Hash(),
+				Number: 0,
+			},
+			L2: eth.BlockID{
+				Hash:   sys.L2GenesisCfg.ToBlock().Hash(),
+				Number: 0,
+			},
+			L2Time:       uint64(cfg.DeployConfig.L1GenesisBlockTimestamp),
+			SystemConfig: e2eutils.SystemConfigFromDeployConfig(cfg.DeployConfig),
+		},
+		BlockTime:              cfg.DeployConfig.L2BlockTime,
+		MaxSequencerDrift:      cfg.DeployConfig.MaxSequencerDrift,
+		SeqWindowSize:          cfg.DeployConfig.SequencerWindowSize,
+		ChannelTimeout:         cfg.DeployConfig.ChannelTimeout,
+		L1ChainID:              cfg.L1ChainIDBig(),
+		L2ChainID:              cfg.L2ChainIDBig(),
+		BatchInboxAddress:      cfg.DeployConfig.BatchInboxAddress,
+		DepositContractAddress: predeploys.DevOptimismPortalAddr,
+		L1SystemConfigAddress:  predeploys.DevSystemConfigAddr,
+		DataStreamAddress:      cfg.DeployConfig.DataStreamAddress,
+		ProposeSelector:        cfg.DeployConfig.ProposeSelector,
+		ProposeRangeSelector:   cfg.DeployConfig.ProposeRangeSelector,
+		RegolithTime:           cfg.DeployConfig.RegolithTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+	}
+}
+
+// startGethNodes starts the L1 geth node, unless the L1 is shared, and the L2 geth node of every rollup node.
+func (sys *System) startGethNodes(opts SystemConfigOptions) error {
+	cfg := &sys.cfg
+	if cfg.SharedL1 != nil {
+		sys.Nodes["l1"] = cfg.SharedL1.Nodes["l1"]
+		sys.Backends["l1"] = cfg.SharedL1.Backends["l1"]
+		sys.l1PoS = cfg.SharedL1.l1PoS
+	} else {
+		l1Node, l1Backend, l1PoS, err := initL1Geth(cfg, sys.L1GenesisCfg, cfg.GethOptions["l1"]...)
+		if err != nil {
+			return err
+		}
+		sys.Nodes["l1"] = l1Node
+		sys.Backends["l1"] = l1Backend
+		sys.l1PoS = l1PoS
+		for _, key := range []string{"l1Stall", "l1Reorg"} {
+			if action, ok := opts.Get(key, "l1"); ok {
+				action(cfg, sys)
+			}
+		}
 	}
-	sys.Nodes["l1"] = l1Node
-	sys.Backends["l1"] = l1Backend
 
 	for name := range cfg.Nodes {
-		node, backend, err := initL2Geth(name, big.NewInt(int64(cfg.DeployConfig.L2ChainID)), l2Genesis, cfg.JWTFilePath, cfg.GethOptions[name]...)
+		node, backend, err := initL2Geth(name, cfg.L2ChainIDBig(), sys.L2GenesisCfg, cfg.JWTFilePath, cfg.GethOptions[name]...)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		sys.Nodes[name] = node
 		sys.Backends[name] = backend
 	}
 
-	// Start
-	err = l1Node.Start()
-	if err != nil {
-		didErrAfterStart = true
-		return nil, err
+	if cfg.SharedL1 == nil {
+		if err := sys.Nodes["l1"].Start(); err != nil {
+			return err
+		}
 	}
 	for name, node := range sys.Nodes {
 		if name == "l1" {
 			continue
 		}
-		err = node.Start()
-		if err != nil {
-			didErrAfterStart = true
-			return nil, err
+		if err := node.Start(); err != nil {
+			return err
 		}
 	}
 
 	// Configure connections to L1 and L2 for rollup nodes.
 	// TODO: refactor testing to use in-process rpc connections instead of websockets.
-
 	for name, rollupCfg := range cfg.Nodes {
-		configureL1(rollupCfg, l1Node)
+		configureL1(rollupCfg, sys.Nodes["l1"])
 		configureL2(rollupCfg, sys.Nodes[name], cfg.JWTSecret)
 
 		rollupCfg.L2Sync = &rollupNode.PreparedL2SyncEndpoint{
@@ -430,85 +545,97 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 			TrustRPC: false,
 		}
 	}
+	return nil
+}
 
-	// Geth Clients
+// connectClients creates the geth clients, and waits for the L1 chain to make progress.
+func (sys *System) connectClients() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
-	l1Srv, err := l1Node.RPCHandler()
+	l1Srv, err := sys.Nodes["l1"].RPCHandler()
 	if err != nil {
-		didErrAfterStart = true
-		return nil, err
+		return err
 	}
 	l1Client := ethclient.NewClient(rpc.DialInProc(l1Srv))
 	sys.Clients["l1"] = l1Client
 	for name, node := range sys.Nodes {
 		client, err := ethclient.DialContext(ctx, node.WSEndpoint())
 		if err != nil {
-			didErrAfterStart = true
-			return nil, err
+			return err
 		}
 		sys.Clients[name] = client
 	}
 
-	_, err = waitForBlock(big.NewInt(2), l1Client, 6*time.Second*time.Duration(cfg.DeployConfig.L1BlockTime))
+	_, err = waitForBlock(big.NewInt(2), l1Client, 6*time.Second*time.Duration(sys.cfg.DeployConfig.L1BlockTime))
 	if err != nil {
-		return nil, fmt.Errorf("waiting for blocks: %w", err)
+		return fmt.Errorf("waiting for blocks: %w", err)
 	}
+	return nil
+}
 
+// initP2P creates and links the mocknet peers of the P2P topology.
+func (sys *System) initP2P() error {
+	cfg := &sys.cfg
 	sys.Mocknet = mocknet.New()
 
 	p2pNodes := make(map[string]*p2p.Prepared)
-	if cfg.P2PTopology != nil {
-		// create the peer if it doesn't exist yet.
-		initHostMaybe := func(name string) (*p2p.Prepared, error) {
-			if p, ok := p2pNodes[name]; ok {
-				return p, nil
-			}
-			h, err := sys.Mocknet.GenPeer()
-			if err != nil {
-				return nil, fmt.Errorf("failed to init p2p host for node %s", name)
-			}
-			h.Network()
-			_, ok := cfg.Nodes[name]
-			if !ok {
-				return nil, fmt.Errorf("node %s from p2p topology not found in actual nodes map", name)
-			}
-			// TODO we can enable discv5 in the testnodes to test discovery of new peers.
-			// Would need to mock though, and the discv5 implementation does not provide nice mocks here.
-			p := &p2p.Prepared{
-				HostP2P:           h,
-				LocalNode:         nil,
-				UDPv5:             nil,
-				EnableReqRespSync: cfg.P2PReqRespSync,
-			}
-			p2pNodes[name] = p
+	sys.p2pNodes = p2pNodes
+	if cfg.P2PTopology == nil {
+		return nil
+	}
+	// create the peer if it doesn't exist yet.
+	initHostMaybe := func(name string) (*p2p.Prepared, error) {
+		if p, ok := p2pNodes[name]; ok {
 			return p, nil
 		}
-		for k, vs := range cfg.P2PTopology {
-			peerA, err := initHostMaybe(k)
+		h, err := sys.Mocknet.GenPeer()
+		if err != nil {
+			return nil, fmt.Errorf("failed to init p2p host for node %s", name)
+		}
+		h.Network()
+		_, ok := cfg.Nodes[name]
+		if !ok {
+			return nil, fmt.Errorf("node %s from p2p topology not found in actual nodes map", name)
+		}
+		// TODO we can enable discv5 in the testnodes to test discovery of new peers.
+		// Would need to mock though, and the discv5 implementation does not provide nice mocks here.
+		p := &p2p.Prepared{
+			HostP2P:           h,
+			LocalNode:         nil,
+			UDPv5:             nil,
+			EnableReqRespSync: cfg.P2PReqRespSync,
+		}
+		p2pNodes[name] = p
+		return p, nil
+	}
+	for k, vs := range cfg.P2PTopology {
+		peerA, err := initHostMaybe(k)
+		if err != nil {
+			return fmt.Errorf("failed to setup mocknet peer %s", k)
+		}
+		for _, v := range vs {
+			v = strings.TrimPrefix(v, "~")
+			peerB, err := initHostMaybe(v)
 			if err != nil {
-				return nil, fmt.Errorf("failed to setup mocknet peer %s", k)
+				return fmt.Errorf("failed to setup mocknet peer %s (peer of %s)", v, k)
 			}
-			for _, v := range vs {
-				v = strings.TrimPrefix(v, "~")
-				peerB, err := initHostMaybe(v)
-				if err != nil {
-					return nil, fmt.Errorf("failed to setup mocknet peer %s (peer of %s)", v, k)
-				}
-				if _, err := sys.Mocknet.LinkPeers(peerA.HostP2P.ID(), peerB.HostP2P.ID()); err != nil {
-					return nil, fmt.Errorf("failed to setup mocknet link between %s and %s", k, v)
-				}
-				// connect the peers after starting the full rollup node
+			if _, err := sys.Mocknet.LinkPeers(peerA.HostP2P.ID(), peerB.HostP2P.ID()); err != nil {
+				return fmt.Errorf("failed to setup mocknet link between %s and %s", k, v)
 			}
+			// connect the peers after starting the full rollup node
 		}
 	}
+	return nil
+}
+
+// startRollupNodes starts the rollup nodes, in alphabetical order of their names.
+func (sys *System) startRollupNodes(opts SystemConfigOptions) error {
+	cfg := &sys.cfg
 
 	// Don't log state snapshots in test output
 	snapLog := log.New()
 	snapLog.SetHandler(log.DiscardHandler())
 
-	// Rollup nodes
-
 	// Ensure we are looping through the nodes in alphabetical order
 	ks := make([]string, 0, len(cfg.Nodes))
 	for k := range cfg.Nodes {
@@ -520,9 +647,9 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 	for _, name := range ks {
 		nodeConfig := cfg.Nodes[name]
 		c := *nodeConfig // copy
-		c.Rollup = makeRollupConfig()
+		c.Rollup = sys.makeRollupConfig()
 
-		if p, ok := p2pNodes[name]; ok {
+		if p, ok := sys.p2pNodes[name]; ok {
 			c.P2P = p
 
 			if c.Driver.SequencerEnabled && c.P2PSigner == nil {
@@ -530,97 +657,152 @@ func (cfg SystemConfig) Start(_opts ...SystemConfigOption) (*System, error) {
 			}
 		}
 
-		c.Rollup.LogDescription(cfg.Loggers[name], chaincfg.L2ChainIDToNetworkName)
+		logger := sys.logger(name)
+		c.Rollup.LogDescription(logger, chaincfg.L2ChainIDToNetworkName)
 
-		node, err := rollupNode.New(context.Background(), &c, cfg.Loggers[name], snapLog, "", metrics.NewMetrics(""))
+		node, err := rollupNode.New(context.Background(), &c, logger, snapLog, "", metrics.NewMetrics(""))
 		if err != nil {
-			didErrAfterStart = true
-			return nil, err
+			return err
 		}
 		err = node.Start(context.Background())
 		if err != nil {
-			didErrAfterStart = true
-			return nil, err
+			return err
 		}
 		sys.RollupNodes[name] = node
 
 		if action, ok := opts.Get("afterRollupNodeStart", name); ok {
-			action(&cfg, sys)
+			action(cfg, sys)
 		}
 	}
+	return nil
+}
 
-	if cfg.P2PTopology != nil {
-		// We only set up the connections after starting the actual nodes,
-		// so GossipSub and other p2p protocols can be started before the connections go live.
-		// This way protocol negotiation happens correctly.
-		for k, vs := range cfg.P2PTopology {
-			peerA := p2pNodes[k]
-			for _, v := range vs {
-				unconnected := strings.HasPrefix(v, "~")
-				if unconnected {
-					v = v[1:]
-				}
-				if !unconnected {
-					peerB := p2pNodes[v]
-					if _, err := sys.Mocknet.ConnectPeers(peerA.HostP2P.ID(), peerB.HostP2P.ID()); err != nil {
-						return nil, fmt.Errorf("failed to setup mocknet connection between %s and %s", k, v)
-					}
+// connectP2P connects the peers of the P2P topology, except for the links of a partition.
+func (sys *System) connectP2P(opts SystemConfigOptions) error {
+	if action, ok := opts.Get("p2pPartition", "p2p"); ok {
+		action(&sys.cfg, sys)
+	}
+	// We only set up the connections after starting the actual nodes,
+	// so GossipSub and other p2p protocols can be started before the connections go live.
+	// This way protocol negotiation happens correctly.
+	for k, vs := range sys.cfg.P2PTopology {
+		peerA := sys.p2pNodes[k]
+		for _, v := range vs {
+			unconnected := strings.HasPrefix(v, "~")
+			if unconnected {
+				v = v[1:]
+			}
+			if !unconnected && !sys.isPartitioned(k, v) {
+				peerB := sys.p2pNodes[v]
+				if _, err := sys.Mocknet.ConnectPeers(peerA.HostP2P.ID(), peerB.HostP2P.ID()); err != nil {
+					return fmt.Errorf("failed to setup mocknet connection between %s and %s", k, v)
 				}
 			}
 		}
 	}
+	return nil
+}
 
-	// L2Output Submitter
+// startProposer starts the L2 output submitter, which proposes the outputs of the sequencer.
+func (sys *System) startProposer() error {
+	var err error
 	sys.L2OutputSubmitter, err = l2os.NewL2OutputSubmitterFromCLIConfig(l2os.CLIConfig{
 		L1EthRpc:          sys.Nodes["l1"].WSEndpoint(),
 		RollupRpc:         sys.RollupNodes["sequencer"].HTTPEndpoint(),
 		L2OOAddress:       predeploys.DevL2OutputOracleAddr.String(),
 		PollInterval:      50 * time.Millisecond,
-		TxMgrConfig:       newTxMgrConfig(sys.Nodes["l1"].WSEndpoint(), cfg.Secrets.Proposer),
-		AllowNonFinalized: cfg.NonFinalizedProposals,
+		TxMgrConfig:       newTxMgrConfig(sys.Nodes["l1"].WSEndpoint(), sys.cfg.Secrets.Proposer),
+		AllowNonFinalized: sys.cfg.NonFinalizedProposals,
 		LogConfig: oplog.CLIConfig{
 			Level:  "info",
 			Format: "text",
 		},
-	}, sys.cfg.Loggers["proposer"], proposermetrics.NoopMetrics)
+	}, sys.logger("proposer"), proposermetrics.NoopMetrics)
 	if err != nil {
-		return nil, fmt.Errorf("unable to setup l2 output submitter: %w", err)
+		return fmt.Errorf("unable to setup l2 output submitter: %w", err)
 	}
 
 	if err := sys.L2OutputSubmitter.Start(); err != nil {
-		return nil, fmt.Errorf("unable to start l2 output submitter: %w", err)
+		return fmt.Errorf("unable to start l2 output submitter: %w", err)
+	}
+	return nil
+}
+
+// startBatcherSigner starts a remote signer with the batcher key, and returns the config
+// of a signer client that connects to it with a client certificate.
+func (sys *System) startBatcherSigner() (signer.CLIConfig, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(sys.cfg.JWTFilePath), "batcher-signer-")
+	if err != nil {
+		return signer.CLIConfig{}, fmt.Errorf("failed to create signer TLS dir: %w", err)
+	}
+	serverTLS, clientTLS, err := signertest.GenerateTLS(dir)
+	if err != nil {
+		return signer.CLIConfig{}, fmt.Errorf("failed to generate signer TLS certificates: %w", err)
+	}
+	sys.batcherSigner, err = signertest.NewServer(sys.logger("batcher-signer"), sys.cfg.Secrets.Batcher, serverTLS)
+	if err != nil {
+		return signer.CLIConfig{}, fmt.Errorf("failed to start batcher signer: %w", err)
 	}
+	return sys.batcherSigner.ClientConfig(clientTLS), nil
+}
 
-	// Batch Submitter
+// startBatcher creates the batch submitter of the sequencer, and starts it unless it is disabled.
+func (sys *System) startBatcher(opts SystemConfigOptions) error {
+	cfg := &sys.cfg
+	l1Endpoint := sys.Nodes["l1"].WSEndpoint()
+	if action, ok := opts.Get("proposeTxFault", "batcher"); ok {
+		action(cfg, sys)
+		proxy, err := newProposeTxProxy(sys.logger("batcher"), sys.Nodes["l1"].HTTPEndpoint(), sys.Clients["l1"], sys.proposeTxFault)
+		if err != nil {
+			return fmt.Errorf("failed to start propose tx fault proxy: %w", err)
+		}
+		sys.proposeTxProxy = proxy
+		l1Endpoint = proxy.Endpoint()
+	}
+	txMgrConfig := newTxMgrConfig(l1Endpoint, cfg.Secrets.Batcher)
+	if cfg.BatcherRemoteSigner {
+		signerCfg, err := sys.startBatcherSigner()
+		if err != nil {
+			return err
+		}
+		txMgrConfig.PrivateKey = ""
+		txMgrConfig.SignerCLIConfig = signerCfg
+	}
+	var err error
 	sys.BatchSubmitter, err = bss.NewBatchSubmitterFromCLIConfig(bss.CLIConfig{
-		L1EthRpc:           sys.Nodes["l1"].WSEndpoint(),
-		L2EthRpc:           sys.Nodes["sequencer"].WSEndpoint(),
-		RollupRpc:          sys.RollupNodes["sequencer"].HTTPEndpoint(),
-		MaxChannelDuration: 1,
-		MaxL1TxSize:        120_000,
-		TargetL1TxSize:     100_000,
-		TargetNumFrames:    1,
-		ApproxComprRatio:   0.4,
-		SubSafetyMargin:    4,
-		PollInterval:       50 * time.Millisecond,
-		TxMgrConfig:        newTxMgrConfig(sys.Nodes["l1"].WSEndpoint(), cfg.Secrets.Batcher),
+		L1EthRpc:               l1Endpoint,
+		L2EthRpc:               sys.Nodes["sequencer"].WSEndpoint(),
+		RollupRpc:              sys.RollupNodes["sequencer"].HTTPEndpoint(),
+		MaxChannelDuration:     1,
+		MaxL1TxSize:            120_000,
+		TargetL1TxSize:         100_000,
+		TargetNumFrames:        1,
+		ApproxComprRatio:       0.4,
+		SubSafetyMargin:        4,
+		PollInterval:           50 * time.Millisecond,
+		JournalDir:             cfg.BatcherJournalDir,
+		L1FinalityDepth:        64,
+		MempoolTimeout:         time.Minute,
+		MaxPendingTransactions: cfg.BatcherMaxPendingTransactions,
+		ProposeRange:           cfg.BatcherProposeRange,
+		Compression:            cfg.BatcherCompression,
+		TxMgrConfig:            txMgrConfig,
 		LogConfig: oplog.CLIConfig{
 			Level:  "info",
 			Format: "text",
 		},
-	}, sys.cfg.Loggers["batcher"], batchermetrics.NoopMetrics)
+	}, sys.logger("batcher"), batchermetrics.NoopMetrics)
 	if err != nil {
-		return nil, fmt.Errorf("failed to setup batch submitter: %w", err)
+		return fmt.Errorf("failed to setup batch submitter: %w", err)
 	}
 
 	// Batcher may be enabled later
-	if !sys.cfg.DisableBatcher {
+	if !cfg.DisableBatcher {
 		if err := sys.BatchSubmitter.Start(); err != nil {
-			return nil, fmt.Errorf("unable to start batch submitter: %w", err)
+			return fmt.Errorf("unable to start batch submitter: %w", err)
 		}
 	}
-
-	return sys, nil
+	return nil
 }
 
 func selectEndpoint(node *node.Node) string {
@@ -0,0 +1,24 @@
+package op_seqsy
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// DefaultSystemConfig returns the default system configuration of the tests,
+// with the JWT secret in a temporary directory and the logs in the test output.
+func DefaultSystemConfig(t *testing.T) SystemConfig {
+	cfg, err := NewSystemConfig(t.TempDir())
+	require.NoError(t, err)
+	cfg.Loggers = map[string]log.Logger{
+		"verifier":  testlog.Logger(t, log.LvlInfo).New("role", "verifier"),
+		"sequencer": testlog.Logger(t, log.LvlInfo).New("role", "sequencer"),
+		"batcher":   testlog.Logger(t, log.LvlInfo).New("role", "batcher"),
+		"proposer":  testlog.Logger(t, log.LvlCrit).New("role", "proposer"),
+	}
+	return cfg
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli"
 )
 
@@ -31,6 +32,11 @@ const (
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	MaxGasFeeCapFlagName              = "txmgr.max-gas-fee-cap"
+	MaxGasTipCapFlagName              = "txmgr.max-gas-tip-cap"
+	MaxSpendFlagName                  = "txmgr.max-spend"
+	MaxSpendWindowFlagName            = "txmgr.max-spend-window"
+	CircuitBreakerCooldownFlagName    = "txmgr.circuit-breaker-cooldown"
 )
 
 var (
@@ -109,6 +115,36 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Value:  12 * time.Second,
 			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		cli.Float64Flag{
+			Name:   MaxGasFeeCapFlagName,
+			Usage:  "Maximum gas fee cap of transactions in GWEI, fee bumps are capped at it. Sending is paused while the basefee is at or above it. If 0 it is disabled.",
+			Value:  0,
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_GAS_FEE_CAP"),
+		},
+		cli.Float64Flag{
+			Name:   MaxGasTipCapFlagName,
+			Usage:  "Maximum gas tip cap of transactions in GWEI, fee bumps are capped at it. If 0 it is disabled.",
+			Value:  0,
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_GAS_TIP_CAP"),
+		},
+		cli.Float64Flag{
+			Name:   MaxSpendFlagName,
+			Usage:  "Maximum fees in ETH that are spent in a spend window, including the maximum fees of the transactions in flight. Sending is paused while it is reached. If 0 it is disabled.",
+			Value:  0,
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_SPEND"),
+		},
+		cli.DurationFlag{
+			Name:   MaxSpendWindowFlagName,
+			Usage:  "Duration of the sliding window of the maximum spend",
+			Value:  time.Hour,
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_MAX_SPEND_WINDOW"),
+		},
+		cli.DurationFlag{
+			Name:   CircuitBreakerCooldownFlagName,
+			Usage:  "Duration for which sending is paused when the basefee is above the maximum gas fee cap, or when the transactions in flight reach the maximum spend",
+			Value:  time.Minute,
+			EnvVar: opservice.PrefixEnvVar(envPrefix, "TXMGR_CIRCUIT_BREAKER_COOLDOWN"),
+		},
 	}, client.CLIFlags(envPrefix)...)
 }
 
@@ -127,6 +163,13 @@ type CLIConfig struct {
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	MaxGasFeeCapGwei          float64
+	MaxGasTipCapGwei          float64
+	MaxSpendEth               float64
+	MaxSpendWindow            time.Duration
+	CircuitBreakerCooldown    time.Duration
+	// KeepPendingAfterSendTimeout is not a flag, the service that pipelines its transactions sets it.
+	KeepPendingAfterSendTimeout bool
 }
 
 func (m CLIConfig) Check() error {
@@ -151,6 +194,15 @@ func (m CLIConfig) Check() error {
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	if m.MaxGasFeeCapGwei < 0 || m.MaxGasTipCapGwei < 0 || m.MaxSpendEth < 0 {
+		return errors.New("MaxGasFeeCap, MaxGasTipCap and MaxSpend must not be negative")
+	}
+	if m.MaxSpendEth != 0 && m.MaxSpendWindow == 0 {
+		return errors.New("must provide MaxSpendWindow with MaxSpend")
+	}
+	if (m.MaxGasFeeCapGwei != 0 || m.MaxSpendEth != 0) && m.CircuitBreakerCooldown == 0 {
+		return errors.New("must provide CircuitBreakerCooldown with MaxGasFeeCap or MaxSpend")
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
@@ -173,6 +225,11 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		NetworkTimeout:            ctx.GlobalDuration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.GlobalDuration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.GlobalDuration(TxNotInMempoolTimeoutFlagName),
+		MaxGasFeeCapGwei:          ctx.GlobalFloat64(MaxGasFeeCapFlagName),
+		MaxGasTipCapGwei:          ctx.GlobalFloat64(MaxGasTipCapFlagName),
+		MaxSpendEth:               ctx.GlobalFloat64(MaxSpendFlagName),
+		MaxSpendWindow:            ctx.GlobalDuration(MaxSpendWindowFlagName),
+		CircuitBreakerCooldown:    ctx.GlobalDuration(CircuitBreakerCooldownFlagName),
 	}
 }
 
@@ -209,20 +266,35 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 	}
 
 	return Config{
-		Backend:                   l1,
-		ResubmissionTimeout:       cfg.ResubmissionTimeout,
-		ChainID:                   chainID,
-		TxSendTimeout:             cfg.TxSendTimeout,
-		TxNotInMempoolTimeout:     cfg.TxNotInMempoolTimeout,
-		NetworkTimeout:            cfg.NetworkTimeout,
-		ReceiptQueryInterval:      cfg.ReceiptQueryInterval,
-		NumConfirmations:          cfg.NumConfirmations,
-		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
-		Signer:                    signerFactory(chainID),
-		From:                      from,
+		Backend:                     l1,
+		ResubmissionTimeout:         cfg.ResubmissionTimeout,
+		ChainID:                     chainID,
+		TxSendTimeout:               cfg.TxSendTimeout,
+		KeepPendingAfterSendTimeout: cfg.KeepPendingAfterSendTimeout,
+		TxNotInMempoolTimeout:       cfg.TxNotInMempoolTimeout,
+		NetworkTimeout:              cfg.NetworkTimeout,
+		ReceiptQueryInterval:        cfg.ReceiptQueryInterval,
+		NumConfirmations:            cfg.NumConfirmations,
+		SafeAbortNonceTooLowCount:   cfg.SafeAbortNonceTooLowCount,
+		MaxGasFeeCap:                toWei(cfg.MaxGasFeeCapGwei, params.GWei),
+		MaxGasTipCap:                toWei(cfg.MaxGasTipCapGwei, params.GWei),
+		MaxSpend:                    toWei(cfg.MaxSpendEth, params.Ether),
+		MaxSpendWindow:              cfg.MaxSpendWindow,
+		CircuitBreakerCooldown:      cfg.CircuitBreakerCooldown,
+		Signer:                      signerFactory(chainID),
+		From:                        from,
 	}, nil
 }
 
+// toWei converts an amount in a unit of wei to wei, or returns nil for 0.
+func toWei(amount float64, unit float64) *big.Int {
+	if amount == 0 {
+		return nil
+	}
+	wei, _ := new(big.Float).Mul(big.NewFloat(amount), big.NewFloat(unit)).Int(nil)
+	return wei
+}
+
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	Backend ETHBackend
@@ -239,6 +311,12 @@ type Config struct {
 	// By default it is unbounded. If set, this is recommended to be at least 20 minutes.
 	TxSendTimeout time.Duration
 
+	// KeepPendingAfterSendTimeout makes SendAsync send a transaction that may still be included
+	// after TxSendTimeout further, until it is included or known to be gone. It is meant for
+	// pipelined senders, whose later transactions depend on the nonce of the timed out one.
+	// Send always fails at TxSendTimeout.
+	KeepPendingAfterSendTimeout bool
+
 	// TxNotInMempoolTimeout is how long to wait before aborting a transaction send if the transaction does not
 	// make it to the mempool. If the tx is in the mempool, TxSendTimeout is used instead.
 	TxNotInMempoolTimeout time.Duration
@@ -261,6 +339,21 @@ type Config struct {
 	// confirmation.
 	SafeAbortNonceTooLowCount uint64
 
+	// MaxGasFeeCap and MaxGasTipCap cap the fees of transactions and of their fee bumps,
+	// nil for no cap. Sending is paused while the basefee is at or above MaxGasFeeCap.
+	MaxGasFeeCap *big.Int
+	MaxGasTipCap *big.Int
+
+	// MaxSpend is the limit of the fees that are spent in a sliding window of MaxSpendWindow,
+	// including the maximum fees of the transactions in flight, nil for no limit. Sending
+	// is paused while it is reached.
+	MaxSpend       *big.Int
+	MaxSpendWindow time.Duration
+
+	// CircuitBreakerCooldown is how long sending is paused if the basefee is above MaxGasFeeCap,
+	// or if the transactions in flight alone reach MaxSpend.
+	CircuitBreakerCooldown time.Duration
+
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer opcrypto.SignerFn
 	From   common.Address
@@ -0,0 +1,84 @@
+package txmgr_test
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-signer/signertest"
+)
+
+type chainIDAPI struct{}
+
+func (chainIDAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(900))
+}
+
+// TestRemoteSigner reads the signer flags with the txmgr flags, and checks that the txmgr config
+// signs with the test signer server over mutual TLS, from the configured address.
+func TestRemoteSigner(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	serverTLS, clientTLS, err := signertest.GenerateTLS(t.TempDir())
+	require.NoError(t, err)
+	srv, err := signertest.NewServer(logger, key, serverTLS)
+	require.NoError(t, err)
+	defer srv.Close()
+
+	// a stand-in for the L1 node, the txmgr config only needs the chain ID
+	l1 := rpc.NewServer()
+	require.NoError(t, l1.RegisterName("eth", chainIDAPI{}))
+	l1Server := httptest.NewServer(l1)
+	defer l1Server.Close()
+
+	var cliCfg txmgr.CLIConfig
+	app := cli.NewApp()
+	app.Flags = append(txmgr.CLIFlags("OP_TEST"), cli.StringFlag{Name: txmgr.L1RPCFlagName})
+	app.Action = func(ctx *cli.Context) error {
+		cliCfg = txmgr.ReadCLIConfig(ctx)
+		return nil
+	}
+	require.NoError(t, app.Run([]string{"test",
+		"--" + txmgr.L1RPCFlagName, l1Server.URL,
+		"--signer.endpoint", srv.Endpoint(),
+		"--signer.address", srv.Address().Hex(),
+		"--signer.tls.ca", clientTLS.TLSCaCert,
+		"--signer.tls.cert", clientTLS.TLSCert,
+		"--signer.tls.key", clientTLS.TLSKey,
+	}))
+	require.True(t, cliCfg.SignerCLIConfig.Enabled())
+
+	cfg, err := txmgr.NewConfig(cliCfg, logger)
+	require.NoError(t, err)
+	require.Equal(t, srv.Address(), cfg.From)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   cfg.ChainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21_000,
+		To:        &common.Address{0x42},
+	})
+	signed, err := cfg.Signer(context.Background(), cfg.From, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(cfg.ChainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, srv.Address(), sender)
+
+	cliCfg.SignerCLIConfig.Address = "not an address"
+	_, err = txmgr.NewConfig(cliCfg, logger)
+	require.ErrorContains(t, err, "invalid signer address")
+}
@@ -0,0 +1,191 @@
+package txmgr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when the circuit breaker of the txmgr pauses sending.
+// The error is a *CircuitOpenError, with the reason and the time until which sending is paused.
+var ErrCircuitOpen = errors.New("txmgr circuit breaker open")
+
+const (
+	// CircuitReasonSpendLimit means that the fees of the spend window reached the spend limit.
+	CircuitReasonSpendLimit = "spend_limit"
+	// CircuitReasonFeeCap means that the L1 basefee is above the maximum fee cap.
+	CircuitReasonFeeCap = "fee_cap"
+)
+
+// CircuitOpenError is returned instead of sending a transaction while the circuit breaker is open.
+// The transactions in flight are not affected, but their fees are not bumped above the limits.
+type CircuitOpenError struct {
+	Reason string
+	Until  time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%v: %s until %s", ErrCircuitOpen, e.Reason, e.Until.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type spend struct {
+	time time.Time
+	fee  *big.Int
+}
+
+// circuitBreaker limits the fees that are spent in a sliding time window. The maximum fees of the
+// transactions in flight are reserved, so that bumping their fees can't exceed the limit either.
+// The zero value has no limit, and only opens when tripped.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	// maxSpend is the limit of the fees of a window, nil for no limit
+	maxSpend *big.Int
+	window   time.Duration
+	now      func() time.Time
+
+	// spends are the fees of the confirmed transactions of the window, oldest first
+	spends []spend
+	// reserved is the sum of the maximum fees of the transactions in flight
+	reserved *big.Int
+
+	open *CircuitOpenError
+}
+
+func newCircuitBreaker(maxSpend *big.Int, window time.Duration) circuitBreaker {
+	return circuitBreaker{
+		maxSpend: maxSpend,
+		window:   window,
+	}
+}
+
+func (b *circuitBreaker) time() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+	return time.Now()
+}
+
+// prune drops the spends that are older than the window.
+func (b *circuitBreaker) prune(now time.Time) {
+	i := 0
+	for i < len(b.spends) && !b.spends[i].time.Add(b.window).After(now) {
+		i++
+	}
+	b.spends = b.spends[i:]
+}
+
+func (b *circuitBreaker) spent() *big.Int {
+	total := new(big.Int)
+	for _, s := range b.spends {
+		total.Add(total, s.fee)
+	}
+	if b.reserved != nil {
+		total.Add(total, b.reserved)
+	}
+	return total
+}
+
+// check returns a *CircuitOpenError while the circuit breaker is open. It reports whether the
+// circuit breaker closed since the last check.
+func (b *circuitBreaker) check() (closed bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.open != nil && b.time().Before(b.open.Until) {
+		return false, b.open
+	}
+	if b.open != nil {
+		b.open = nil
+		return true, nil
+	}
+	return false, nil
+}
+
+// trip opens the circuit breaker for the cooldown, or extends the time that it is open.
+// It reports whether the circuit breaker was closed.
+func (b *circuitBreaker) trip(reason string, cooldown time.Duration) (*CircuitOpenError, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tripLocked(reason, b.time().Add(cooldown))
+}
+
+func (b *circuitBreaker) tripLocked(reason string, until time.Time) (*CircuitOpenError, bool) {
+	opened := b.open == nil
+	if opened || until.After(b.open.Until) {
+		b.open = &CircuitOpenError{Reason: reason, Until: until}
+	}
+	return b.open, opened
+}
+
+// reserve reserves the maximum fee of a transaction, or of a fee bump, within the spend limit.
+// If the fee exceeds the limit, the circuit breaker opens until enough fees left the window,
+// or until cooldown passed if the fees in flight alone exceed the limit. It reports whether
+// the circuit breaker was closed.
+func (b *circuitBreaker) reserve(fee *big.Int, cooldown time.Duration) (*CircuitOpenError, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.time()
+	b.prune(now)
+	if b.maxSpend != nil {
+		excess := new(big.Int).Add(b.spent(), fee)
+		excess.Sub(excess, b.maxSpend)
+		if excess.Sign() > 0 {
+			until := now.Add(cooldown)
+			for _, s := range b.spends {
+				excess.Sub(excess, s.fee)
+				if excess.Sign() <= 0 {
+					until = s.time.Add(b.window)
+					break
+				}
+			}
+			return b.tripLocked(CircuitReasonSpendLimit, until)
+		}
+	}
+	if b.reserved == nil {
+		b.reserved = new(big.Int)
+	}
+	b.reserved.Add(b.reserved, fee)
+	return nil, false
+}
+
+// release releases a reserved fee, and records the fee that was actually spent, if any.
+// It returns the fees of the window.
+func (b *circuitBreaker) release(reserved *big.Int, spent *big.Int) *big.Int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.time()
+	if b.reserved != nil {
+		b.reserved.Sub(b.reserved, reserved)
+	}
+	if spent != nil {
+		b.spends = append(b.spends, spend{time: now, fee: spent})
+	}
+	b.prune(now)
+	return b.spent()
+}
+
+// maxFee returns the maximum fee of the transaction, which is reserved while it is in flight.
+func maxFee(gas uint64, gasFeeCap *big.Int) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(gas), gasFeeCap)
+}
+
+// capFees lowers the tip and fee cap to the maximum tip and fee cap, if they are set.
+// The tip is never above the fee cap.
+func capFees(tip, feeCap, maxTip, maxFeeCap *big.Int) (*big.Int, *big.Int) {
+	if maxTip != nil && tip.Cmp(maxTip) > 0 {
+		tip = maxTip
+	}
+	if maxFeeCap != nil && feeCap.Cmp(maxFeeCap) > 0 {
+		feeCap = maxFeeCap
+	}
+	if tip.Cmp(feeCap) > 0 {
+		tip = feeCap
+	}
+	return tip, feeCap
+}
@@ -0,0 +1,81 @@
+package txmgr
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCircuitBreakerSpendWindow checks that the circuit breaker opens when the fees of the
+// window and the fees in flight exceed the limit, until enough fees left the window.
+func TestCircuitBreakerSpendWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := newCircuitBreaker(big.NewInt(100), time.Minute)
+	b.now = func() time.Time { return now }
+
+	open, _ := b.reserve(big.NewInt(40), time.Second)
+	require.Nil(t, open)
+	require.Equal(t, big.NewInt(30), b.release(big.NewInt(40), big.NewInt(30)))
+
+	now = now.Add(30 * time.Second)
+	open, _ = b.reserve(big.NewInt(50), time.Second)
+	require.Nil(t, open)
+	require.Equal(t, big.NewInt(80), b.release(big.NewInt(50), big.NewInt(50)))
+
+	// 80 were spent in the window, so 30 exceed the limit until the first spend leaves it
+	open, opened := b.reserve(big.NewInt(30), time.Second)
+	require.NotNil(t, open)
+	require.True(t, opened)
+	require.Equal(t, CircuitReasonSpendLimit, open.Reason)
+	require.Equal(t, time.Unix(1060, 0), open.Until)
+	_, err := b.check()
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	var circuitErr *CircuitOpenError
+	require.True(t, errors.As(err, &circuitErr))
+	require.Equal(t, open, circuitErr)
+
+	now = time.Unix(1060, 0)
+	closed, err := b.check()
+	require.NoError(t, err)
+	require.True(t, closed)
+	open, _ = b.reserve(big.NewInt(30), time.Second)
+	require.Nil(t, open)
+
+	// the fees in flight alone exceed the limit, so it opens for the cooldown
+	open, _ = b.reserve(big.NewInt(71), time.Second)
+	require.NotNil(t, open)
+	require.Equal(t, now.Add(time.Second), open.Until)
+}
+
+func TestCircuitBreakerNoLimit(t *testing.T) {
+	var b circuitBreaker
+	open, _ := b.reserve(big.NewInt(1_000_000), time.Second)
+	require.Nil(t, open)
+	closed, err := b.check()
+	require.NoError(t, err)
+	require.False(t, closed)
+
+	open, opened := b.trip(CircuitReasonFeeCap, time.Hour)
+	require.True(t, opened)
+	_, err = b.check()
+	require.Equal(t, open, err)
+	_, opened = b.trip(CircuitReasonFeeCap, time.Hour)
+	require.False(t, opened)
+}
+
+func TestCapFees(t *testing.T) {
+	tip, feeCap := capFees(big.NewInt(10), big.NewInt(100), nil, nil)
+	require.Equal(t, big.NewInt(10), tip)
+	require.Equal(t, big.NewInt(100), feeCap)
+
+	tip, feeCap = capFees(big.NewInt(10), big.NewInt(100), big.NewInt(5), big.NewInt(50))
+	require.Equal(t, big.NewInt(5), tip)
+	require.Equal(t, big.NewInt(50), feeCap)
+
+	tip, feeCap = capFees(big.NewInt(10), big.NewInt(100), nil, big.NewInt(8))
+	require.Equal(t, big.NewInt(8), tip)
+	require.Equal(t, big.NewInt(8), feeCap)
+}
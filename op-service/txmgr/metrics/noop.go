@@ -1,6 +1,10 @@
 package metrics
 
-import "github.com/ethereum/go-ethereum/core/types"
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
 
 type NoopTxMetrics struct{}
 
@@ -10,3 +14,6 @@ func (*NoopTxMetrics) RecordTxConfirmationLatency(int64) {}
 func (*NoopTxMetrics) TxConfirmed(*types.Receipt)        {}
 func (*NoopTxMetrics) TxPublished(string)                {}
 func (*NoopTxMetrics) RPCError()                         {}
+func (*NoopTxMetrics) RecordCircuitBreakerOpen(string)   {}
+func (*NoopTxMetrics) RecordCircuitBreakerClosed()       {}
+func (*NoopTxMetrics) RecordWindowSpend(*big.Int)        {}
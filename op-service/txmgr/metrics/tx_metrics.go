@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"math/big"
+
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -15,6 +17,9 @@ type TxMetricer interface {
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
 	RPCError()
+	RecordCircuitBreakerOpen(reason string)
+	RecordCircuitBreakerClosed()
+	RecordWindowSpend(*big.Int)
 }
 
 type TxMetrics struct {
@@ -28,6 +33,9 @@ type TxMetrics struct {
 	publishEvent       metrics.Event
 	confirmEvent       metrics.EventVec
 	rpcError           prometheus.Counter
+	circuitBreakerOpen prometheus.Gauge
+	circuitBreakerTrip *prometheus.CounterVec
+	windowSpend        prometheus.Gauge
 }
 
 func receiptStatusString(receipt *types.Receipt) string {
@@ -96,6 +104,24 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Temporary: Count of RPC errors (like timeouts) that have occurred",
 			Subsystem: "txmgr",
 		}),
+		circuitBreakerOpen: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "circuit_breaker_open",
+			Help:      "1 if the circuit breaker pauses sending transactions, 0 otherwise",
+			Subsystem: "txmgr",
+		}),
+		circuitBreakerTrip: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "circuit_breaker_trip_count",
+			Help:      "Count of the times that the circuit breaker opened. Labels are the reasons: spend_limit or fee_cap",
+			Subsystem: "txmgr",
+		}, []string{"reason"}),
+		windowSpend: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "window_spend_gwei",
+			Help:      "Fees of the confirmed transactions of the spend window and maximum fees of the transactions in flight, in GWEI",
+			Subsystem: "txmgr",
+		}),
 	}
 }
 
@@ -132,3 +158,17 @@ func (t *TxMetrics) TxPublished(errString string) {
 func (t *TxMetrics) RPCError() {
 	t.rpcError.Inc()
 }
+
+func (t *TxMetrics) RecordCircuitBreakerOpen(reason string) {
+	t.circuitBreakerOpen.Set(1)
+	t.circuitBreakerTrip.WithLabelValues(reason).Inc()
+}
+
+func (t *TxMetrics) RecordCircuitBreakerClosed() {
+	t.circuitBreakerOpen.Set(0)
+}
+
+func (t *TxMetrics) RecordWindowSpend(spend *big.Int) {
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(spend), big.NewFloat(params.GWei)).Float64()
+	t.windowSpend.Set(gwei)
+}
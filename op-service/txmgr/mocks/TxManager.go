@@ -35,6 +35,32 @@ func (_m *TxManager) From() common.Address {
 	return r0
 }
 
+// LatestTxHash provides a mock function with given fields: nonce
+func (_m *TxManager) LatestTxHash(nonce uint64) (common.Hash, bool) {
+	ret := _m.Called(nonce)
+
+	var r0 common.Hash
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(uint64) (common.Hash, bool)); ok {
+		return rf(nonce)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) common.Hash); ok {
+		r0 = rf(nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) bool); ok {
+		r1 = rf(nonce)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // Send provides a mock function with given fields: ctx, candidate
 func (_m *TxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
 	ret := _m.Called(ctx, candidate)
@@ -61,6 +87,22 @@ func (_m *TxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ty
 	return r0, r1
 }
 
+// SendAsync provides a mock function with given fields: ctx, candidate, done
+func (_m *TxManager) SendAsync(ctx context.Context, candidate txmgr.TxCandidate, done func(*types.Receipt, error)) *types.Transaction {
+	ret := _m.Called(ctx, candidate, done)
+
+	var r0 *types.Transaction
+	if rf, ok := ret.Get(0).(func(context.Context, txmgr.TxCandidate, func(*types.Receipt, error)) *types.Transaction); ok {
+		r0 = rf(ctx, candidate, done)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewTxManager interface {
 	mock.TestingT
 	Cleanup(func())
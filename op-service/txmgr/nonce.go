@@ -0,0 +1,149 @@
+package txmgr
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// nonceManager assigns the nonces of the transactions of a single sender, so that several
+// transactions can be in flight at the same time.
+//
+// A transaction that fails before it is included may leave its nonce unused. The transactions
+// with higher nonces can't be included until the nonce is used, so the gap is assigned to the
+// next transaction, before any new nonce. A failed transaction that may still be included keeps
+// its nonce in flight as stale, until it is known to be included or gone from the transaction pool.
+type nonceManager struct {
+	mu sync.Mutex
+	// next is the nonce after the highest assigned nonce, nil if it must be fetched from the backend.
+	next *uint64
+	// inflight are the hashes of the published transactions of the assigned nonces that are not done yet,
+	// in the order of publication.
+	inflight map[uint64][]common.Hash
+	// stale are the nonces in flight of failed transactions that may still be included.
+	stale map[uint64]struct{}
+	// gaps are the assigned nonces that were left unused by failed transactions, in ascending order.
+	gaps []uint64
+}
+
+// acquire assigns the lowest gap, or else the next nonce. fetch is called for the next nonce
+// the first time, and after all transactions failed.
+func (n *nonceManager) acquire(fetch func() (uint64, error)) (uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var nonce uint64
+	switch {
+	case len(n.gaps) > 0:
+		nonce = n.gaps[0]
+		n.gaps = n.gaps[1:]
+	case n.next == nil:
+		next, err := fetch()
+		if err != nil {
+			return 0, err
+		}
+		nonce = next
+		n.next = &next
+		*n.next++
+	default:
+		nonce = *n.next
+		*n.next++
+	}
+	if n.inflight == nil {
+		n.inflight = make(map[uint64][]common.Hash)
+	}
+	n.inflight[nonce] = nil
+	return nonce, nil
+}
+
+// published records the hash of a transaction that is published with the nonce in flight.
+func (n *nonceManager) published(nonce uint64, hash common.Hash) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if hashes, ok := n.inflight[nonce]; ok {
+		n.inflight[nonce] = append(hashes, hash)
+	}
+}
+
+// hashes returns the hashes of the transactions that were published with the nonce in flight.
+func (n *nonceManager) hashes(nonce uint64) []common.Hash {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]common.Hash(nil), n.inflight[nonce]...)
+}
+
+// used records that the transaction with the nonce is done, and that the nonce was used.
+func (n *nonceManager) used(nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.inflight, nonce)
+	delete(n.stale, nonce)
+}
+
+// unused records that the transaction with the nonce failed without using it, and that it is gone.
+// It reports whether the nonce is a gap that blocks the transactions in flight.
+func (n *nonceManager) unused(nonce uint64) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.inflight, nonce)
+	delete(n.stale, nonce)
+
+	if n.next == nil || nonce >= *n.next {
+		return false
+	}
+	if len(n.inflight) == 0 {
+		// nothing waits for the nonce, the next transaction starts from the backend nonce again
+		n.next = nil
+		n.gaps = nil
+		return false
+	}
+	i := sort.Search(len(n.gaps), func(i int) bool { return n.gaps[i] >= nonce })
+	if i < len(n.gaps) && n.gaps[i] == nonce {
+		return false
+	}
+	n.gaps = append(n.gaps, 0)
+	copy(n.gaps[i+1:], n.gaps[i:])
+	n.gaps[i] = nonce
+	// the gaps at the top aren't followed by a transaction in flight, the next nonces fill them
+	for len(n.gaps) > 0 && n.gaps[len(n.gaps)-1] == *n.next-1 {
+		n.gaps = n.gaps[:len(n.gaps)-1]
+		*n.next--
+	}
+	return i < len(n.gaps)
+}
+
+// keep records that the transaction with the nonce failed, but may still be included.
+// The nonce stays in flight, so that it is not assigned to another transaction.
+func (n *nonceManager) keep(nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.inflight[nonce]; !ok {
+		return
+	}
+	if n.stale == nil {
+		n.stale = make(map[uint64]struct{})
+	}
+	n.stale[nonce] = struct{}{}
+}
+
+// staleNonces returns the nonces that failed transactions keep in flight, in ascending order.
+func (n *nonceManager) staleNonces() []uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	nonces := make([]uint64, 0, len(n.stale))
+	for nonce := range n.stale {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	return nonces
+}
+
+// usedBelow records that the nonces below the given backend nonce were used,
+// which drops the gaps that were filled by transactions that aren't tracked.
+func (n *nonceManager) usedBelow(nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	i := sort.Search(len(n.gaps), func(i int) bool { return n.gaps[i] >= nonce })
+	n.gaps = n.gaps[i:]
+}
@@ -0,0 +1,99 @@
+package txmgr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNonceManagerGaps ensures that the nonces left unused by failed transactions are assigned
+// first, in ascending order, and only while transactions with higher nonces are in flight.
+func TestNonceManagerGaps(t *testing.T) {
+	var n nonceManager
+	fetches := 0
+	fetch := func() (uint64, error) {
+		fetches++
+		return 10, nil
+	}
+	acquire := func(expected uint64) {
+		nonce, err := n.acquire(fetch)
+		require.NoError(t, err)
+		require.Equal(t, expected, nonce)
+	}
+
+	for i := uint64(10); i < 15; i++ {
+		acquire(i)
+	}
+	require.Equal(t, 1, fetches)
+
+	require.True(t, n.unused(13), "nonce 14 is in flight")
+	require.True(t, n.unused(11), "nonce 12 is in flight")
+	require.False(t, n.unused(13), "nonce 13 is already a gap")
+	acquire(11)
+	acquire(13)
+	acquire(15)
+
+	// the gaps that aren't followed by a transaction in flight are not reused
+	require.True(t, n.unused(12))
+	require.False(t, n.unused(15))
+	require.False(t, n.unused(14))
+	require.False(t, n.unused(13))
+	acquire(12)
+
+	// gaps below the backend nonce were used by other transactions
+	require.True(t, n.unused(11))
+	n.usedBelow(12)
+	acquire(13)
+
+	n.used(10)
+	n.used(12)
+	n.used(13)
+	acquire(14)
+	require.False(t, n.unused(14), "no transaction is in flight")
+	acquire(10)
+	require.Equal(t, 2, fetches, "the nonce is fetched again after all transactions failed")
+
+	_, err := (&nonceManager{}).acquire(func() (uint64, error) {
+		return 0, errors.New("failed")
+	})
+	require.Error(t, err)
+}
+
+// TestNonceManagerStale ensures that the nonce of a failed transaction that may still be included
+// stays in flight, and is assigned again only once the transaction is known to be gone.
+func TestNonceManagerStale(t *testing.T) {
+	var n nonceManager
+	fetch := func() (uint64, error) { return 10, nil }
+	for i := uint64(10); i < 13; i++ {
+		nonce, err := n.acquire(fetch)
+		require.NoError(t, err)
+		require.Equal(t, i, nonce)
+	}
+	n.published(11, common.Hash{0x01})
+	n.published(11, common.Hash{0x02})
+	n.published(20, common.Hash{0x03})
+	require.Equal(t, []common.Hash{{0x01}, {0x02}}, n.hashes(11))
+	require.Empty(t, n.hashes(20), "nonce 20 is not in flight")
+
+	n.keep(11)
+	n.keep(20)
+	require.Equal(t, []uint64{11}, n.staleNonces())
+	nonce, err := n.acquire(fetch)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), nonce, "the stale nonce is not a gap")
+
+	// the stale transaction is gone
+	require.True(t, n.unused(11))
+	require.Empty(t, n.staleNonces())
+	nonce, err = n.acquire(fetch)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), nonce)
+
+	// the stale transaction was included
+	n.keep(12)
+	n.used(12)
+	require.Empty(t, n.staleNonces())
+	require.Empty(t, n.hashes(12))
+}
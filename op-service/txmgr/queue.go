@@ -0,0 +1,94 @@
+package txmgr
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxReceipt is the outcome of a transaction candidate that was sent through a [Queue].
+type TxReceipt[T any] struct {
+	// ID identifies the candidate, as passed to [Queue.Send].
+	ID T
+	// Receipt is the receipt of the confirmed transaction, nil if Err is set.
+	Receipt *types.Receipt
+	// Err is the error of the transaction manager if the transaction was not confirmed.
+	Err error
+}
+
+// Queue sends transaction candidates through a [TxManager], with at most maxPending of them
+// in flight at the same time. The TxManager assigns consecutive nonces to the candidates, so
+// they are included in the order in which they are sent, and a candidate that fails leaves
+// its nonce to the next candidate once its transaction is known to be gone.
+type Queue[T any] struct {
+	ctx   context.Context
+	txMgr TxManager
+	// pending holds one element per candidate in flight, it is nil if the candidates are unlimited.
+	pending chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewQueue creates a queue that sends the candidates through txMgr until ctx is done.
+// If maxPending is zero, the number of candidates in flight is not limited.
+func NewQueue[T any](ctx context.Context, txMgr TxManager, maxPending uint64) *Queue[T] {
+	q := &Queue[T]{
+		ctx:   ctx,
+		txMgr: txMgr,
+	}
+	if maxPending > 0 {
+		q.pending = make(chan struct{}, maxPending)
+	}
+	return q
+}
+
+// Send sends the candidate, and blocks while maxPending candidates are in flight.
+// The receipt of the candidate is sent to receiptCh once its transaction is confirmed or failed,
+// so receiptCh is the future of the candidate. It may be shared by several candidates.
+// A candidate is in flight until its receipt is received, so receiptCh must be drained
+// concurrently, or be buffered for maxPending receipts.
+//
+// It returns the transaction as it was first crafted, or nil if it could not be crafted,
+// in which case the error is sent to receiptCh before Send returns.
+func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan<- TxReceipt[T]) *types.Transaction {
+	if q.pending == nil {
+		return q.send(id, candidate, receiptCh, false)
+	}
+	select {
+	case q.pending <- struct{}{}:
+		return q.send(id, candidate, receiptCh, true)
+	case <-q.ctx.Done():
+		// the transaction manager fails the candidate with the context error
+		return q.send(id, candidate, receiptCh, false)
+	}
+}
+
+// TrySend is like Send, but it doesn't block. It returns false without sending the candidate
+// if maxPending candidates are in flight.
+func (q *Queue[T]) TrySend(id T, candidate TxCandidate, receiptCh chan<- TxReceipt[T]) (*types.Transaction, bool) {
+	if q.pending == nil {
+		return q.send(id, candidate, receiptCh, false), true
+	}
+	select {
+	case q.pending <- struct{}{}:
+		return q.send(id, candidate, receiptCh, true), true
+	default:
+		return nil, false
+	}
+}
+
+// Wait waits until the receipts of all candidates in flight are received.
+func (q *Queue[T]) Wait() {
+	q.wg.Wait()
+}
+
+func (q *Queue[T]) send(id T, candidate TxCandidate, receiptCh chan<- TxReceipt[T], acquired bool) *types.Transaction {
+	q.wg.Add(1)
+	return q.txMgr.SendAsync(q.ctx, candidate, func(receipt *types.Receipt, err error) {
+		defer q.wg.Done()
+		receiptCh <- TxReceipt[T]{ID: id, Receipt: receipt, Err: err}
+		if acquired {
+			<-q.pending
+		}
+	})
+}
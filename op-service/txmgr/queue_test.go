@@ -0,0 +1,99 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+// asyncTxManager mocks SendAsync of a transaction manager that assigns consecutive nonces,
+// and passes the done callbacks of the transactions in flight to the test.
+func asyncTxManager(t *testing.T, craftErr error) (*mocks.TxManager, chan func(*types.Receipt, error)) {
+	txMgr := mocks.NewTxManager(t)
+	inflight := make(chan func(*types.Receipt, error), 10)
+	nonce := uint64(0)
+	txMgr.On("SendAsync", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ txmgr.TxCandidate, done func(*types.Receipt, error)) *types.Transaction {
+			if craftErr != nil {
+				done(nil, craftErr)
+				return nil
+			}
+			tx := types.NewTx(&types.DynamicFeeTx{Nonce: nonce})
+			nonce++
+			inflight <- done
+			return tx
+		})
+	return txMgr, inflight
+}
+
+// TestQueueSend ensures that the queue limits the candidates in flight, and passes their
+// receipts with their IDs to the receipt channel.
+func TestQueueSend(t *testing.T) {
+	txMgr, inflight := asyncTxManager(t, nil)
+	q := txmgr.NewQueue[int](context.Background(), txMgr, 2)
+	receiptCh := make(chan txmgr.TxReceipt[int], 2)
+
+	tx := q.Send(0, txmgr.TxCandidate{}, receiptCh)
+	require.Equal(t, uint64(0), tx.Nonce())
+	tx, ok := q.TrySend(1, txmgr.TxCandidate{}, receiptCh)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), tx.Nonce())
+	_, ok = q.TrySend(2, txmgr.TxCandidate{}, receiptCh)
+	require.False(t, ok, "two candidates are in flight")
+
+	// a blocked Send continues once a candidate is done
+	sent := make(chan *types.Transaction)
+	go func() {
+		sent <- q.Send(2, txmgr.TxCandidate{}, receiptCh)
+	}()
+	select {
+	case <-sent:
+		t.Fatal("send does not block while two candidates are in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	(<-inflight)(&types.Receipt{TxHash: common.Hash{0x01}}, nil)
+	r := <-receiptCh
+	require.Equal(t, 0, r.ID)
+	require.Equal(t, common.Hash{0x01}, r.Receipt.TxHash)
+	require.NoError(t, r.Err)
+	require.Equal(t, uint64(2), (<-sent).Nonce())
+
+	failed := errors.New("aborted transaction sending")
+	(<-inflight)(nil, failed)
+	(<-inflight)(&types.Receipt{TxHash: common.Hash{0x03}}, nil)
+	q.Wait()
+	r = <-receiptCh
+	require.Equal(t, 1, r.ID)
+	require.ErrorIs(t, r.Err, failed)
+	r = <-receiptCh
+	require.Equal(t, 2, r.ID)
+	require.Equal(t, common.Hash{0x03}, r.Receipt.TxHash)
+}
+
+// TestQueueCraftError ensures that a candidate that can't be crafted is failed right away,
+// and doesn't stay in flight.
+func TestQueueCraftError(t *testing.T) {
+	failed := errors.New("failed to create the tx")
+	txMgr, _ := asyncTxManager(t, failed)
+	q := txmgr.NewQueue[string](context.Background(), txMgr, 1)
+	receiptCh := make(chan txmgr.TxReceipt[string], 1)
+
+	for _, id := range []string{"a", "b"} {
+		tx, ok := q.TrySend(id, txmgr.TxCandidate{}, receiptCh)
+		require.True(t, ok)
+		require.Nil(t, tx)
+		r := <-receiptCh
+		require.Equal(t, id, r.ID)
+		require.ErrorIs(t, r.Err, failed)
+	}
+	q.Wait()
+}
@@ -38,9 +38,23 @@ type TxManager interface {
 	// It can be stopped by cancelling the provided context; however, the transaction
 	// may be included on L1 even if the context is cancelled.
 	//
-	// NOTE: Send should be called by AT MOST one caller at a time.
+	// NOTE: Send can be called concurrently, the nonce is managed internally. The nonce of a
+	// failed transaction is assigned to the next transaction once the failed transaction is
+	// known to be gone from the transaction pool, and is kept in flight while it may still be included.
 	Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error)
 
+	// SendAsync crafts the transaction like Send, and then publishes it and waits for
+	// its receipt in the background. The result is passed to done.
+	// The nonce is assigned before SendAsync returns, so the transactions of consecutive
+	// calls are included in the order of the calls.
+	// It returns the crafted transaction, or nil if it could not be crafted. Gas price bumps
+	// replace it with transactions of the same nonce and gas limit, but a different hash.
+	SendAsync(ctx context.Context, candidate TxCandidate, done func(*types.Receipt, error)) *types.Transaction
+
+	// LatestTxHash returns the hash of the latest transaction that was published with the nonce,
+	// while the nonce is in flight. Gas price bumps replace the transaction with one of a different hash.
+	LatestTxHash(nonce uint64) (common.Hash, bool)
+
 	// From returns the sending address associated with the instance of the transaction manager.
 	// It is static for a single instance of a TxManager.
 	From() common.Address
@@ -52,6 +66,10 @@ type ETHBackend interface {
 	// BlockNumber returns the most recent block number.
 	BlockNumber(ctx context.Context) (uint64, error)
 
+	// TransactionByHash returns the transaction with the given hash, from the chain or the transaction pool.
+	// It returns ethereum.NotFound if the backend doesn't know the transaction.
+	TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error)
+
 	// TransactionReceipt queries the backend for a receipt associated with
 	// txHash. If lookup does not fail, but the transaction is not found,
 	// nil should be returned for both values.
@@ -84,6 +102,9 @@ type SimpleTxManager struct {
 	backend ETHBackend
 	l       log.Logger
 	metr    metrics.TxMetricer
+
+	nonces  nonceManager
+	breaker circuitBreaker
 }
 
 // NewSimpleTxManager initializes a new SimpleTxManager with the passed Config.
@@ -100,6 +121,7 @@ func NewSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLI
 		backend: conf.Backend,
 		l:       l.New("service", name),
 		metr:    m,
+		breaker: newCircuitBreaker(conf.MaxSpend, conf.MaxSpendWindow),
 	}, nil
 }
 
@@ -107,6 +129,15 @@ func (m *SimpleTxManager) From() common.Address {
 	return m.cfg.From
 }
 
+// LatestTxHash returns the hash of the latest transaction that was published with the nonce in flight.
+func (m *SimpleTxManager) LatestTxHash(nonce uint64) (common.Hash, bool) {
+	hashes := m.nonces.hashes(nonce)
+	if len(hashes) == 0 {
+		return common.Hash{}, false
+	}
+	return hashes[len(hashes)-1], true
+}
+
 // TxCandidate is a transaction candidate that can be submitted to ask the
 // [TxManager] to construct a transaction with gas price bounds.
 type TxCandidate struct {
@@ -126,7 +157,7 @@ type TxCandidate struct {
 // The transaction manager handles all signing. If and only if the gas limit is 0, the
 // transaction manager will do a gas estimation.
 //
-// NOTE: Send should be called by AT MOST one caller at a time.
+// NOTE: Send can be called concurrently, the nonce is managed internally.
 func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
 	if m.cfg.TxSendTimeout != 0 {
 		var cancel context.CancelFunc
@@ -137,7 +168,41 @@ func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.send(ctx, tx)
+	receipt, err := m.send(ctx, tx)
+	m.nonceDone(tx.Nonce(), err)
+	return receipt, err
+}
+
+// SendAsync crafts the transaction with the next nonce, and then publishes it with
+// incrementally higher gas prices in the background, like Send.
+// done is called with the receipt or the error once the transaction is confirmed or failed.
+// It is called from the goroutine of the caller if the transaction can't be crafted, and nil is returned.
+//
+// If KeepPendingAfterSendTimeout is set, the send timeout only bounds the crafting of the transaction,
+// and a transaction that may still be included after it is sent further, see sendUntilGone.
+func (m *SimpleTxManager) SendAsync(ctx context.Context, candidate TxCandidate, done func(*types.Receipt, error)) *types.Transaction {
+	keepPending := m.cfg.KeepPendingAfterSendTimeout
+	sendCtx, cancel := ctx, context.CancelFunc(func() {})
+	if m.cfg.TxSendTimeout != 0 {
+		sendCtx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
+	}
+	tx, err := m.craftTx(sendCtx, candidate)
+	if err != nil {
+		cancel()
+		done(nil, fmt.Errorf("failed to create the tx: %w", err))
+		return nil
+	}
+	if keepPending {
+		// sendUntilGone handles the send timeout itself
+		sendCtx = ctx
+	}
+	go func() {
+		defer cancel()
+		receipt, err := m.sendUntilGone(sendCtx, tx, keepPending)
+		m.nonceDone(tx.Nonce(), err)
+		done(receipt, err)
+	}()
+	return tx
 }
 
 // craftTx creates the signed transaction
@@ -146,26 +211,24 @@ func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
+	if err := m.checkCircuit(); err != nil {
+		return nil, err
+	}
 	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
-	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
-
-	// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
-	childCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	nonce, err := m.backend.NonceAt(childCtx, m.cfg.From, nil)
-	if err != nil {
-		m.metr.RPCError()
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	if m.cfg.MaxGasFeeCap != nil && basefee.Cmp(m.cfg.MaxGasFeeCap) >= 0 {
+		// a transaction within the fee cap can't be included until the basefee drops
+		open, opened := m.breaker.trip(CircuitReasonFeeCap, m.cfg.CircuitBreakerCooldown)
+		m.circuitTripped(open, opened, "basefee", basefee, "max_fee_cap", m.cfg.MaxGasFeeCap)
+		return nil, open
 	}
-	m.metr.RecordNonce(nonce)
+	gasTipCap, gasFeeCap := capFees(gasTipCap, calcGasFeeCap(basefee, gasTipCap), m.cfg.MaxGasTipCap, m.cfg.MaxGasFeeCap)
 
 	rawTx := &types.DynamicFeeTx{
 		ChainID:   m.chainID,
-		Nonce:     nonce,
 		To:        candidate.To,
 		GasTipCap: gasTipCap,
 		GasFeeCap: gasFeeCap,
@@ -192,36 +255,220 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		rawTx.Gas = gas
 	}
 
-	ctx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	// the maximum fee is reserved until send returns, so that the fees in flight are within the spend limit
+	fee := maxFee(rawTx.Gas, gasFeeCap)
+	if open, opened := m.breaker.reserve(fee, m.cfg.CircuitBreakerCooldown); open != nil {
+		m.circuitTripped(open, opened, "max_fee", fee)
+		return nil, open
+	}
+
+	nonce, err := m.nextNonce(ctx)
+	if err != nil {
+		m.breaker.release(fee, nil)
+		return nil, err
+	}
+	rawTx.Nonce = nonce
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	tx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	if err != nil {
+		m.breaker.release(fee, nil)
+		m.nonces.unused(nonce)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// checkCircuit returns a *CircuitOpenError while the circuit breaker is open.
+func (m *SimpleTxManager) checkCircuit() error {
+	closed, err := m.breaker.check()
+	if closed {
+		m.l.Info("circuit breaker closed, sending resumes")
+		m.metr.RecordCircuitBreakerClosed()
+	}
+	return err
+}
+
+// circuitTripped alerts that the circuit breaker opened, and logs that it stays open otherwise.
+func (m *SimpleTxManager) circuitTripped(open *CircuitOpenError, opened bool, ctx ...interface{}) {
+	ctx = append([]interface{}{"reason", open.Reason, "until", open.Until}, ctx...)
+	if opened {
+		m.l.Error("circuit breaker opened, sending is paused", ctx...)
+		m.metr.RecordCircuitBreakerOpen(open.Reason)
+	} else {
+		m.l.Warn("circuit breaker is open", ctx...)
+	}
+}
+
+// nextNonce returns the nonce of the next transaction. The nonce is fetched from the
+// latest known block the first time, and after all transactions in flight failed.
+// It is incremented otherwise, so that the transactions that are in flight at the same
+// time have consecutive nonces. A nonce left unused by a failed transaction is reused first,
+// so the stale nonces of failed transactions are checked again before.
+func (m *SimpleTxManager) nextNonce(ctx context.Context) (uint64, error) {
+	for _, nonce := range m.nonces.staleNonces() {
+		m.settleNonce(ctx, nonce)
+	}
+	nonce, err := m.nonces.acquire(func() (uint64, error) {
+		// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
+		childCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		defer cancel()
+		nonce, err := m.backend.NonceAt(childCtx, m.cfg.From, nil)
+		if err != nil {
+			m.metr.RPCError()
+			return 0, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		return nonce, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	m.metr.RecordNonce(nonce)
+	return nonce, nil
+}
+
+// nonceDone records the outcome of the transaction with the nonce. The nonce of a failed
+// transaction is only released once it is known whether the nonce was used, see settleNonce.
+func (m *SimpleTxManager) nonceDone(nonce uint64, err error) {
+	if err == nil {
+		m.nonces.used(nonce)
+		return
+	}
+	m.settleNonce(context.Background(), nonce)
+}
+
+// settleNonce releases the nonce of a failed transaction if it was used, e.g. if sending was
+// cancelled after the transaction was included, or if the transaction is gone. A gone transaction
+// leaves a gap that the next transaction fills, so that the transactions in flight after it can be
+// included. Otherwise the transaction may still be included, and the nonce is kept in flight,
+// so that the next transaction neither replaces it nor is included after it with the same data.
+func (m *SimpleTxManager) settleNonce(ctx context.Context, nonce uint64) {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	return m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	state, latest, err := m.nonceState(ctx, nonce, m.nonces.hashes(nonce))
+	if err != nil {
+		m.metr.RPCError()
+		m.l.Warn("failed to check the nonce of a failed transaction, keeping it in flight", "nonce", nonce, "err", err)
+		m.nonces.keep(nonce)
+		return
+	}
+	switch state {
+	case nonceIncluded:
+		m.nonces.used(nonce)
+		m.nonces.usedBelow(latest)
+	case nonceGone:
+		if m.nonces.unused(nonce) {
+			m.l.Warn("failed transaction left a nonce gap, the next transaction fills it", "nonce", nonce)
+		}
+	default:
+		m.l.Warn("failed transaction may still be included, keeping its nonce in flight", "nonce", nonce)
+		m.nonces.keep(nonce)
+	}
+}
+
+// nonceStatus is what the backend tells about the transactions with a nonce of the sender.
+type nonceStatus int
+
+const (
+	// nonceGone means that no transaction with the nonce was included or is in the transaction pool.
+	nonceGone nonceStatus = iota
+	// noncePending means that a transaction with the nonce may still be included.
+	noncePending
+	// nonceIncluded means that a transaction with the nonce was included in the latest known block.
+	nonceIncluded
+)
+
+// nonceState returns the state of the nonce, given the hashes of the transactions that were published with it,
+// and the nonce of the latest known block. The transactions are pending if the pending nonce of the sender
+// covers the nonce, or if the transaction pool holds any of them, e.g. queued behind a nonce gap.
+func (m *SimpleTxManager) nonceState(ctx context.Context, nonce uint64, hashes []common.Hash) (nonceStatus, uint64, error) {
+	latest, err := m.backend.NonceAt(ctx, m.cfg.From, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	if nonce < latest {
+		return nonceIncluded, latest, nil
+	}
+	pending, err := m.backend.PendingNonceAt(ctx, m.cfg.From)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+	if nonce < pending {
+		return noncePending, latest, nil
+	}
+	for _, hash := range hashes {
+		_, _, err := m.backend.TransactionByHash(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return 0, 0, fmt.Errorf("failed to get transaction %s: %w", hash, err)
+		}
+		return noncePending, latest, nil
+	}
+	return nonceGone, latest, nil
 }
 
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
 func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return m.sendUntilGone(ctx, tx, false)
+}
+
+// sendUntilGone sends the transaction like send. If keepPending is set, ctx is not bounded by the
+// send timeout. Once the send timeout passed, sending fails as soon as the transaction is known to be
+// gone. While it may still be included, it is bumped further like before the timeout, since giving up
+// would leave it to be included after its data was sent again in another transaction.
+func (m *SimpleTxManager) sendUntilGone(ctx context.Context, tx *types.Transaction, keepPending bool) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// release the maximum fee of the last bump, which craftTx and increaseGasPrice reserved
+	var fee *big.Int
+	defer func() {
+		m.metr.RecordWindowSpend(m.breaker.release(maxFee(tx.Gas(), tx.GasFeeCap()), fee))
+	}()
+
 	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
 	receiptChan := make(chan *types.Receipt, 1)
+	var hashes []common.Hash
 	sendTxAsync := func(tx *types.Transaction) {
 		defer wg.Done()
 		m.publishAndWaitForTx(ctx, tx, sendState, receiptChan)
 	}
+	publish := func(tx *types.Transaction) {
+		hashes = append(hashes, tx.Hash())
+		m.nonces.published(tx.Nonce(), tx.Hash())
+		wg.Add(1)
+		go sendTxAsync(tx)
+	}
 
 	// Immediately publish a transaction before starting the resumbission loop
-	wg.Add(1)
-	go sendTxAsync(tx)
+	publish(tx)
 
 	ticker := time.NewTicker(m.cfg.ResubmissionTimeout)
 	defer ticker.Stop()
 
+	var timeout <-chan time.Time
+	if keepPending && m.cfg.TxSendTimeout != 0 {
+		timer := time.NewTimer(m.cfg.TxSendTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	timedOut := false
+
 	bumpCounter := 0
 	for {
 		select {
+		case <-timeout:
+			timedOut = true
+			if m.txGone(ctx, tx.Nonce(), hashes) {
+				return nil, fmt.Errorf("transaction not included within the send timeout: %w", context.DeadlineExceeded)
+			}
+			m.l.Warn("send timeout passed, but the transaction may still be included, sending it further", "nonce", tx.Nonce())
+
 		case <-ticker.C:
 			// Don't resubmit a transaction if it has been mined, but we are waiting for the conf depth.
 			if sendState.IsWaitingForConfirmation() {
@@ -232,16 +479,25 @@ func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*typ
 				m.l.Warn("Aborting transaction submission")
 				return nil, errors.New("aborted transaction sending")
 			}
+			if timedOut && m.txGone(ctx, tx.Nonce(), hashes) {
+				return nil, fmt.Errorf("transaction not included within the send timeout: %w", context.DeadlineExceeded)
+			}
 			// Increase the gas price & submit the new transaction
-			tx = m.increaseGasPrice(ctx, tx)
-			wg.Add(1)
+			bumped := m.increaseGasPrice(ctx, tx)
 			bumpCounter += 1
-			go sendTxAsync(tx)
+			if bumped != tx {
+				tx = bumped
+				publish(tx)
+			} else {
+				wg.Add(1)
+				go sendTxAsync(tx)
+			}
 
 		case <-ctx.Done():
 			return nil, ctx.Err()
 
 		case receipt := <-receiptChan:
+			fee = receiptFee(receipt, tx)
 			m.metr.RecordGasBumpCount(bumpCounter)
 			m.metr.TxConfirmed(receipt)
 			return receipt, nil
@@ -249,6 +505,19 @@ func (m *SimpleTxManager) send(ctx context.Context, tx *types.Transaction) (*typ
 	}
 }
 
+// txGone reports whether the transaction with the nonce is known to be gone, and thus can't be included anymore.
+func (m *SimpleTxManager) txGone(ctx context.Context, nonce uint64, hashes []common.Hash) bool {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	state, _, err := m.nonceState(ctx, nonce, hashes)
+	if err != nil {
+		m.metr.RPCError()
+		m.l.Warn("failed to check whether the transaction is gone", "nonce", nonce, "err", err)
+		return false
+	}
+	return state == nonceGone
+}
+
 // publishAndWaitForTx publishes the transaction to the transaction pool and then waits for it with [waitMined].
 // It should be called in a new go-routine. It will send the receipt to receiptChan in a non-blocking way if a receipt is found
 // for the transaction.
@@ -387,11 +656,31 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 		return tx
 	}
 	gasTipCap, gasFeeCap := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	cappedTip, cappedFeeCap := capFees(gasTipCap, gasFeeCap, m.cfg.MaxGasTipCap, m.cfg.MaxGasFeeCap)
+	if cappedTip.Cmp(gasTipCap) != 0 || cappedFeeCap.Cmp(gasFeeCap) != 0 {
+		m.l.Warn("gas price bump is capped by the maximum fees", "tip", gasTipCap, "fee_cap", gasFeeCap,
+			"max_tip", m.cfg.MaxGasTipCap, "max_fee_cap", m.cfg.MaxGasFeeCap)
+		// the transaction pool rejects replacements that don't bump both fees enough
+		if cappedTip.Cmp(calcThresholdValue(tx.GasTipCap())) < 0 || cappedFeeCap.Cmp(calcThresholdValue(tx.GasFeeCap())) < 0 {
+			return tx
+		}
+		gasTipCap, gasFeeCap = cappedTip, cappedFeeCap
+	}
 
 	if tx.GasTipCapIntCmp(gasTipCap) == 0 && tx.GasFeeCapIntCmp(gasFeeCap) == 0 {
 		return tx
 	}
 
+	// reserve the increase of the maximum fee, within the spend limit
+	var extraFee *big.Int
+	if tx.GasFeeCapIntCmp(gasFeeCap) < 0 {
+		extraFee = maxFee(tx.Gas(), new(big.Int).Sub(gasFeeCap, tx.GasFeeCap()))
+		if open, opened := m.breaker.reserve(extraFee, m.cfg.CircuitBreakerCooldown); open != nil {
+			m.circuitTripped(open, opened, "hash", tx.Hash(), "extra_fee", extraFee)
+			return tx
+		}
+	}
+
 	rawTx := &types.DynamicFeeTx{
 		ChainID:    tx.ChainId(),
 		Nonce:      tx.Nonce(),
@@ -408,11 +697,23 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	newTx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
 	if err != nil {
 		m.l.Warn("failed to sign new transaction", "err", err)
+		if extraFee != nil {
+			m.breaker.release(extraFee, nil)
+		}
 		return tx
 	}
 	return newTx
 }
 
+// receiptFee returns the fee that the transaction of the receipt paid. The maximum fee of the
+// last bump of the transaction is assumed if the receipt has no effective gas price.
+func receiptFee(receipt *types.Receipt, tx *types.Transaction) *big.Int {
+	if receipt.EffectiveGasPrice == nil {
+		return maxFee(receipt.GasUsed, tx.GasFeeCap())
+	}
+	return maxFee(receipt.GasUsed, receipt.EffectiveGasPrice)
+}
+
 // suggestGasPriceCaps suggests what the new tip & new basefee should be based on the current L1 conditions
 func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, error) {
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
@@ -1,6 +1,7 @@
 package txmgr
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -48,6 +49,7 @@ func newTestHarnessWithConfig(t *testing.T, cfg Config) *testHarness {
 		backend: cfg.Backend,
 		l:       testlog.Logger(t, log.LvlCrit),
 		metr:    &metrics.NoopTxMetrics{},
+		breaker: newCircuitBreaker(cfg.MaxSpend, cfg.MaxSpendWindow),
 	}
 
 	return &testHarness{
@@ -153,6 +155,14 @@ type mockBackend struct {
 	// blockHeight tracks the current height of the chain.
 	blockHeight uint64
 
+	// nonce is the nonce of the sender at the current height of the chain.
+	nonce uint64
+	// pendingNonce is the pending nonce of the sender, if it is above nonce.
+	pendingNonce uint64
+
+	// pool are the hashes of the transactions in the transaction pool.
+	pool map[common.Hash]struct{}
+
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
 }
@@ -162,6 +172,7 @@ func newMockBackend(g *gasPricer) *mockBackend {
 	return &mockBackend{
 		g:        g,
 		minedTxs: make(map[common.Hash]minedTxInfo),
+		pool:     make(map[common.Hash]struct{}),
 	}
 }
 
@@ -217,11 +228,48 @@ func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction
 }
 
 func (b *mockBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	return 0, nil
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nonce, nil
+}
+
+// setNonce sets the nonce of the sender at the current height of the chain.
+func (b *mockBackend) setNonce(nonce uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nonce = nonce
+}
+
+// setPending sets the pending nonce of the sender, and the transactions in the transaction pool.
+func (b *mockBackend) setPending(nonce uint64, hashes ...common.Hash) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pendingNonce = nonce
+	b.pool = make(map[common.Hash]struct{})
+	for _, hash := range hashes {
+		b.pool[hash] = struct{}{}
+	}
 }
 
 func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	return 0, nil
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.pendingNonce > b.nonce {
+		return b.pendingNonce, nil
+	}
+	return b.nonce, nil
+}
+
+func (b *mockBackend) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if _, ok := b.pool[txHash]; ok {
+		return types.NewTx(&types.DynamicFeeTx{}), true, nil
+	}
+	if _, ok := b.minedTxs[txHash]; ok {
+		return types.NewTx(&types.DynamicFeeTx{}), false, nil
+	}
+	return nil, false, ethereum.NotFound
 }
 
 func (*mockBackend) ChainID(ctx context.Context) (*big.Int, error) {
@@ -393,6 +441,394 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgrCircuitOpenOnBasefee checks that no transaction is crafted while the basefee is
+// above the maximum fee cap, and that the fees of crafted transactions are capped.
+func TestTxMgrCircuitOpenOnBasefee(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.MaxGasFeeCap = big.NewInt(10)
+	cfg.CircuitBreakerCooldown = time.Hour
+	h := newTestHarnessWithConfig(t, cfg)
+
+	// the basefee of the first epoch is 7, the fee cap 19
+	tx, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), tx.GasFeeCap())
+	require.Equal(t, big.NewInt(5), tx.GasTipCap())
+	h.mgr.nonceDone(tx.Nonce(), nil)
+
+	// the basefee of the second epoch is 14
+	_, err = h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	var circuitErr *CircuitOpenError
+	require.ErrorAs(t, err, &circuitErr)
+	require.Equal(t, CircuitReasonFeeCap, circuitErr.Reason)
+
+	// sending stays paused for the cooldown, and no nonce was used
+	_, err = h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	h.mgr.breaker.now = func() time.Time { return time.Now().Add(time.Hour) }
+	h.mgr.cfg.MaxGasFeeCap = nil
+	tx, err = h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tx.Nonce())
+}
+
+// TestTxMgrSpendLimit checks that sending is paused when the maximum fees of the transactions
+// in flight and the fees of the window exceed the spend limit.
+func TestTxMgrSpendLimit(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	// the first transaction has a maximum fee of 1337 * 19, the second of 1337 * 38
+	cfg.MaxSpend = big.NewInt(60_000)
+	cfg.MaxSpendWindow = time.Hour
+	cfg.CircuitBreakerCooldown = time.Minute
+	h := newTestHarnessWithConfig(t, cfg)
+
+	tx1, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	_, err = h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	var circuitErr *CircuitOpenError
+	require.ErrorAs(t, err, &circuitErr)
+	require.Equal(t, CircuitReasonSpendLimit, circuitErr.Reason)
+
+	// once the first transaction is confirmed, only its fee counts, but sending stays paused
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+	_, err = h.mgr.send(context.Background(), tx1)
+	require.NoError(t, err)
+	require.Equal(t, []spend{{time: h.mgr.breaker.spends[0].time, fee: big.NewInt(19 * 19)}}, h.mgr.breaker.spends)
+	require.Zero(t, h.mgr.breaker.reserved.Sign())
+	_, err = h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	// the fees in flight alone exceeded the limit, so sending resumes after the cooldown
+	h.mgr.breaker.now = func() time.Time { return time.Now().Add(time.Minute) }
+	require.NoError(t, h.mgr.checkCircuit())
+}
+
+// TestTxMgr_NonceManagement ensures that consecutive transactions get consecutive nonces,
+// that a nonce left unused by a failed transaction is reused first, and that the nonce is
+// fetched from the backend again after all transactions failed.
+func TestTxMgr_NonceManagement(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	craft := func(expected uint64) {
+		tx, err := h.mgr.craftTx(context.Background(), candidate)
+		require.NoError(t, err)
+		require.Equal(t, expected, tx.Nonce())
+	}
+	failed := errors.New("failed")
+
+	for i := uint64(0); i < 3; i++ {
+		craft(i)
+	}
+
+	// nonce 1 is a gap before nonce 2, which is in flight
+	h.mgr.nonceDone(1, failed)
+	craft(1)
+	craft(3)
+
+	// nonce 0 was included, although its transaction failed
+	h.backend.setNonce(1)
+	h.mgr.nonceDone(0, failed)
+	craft(4)
+
+	// nonce 4 is the highest nonce, it is reused without a gap
+	h.mgr.nonceDone(4, failed)
+	craft(4)
+
+	h.mgr.nonceDone(4, nil)
+	h.mgr.nonceDone(3, failed)
+	h.mgr.nonceDone(1, failed)
+	h.mgr.nonceDone(2, failed)
+	craft(1)
+}
+
+// TestTxMgrSendAsyncNonceGap ensures that a transaction that is never published leaves a nonce gap,
+// which is filled by the next transaction, so that the transactions after the gap are confirmed.
+func TestTxMgrSendAsyncNonceGap(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.TxNotInMempoolTimeout = 100 * time.Millisecond
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+
+	var mu sync.Mutex
+	dropped := h.createTxCandidate()
+	published := make(map[uint64]*types.Transaction)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		// the first transaction never makes it to the mempool
+		if tx.Nonce() == 0 && bytes.Equal(tx.Data(), dropped.TxData) {
+			return errors.New("txpool is full")
+		}
+		published[tx.Nonce()] = tx
+		return nil
+	})
+	// mine the transactions in nonce order, like L1
+	mineInOrder := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		nonce, _ := h.backend.NonceAt(context.Background(), common.Address{}, nil)
+		for tx := published[nonce]; tx != nil; tx = published[nonce] {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			nonce++
+			h.backend.setNonce(nonce)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	type result struct {
+		receipt *types.Receipt
+		err     error
+	}
+	results := make(chan result, 4)
+	done := func(receipt *types.Receipt, err error) {
+		results <- result{receipt, err}
+	}
+	tx := h.mgr.SendAsync(ctx, dropped, done)
+	require.Zero(t, tx.Nonce())
+	candidate := h.createTxCandidate()
+	candidate.TxData = []byte{0x03}
+	for i := 1; i < 3; i++ {
+		tx := h.mgr.SendAsync(ctx, candidate, done)
+		require.Equal(t, uint64(i), tx.Nonce())
+	}
+
+	// the first transaction is aborted, the others wait for its nonce
+	r := <-results
+	require.ErrorContains(t, r.err, "aborted")
+	mineInOrder()
+	height, _ := h.backend.BlockNumber(ctx)
+	require.Zero(t, height, "no transaction after the gap is mined")
+
+	tx = h.mgr.SendAsync(ctx, candidate, done)
+	require.Zero(t, tx.Nonce(), "the next transaction fills the gap")
+	require.Eventually(t, func() bool {
+		mineInOrder()
+		nonce, _ := h.backend.NonceAt(context.Background(), common.Address{}, nil)
+		return nonce == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		select {
+		case r := <-results:
+			require.NoError(t, r.err)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for receipts")
+		}
+	}
+
+	tx, err := h.mgr.craftTx(ctx, candidate)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), tx.Nonce())
+}
+
+// TestTxMgrKeepsNonceOfPendingTx ensures that a failed transaction that may still be included keeps its
+// nonce in flight, and that the nonce is only assigned to the next transaction once the failed one is gone.
+func TestTxMgrKeepsNonceOfPendingTx(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+
+	published := make(chan *types.Transaction, 1)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		h.backend.setPending(tx.Nonce()+1, tx.Hash())
+		published <- tx
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	tx := h.mgr.SendAsync(ctx, h.createTxCandidate(), func(_ *types.Receipt, err error) {
+		errs <- err
+	})
+	require.Zero(t, tx.Nonce())
+	require.Equal(t, tx.Hash(), (<-published).Hash())
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+	latest, ok := h.mgr.LatestTxHash(0)
+	require.True(t, ok, "the nonce of the cancelled transaction is in flight")
+	require.Equal(t, tx.Hash(), latest)
+
+	next, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), next.Nonce(), "the cancelled transaction is in the pool")
+
+	// the transaction pool drops the cancelled transaction
+	h.backend.setPending(0)
+	gap, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Zero(t, gap.Nonce(), "the cancelled transaction is gone")
+	_, ok = h.mgr.LatestTxHash(0)
+	require.False(t, ok, "the gap is not published yet")
+}
+
+// TestTxMgrSendTimeoutPendingTx ensures that Send, and SendAsync unless KeepPendingAfterSendTimeout is set,
+// fail at the send timeout even if the transaction is still pending, and that the pending transaction keeps its nonce.
+func TestTxMgrSendTimeoutPendingTx(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.TxSendTimeout = 100 * time.Millisecond
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		h.backend.setPending(tx.Nonce()+1, tx.Hash())
+		return nil
+	})
+
+	start := time.Now()
+	_, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 10*cfg.TxSendTimeout, "Send fails at the send timeout")
+
+	errs := make(chan error, 1)
+	tx := h.mgr.SendAsync(context.Background(), h.createTxCandidate(), func(_ *types.Receipt, err error) {
+		errs <- err
+	})
+	require.Equal(t, uint64(1), tx.Nonce(), "the pending transaction of Send keeps its nonce")
+	select {
+	case err := <-errs:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(10 * cfg.TxSendTimeout):
+		t.Fatal("SendAsync did not fail at the send timeout")
+	}
+}
+
+// TestTxMgrSendAsyncTimeoutMinedLate ensures that a transaction that is still pending after the send timeout
+// is sent further and confirmed once it is mined late, and that the next transaction doesn't reuse its nonce,
+// so that its data is not included twice.
+func TestTxMgrSendAsyncTimeoutMinedLate(t *testing.T) {
+	t.Parallel()
+	cfg := configWithNumConfs(1)
+	cfg.TxSendTimeout = 100 * time.Millisecond
+	cfg.KeepPendingAfterSendTimeout = true
+	cfg.ResubmissionTimeout = 50 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+
+	var mu sync.Mutex
+	latest := make(map[uint64]*types.Transaction)
+	data := make(map[uint64][][]byte)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		latest[tx.Nonce()] = tx
+		data[tx.Nonce()] = append(data[tx.Nonce()], tx.Data())
+		h.backend.setPending(uint64(len(latest)), tx.Hash())
+		return nil
+	})
+	mine := func(nonce uint64) common.Hash {
+		mu.Lock()
+		defer mu.Unlock()
+		txHash := latest[nonce].Hash()
+		h.backend.mine(&txHash, latest[nonce].GasFeeCap())
+		h.backend.setNonce(nonce + 1)
+		return txHash
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	late := h.createTxCandidate()
+	results := make(chan *types.Receipt, 2)
+	done := func(receipt *types.Receipt, err error) {
+		require.NoError(t, err)
+		results <- receipt
+	}
+	tx := h.mgr.SendAsync(ctx, late, done)
+	require.Zero(t, tx.Nonce())
+
+	// the send timeout passes several times while the transaction is pending
+	time.Sleep(5 * cfg.TxSendTimeout)
+	require.Empty(t, results, "the pending transaction is not failed")
+	next := h.createTxCandidate()
+	next.TxData = []byte{0x03}
+	tx = h.mgr.SendAsync(ctx, next, done)
+	require.Equal(t, uint64(1), tx.Nonce(), "the pending transaction keeps its nonce")
+
+	mined := mine(0)
+	select {
+	case receipt := <-results:
+		require.Equal(t, mined, receipt.TxHash)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the late receipt")
+	}
+	mine(1)
+	select {
+	case <-results:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the receipt")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, d := range data[0] {
+		require.Equal(t, late.TxData, d, "nonce 0 is only used by the late transaction")
+	}
+	for _, d := range data[1] {
+		require.Equal(t, next.TxData, d)
+	}
+}
+
+// TestTxMgrSendAsync ensures that transactions sent with SendAsync are in flight at the same time
+// with consecutive nonces, and that their receipts are passed to the done callbacks.
+func TestTxMgrSendAsync(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+
+	var mu sync.Mutex
+	published := make(map[uint64]*types.Transaction)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		published[tx.Nonce()] = tx
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipts := make(chan *types.Receipt, 3)
+	for i := 0; i < 3; i++ {
+		tx := h.mgr.SendAsync(ctx, h.createTxCandidate(), func(receipt *types.Receipt, err error) {
+			require.NoError(t, err)
+			receipts <- receipt
+		})
+		require.NotNil(t, tx)
+		require.Equal(t, uint64(i), tx.Nonce())
+	}
+
+	// all transactions are published before any of them is mined
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(published) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx, ok := published[nonce]
+		require.True(t, ok, "nonce %d not published", nonce)
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+	}
+	mu.Unlock()
+
+	var hashes []common.Hash
+	for i := 0; i < 3; i++ {
+		select {
+		case receipt := <-receipts:
+			hashes = append(hashes, receipt.TxHash)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for receipts")
+		}
+	}
+	require.ElementsMatch(t, []common.Hash{published[0].Hash(), published[1].Hash(), published[2].Hash()}, hashes)
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -669,6 +1105,10 @@ func (b *failingBackend) PendingNonceAt(_ context.Context, _ common.Address) (ui
 	return 0, errors.New("unimplemented")
 }
 
+func (b *failingBackend) TransactionByHash(_ context.Context, _ common.Hash) (*types.Transaction, bool, error) {
+	return nil, false, errors.New("unimplemented")
+}
+
 func (b *failingBackend) ChainID(ctx context.Context) (*big.Int, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -849,6 +1289,46 @@ func TestIncreaseGasPriceNotExponential(t *testing.T) {
 
 }
 
+// TestIncreaseGasPriceLimits checks that fee bumps are capped at the maximum fees, and that
+// a bump is skipped if the capped fees can't replace the transaction or exceed the spend limit.
+func TestIncreaseGasPriceLimits(t *testing.T) {
+	t.Parallel()
+	newMgr := func(maxFeeCap *big.Int, maxSpend *big.Int) *SimpleTxManager {
+		cfg := configWithNumConfs(1)
+		cfg.MaxGasFeeCap = maxFeeCap
+		cfg.MaxSpend = maxSpend
+		cfg.MaxSpendWindow = time.Hour
+		cfg.CircuitBreakerCooldown = time.Minute
+		return &SimpleTxManager{
+			cfg:     cfg,
+			name:    "TEST",
+			backend: &failingBackend{gasTip: big.NewInt(50), baseFee: big.NewInt(200)},
+			l:       testlog.Logger(t, log.LvlCrit),
+			metr:    &metrics.NoopTxMetrics{},
+			breaker: newCircuitBreaker(maxSpend, time.Hour),
+		}
+	}
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(100),
+		Gas:       1000,
+	})
+
+	// the bump without limits is to a tip of 50 and a fee cap of 450
+	newTx := newMgr(big.NewInt(300), nil).increaseGasPrice(context.Background(), tx)
+	require.Equal(t, big.NewInt(50), newTx.GasTipCap())
+	require.Equal(t, big.NewInt(300), newTx.GasFeeCap())
+
+	newTx = newMgr(big.NewInt(110), nil).increaseGasPrice(context.Background(), tx)
+	require.Equal(t, tx.Hash(), newTx.Hash(), "fee cap below the replacement threshold")
+
+	mgr := newMgr(nil, big.NewInt(300_000))
+	newTx = mgr.increaseGasPrice(context.Background(), tx)
+	require.Equal(t, tx.Hash(), newTx.Hash(), "fee bump above the spend limit")
+	_, err := mgr.breaker.check()
+	require.ErrorIs(t, err, ErrCircuitOpen)
+}
+
 func TestErrStringMatch(t *testing.T) {
 	tests := []struct {
 		err    error
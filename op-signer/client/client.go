@@ -105,5 +105,18 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 		return nil, err
 	}
 
+	// the signer must sign the requested transaction, for the requested account
+	signer := types.LatestSignerForChainID(chainId)
+	if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, fmt.Errorf("signer returned a different transaction %s", signed.Hash())
+	}
+	sender, err := types.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature of signed transaction: %w", err)
+	}
+	if sender != from {
+		return nil, fmt.Errorf("transaction was signed by %s, expected %s", sender, from)
+	}
+
 	return signed, nil
 }
@@ -0,0 +1,120 @@
+package client_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+	"github.com/ethereum-optimism/optimism/op-signer/signertest"
+)
+
+func testTx(chainID *big.Int) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21_000,
+		To:        &common.Address{0x42},
+		Data:      []byte{0x01, 0x02},
+	})
+}
+
+// TestSignerClientMTLS signs a transaction with the test signer server over mutual TLS,
+// and checks that clients without a certificate signed by the CA of the server are rejected.
+func TestSignerClientMTLS(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	serverTLS, clientTLS, err := signertest.GenerateTLS(t.TempDir())
+	require.NoError(t, err)
+	srv, err := signertest.NewServer(logger, key, serverTLS)
+	require.NoError(t, err)
+	defer srv.Close()
+
+	signer, err := client.NewSignerClientFromConfig(logger, srv.ClientConfig(clientTLS))
+	require.NoError(t, err)
+	chainID := big.NewInt(900)
+	tx := testTx(chainID)
+	signed, err := signer.SignTransaction(context.Background(), chainID, srv.Address(), tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, srv.Address(), sender)
+	require.Equal(t, tx.Nonce(), signed.Nonce())
+
+	_, err = signer.SignTransaction(context.Background(), chainID, common.Address{0x01}, tx)
+	require.ErrorContains(t, err, "not allowed to sign for account")
+
+	// a certificate of another CA is rejected by the server
+	_, otherTLS, err := signertest.GenerateTLS(t.TempDir())
+	require.NoError(t, err)
+	_, err = client.NewSignerClientFromConfig(logger, srv.ClientConfig(optls.CLIConfig{
+		TLSCaCert: clientTLS.TLSCaCert,
+		TLSCert:   otherTLS.TLSCert,
+		TLSKey:    otherTLS.TLSKey,
+	}))
+	require.Error(t, err)
+}
+
+// dishonestSigner signs every transaction with its own key, and can tamper with the nonce.
+type dishonestSigner struct {
+	key        *ecdsa.PrivateKey
+	nonceDelta uint64
+}
+
+func (s *dishonestSigner) SignTransaction(args client.TransactionArgs) (hexutil.Bytes, error) {
+	*args.Nonce += hexutil.Uint64(s.nonceDelta)
+	tx := args.ToTransaction()
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(tx.ChainId()), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+type healthAPI struct{}
+
+func (healthAPI) Status() string { return "dishonest" }
+
+// TestSignerClientChecksSignedTx checks that the client rejects transactions that were not
+// signed for the requested account, or that differ from the requested transaction.
+func TestSignerClientChecksSignedTx(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signerAPI := &dishonestSigner{key: key}
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("eth", signerAPI))
+	require.NoError(t, rpcServer.RegisterName("health", healthAPI{}))
+	httpServer := httptest.NewServer(rpcServer)
+	defer httpServer.Close()
+
+	signer, err := client.NewSignerClient(logger, httpServer.URL, optls.CLIConfig{})
+	require.NoError(t, err)
+	chainID := big.NewInt(900)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	_, err = signer.SignTransaction(context.Background(), chainID, from, testTx(chainID))
+	require.NoError(t, err)
+
+	_, err = signer.SignTransaction(context.Background(), chainID, common.Address{0x01}, testTx(chainID))
+	require.ErrorContains(t, err, "expected 0x0100000000000000000000000000000000000000")
+
+	signerAPI.nonceDelta = 1
+	_, err = signer.SignTransaction(context.Background(), chainID, from, testTx(chainID))
+	require.ErrorContains(t, err, "different transaction")
+}
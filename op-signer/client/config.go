@@ -2,7 +2,9 @@ package client
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -45,6 +47,9 @@ func (c CLIConfig) Check() error {
 	if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
 		return errors.New("signer endpoint and address must both be set or not set")
 	}
+	if c.Address != "" && !common.IsHexAddress(c.Address) {
+		return fmt.Errorf("invalid signer address: %q", c.Address)
+	}
 	return nil
 }
 
@@ -57,8 +62,8 @@ func (c CLIConfig) Enabled() bool {
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	cfg := CLIConfig{
-		Endpoint:  ctx.String(EndpointFlagName),
-		Address:   ctx.String(AddressFlagName),
+		Endpoint:  ctx.GlobalString(EndpointFlagName),
+		Address:   ctx.GlobalString(AddressFlagName),
 		TLSConfig: optls.ReadCLIConfigWithPrefix(ctx, "signer"),
 	}
 	return cfg
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-signer/flags"
+	"github.com/ethereum-optimism/optimism/op-signer/service"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	Version   = "v0.0.0"
+	GitCommit = ""
+	GitDate   = ""
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	app.Name = "op-signer"
+	app.Usage = "Remote Transaction Signer"
+	app.Description = "Service for signing the transactions of the batcher and proposer keys for authenticated clients"
+	app.Action = curryMain(Version)
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+// curryMain transforms the service.Main function into an app.Action
+// This is done to capture the Version of the signer.
+func curryMain(version string) func(ctx *cli.Context) error {
+	return func(ctx *cli.Context) error {
+		return service.Main(version, ctx)
+	}
+}
@@ -0,0 +1,82 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+const envVarPrefix = "OP_SIGNER"
+
+var (
+	// Required flags
+	ClientsConfigFlag = cli.StringFlag{
+		Name:   "clients-config",
+		Usage:  "Path of the JSON config of the clients, with the accounts, to addresses and chain IDs that each client may sign for",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "CLIENTS_CONFIG"),
+	}
+	AuditLogFlag = cli.StringFlag{
+		Name:   "audit-log",
+		Usage:  "Path of the audit log, a JSON line is appended for every signed or rejected transaction",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "AUDIT_LOG"),
+	}
+
+	// Optional flags
+	KeystoreFlag = cli.StringFlag{
+		Name:   "keystore",
+		Usage:  "Directory of a keystore, all keys of the keystore are loaded",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "KEYSTORE"),
+	}
+	PasswordFileFlag = cli.StringFlag{
+		Name:   "password-file",
+		Usage:  "Path of the file with the password of the keys of the keystore",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "PASSWORD_FILE"),
+	}
+	MnemonicFlag = cli.StringFlag{
+		Name:   "mnemonic",
+		Usage:  "The mnemonic of an HD wallet, the keys at the hd-paths are loaded",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "MNEMONIC"),
+	}
+	HDPathsFlag = cli.StringSliceFlag{
+		Name:   "hd-paths",
+		Usage:  "The derivation paths of the keys of the HD wallet of the mnemonic",
+		EnvVar: opservice.PrefixEnvVar(envVarPrefix, "HD_PATHS"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	ClientsConfigFlag,
+	AuditLogFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	KeystoreFlag,
+	PasswordFileFlag,
+	MnemonicFlag,
+	HDPathsFlag,
+}
+
+func init() {
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, optls.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
+
+	Flags = append(requiredFlags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func CheckRequired(ctx *cli.Context) error {
+	for _, f := range requiredFlags {
+		if !ctx.GlobalIsSet(f.GetName()) {
+			return fmt.Errorf("flag %s is required", f.GetName())
+		}
+	}
+	return nil
+}
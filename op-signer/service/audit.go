@@ -0,0 +1,74 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AuditRecord is a line of the audit log, for a signed or a rejected transaction.
+type AuditRecord struct {
+	Time    time.Time       `json:"time"`
+	Client  string          `json:"client"`
+	From    *common.Address `json:"from"`
+	To      *common.Address `json:"to"`
+	ChainID *hexutil.Big    `json:"chainId"`
+	Nonce   *hexutil.Uint64 `json:"nonce"`
+	// TxHash is the hash of the signed transaction, nil if it was rejected
+	TxHash *common.Hash `json:"txHash,omitempty"`
+	// Error is the reason why the transaction was rejected
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLog appends a JSON line per record to a writer. Records written to a file are synced
+// before Record returns.
+type AuditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w}
+}
+
+// OpenAuditLog opens the audit log file at path, and appends to it.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return NewAuditLog(f), nil
+}
+
+func (a *AuditLog) Record(r AuditRecord) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(line); err != nil {
+		return err
+	}
+	if f, ok := a.w.(*os.File); ok {
+		return f.Sync()
+	}
+	return nil
+}
+
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
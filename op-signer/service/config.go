@@ -0,0 +1,193 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-signer/flags"
+)
+
+// ClientConfig is what a client may sign. The to addresses and chain IDs of a client must be
+// listed, unless the client is explicitly unrestricted.
+type ClientConfig struct {
+	// Name is the common name of the TLS certificate of the client
+	Name string `json:"name"`
+	// Accounts are the accounts that the client may sign for
+	Accounts []common.Address `json:"accounts"`
+	// ToAddresses are the addresses that the client may send transactions to
+	ToAddresses []common.Address `json:"toAddresses"`
+	// ChainIDs are the chains that the client may sign transactions for
+	ChainIDs []uint64 `json:"chainIds"`
+	// Unrestricted lets the client sign transactions to any address, including contract creations,
+	// for any chain. It is meant for local testing, the to addresses and chain IDs are ignored.
+	Unrestricted bool `json:"unrestricted"`
+}
+
+// Check ensures that the client can only sign what it lists, so that a missing list fails closed.
+func (c *ClientConfig) Check() error {
+	if c.Name == "" {
+		return errors.New("client without a name")
+	}
+	if len(c.Accounts) == 0 {
+		return fmt.Errorf("client %q has no accounts", c.Name)
+	}
+	if c.Unrestricted {
+		return nil
+	}
+	if len(c.ToAddresses) == 0 {
+		return fmt.Errorf("client %q has no to addresses, it must be explicitly unrestricted to send to any address", c.Name)
+	}
+	if len(c.ChainIDs) == 0 {
+		return fmt.Errorf("client %q has no chain IDs, it must be explicitly unrestricted to sign for any chain", c.Name)
+	}
+	return nil
+}
+
+func (c *ClientConfig) allowsAccount(addr common.Address) bool {
+	for _, account := range c.Accounts {
+		if account == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ClientConfig) allowsTo(to *common.Address) bool {
+	if c.Unrestricted {
+		return true
+	}
+	if to == nil {
+		return false
+	}
+	for _, addr := range c.ToAddresses {
+		if addr == *to {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ClientConfig) allowsChainID(chainID *big.Int) bool {
+	if c.Unrestricted {
+		return true
+	}
+	for _, id := range c.ChainIDs {
+		if new(big.Int).SetUint64(id).Cmp(chainID) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientsConfig is the JSON file of the clients of the signer.
+type ClientsConfig struct {
+	Clients []ClientConfig `json:"clients"`
+}
+
+// LoadClientsConfig reads the clients config file at path.
+func LoadClientsConfig(path string) ([]ClientConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clients config: %w", err)
+	}
+	var cfg ClientsConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode clients config: %w", err)
+	}
+	for _, c := range cfg.Clients {
+		if err := c.Check(); err != nil {
+			return nil, fmt.Errorf("invalid clients config: %w", err)
+		}
+	}
+	return cfg.Clients, nil
+}
+
+type CLIConfig struct {
+	ClientsConfig string
+	AuditLog      string
+	KeystoreDir   string
+	PasswordFile  string
+	Mnemonic      string
+	HDPaths       []string
+
+	RPCConfig oprpc.CLIConfig
+	TLSConfig optls.CLIConfig
+	LogConfig oplog.CLIConfig
+}
+
+func (c CLIConfig) Check() error {
+	if err := c.RPCConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.TLSConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.LogConfig.Check(); err != nil {
+		return err
+	}
+	if !c.TLSConfig.TLSEnabled() {
+		return errors.New("tls must be enabled, the signer only serves clients with a certificate")
+	}
+	if c.KeystoreDir == "" && c.Mnemonic == "" {
+		return errors.New("a keystore or a mnemonic must be set")
+	}
+	if c.KeystoreDir != "" && c.PasswordFile == "" {
+		return errors.New("the keystore requires a password file")
+	}
+	if c.Mnemonic != "" && len(c.HDPaths) == 0 {
+		return errors.New("the mnemonic requires HD paths")
+	}
+	return nil
+}
+
+// LoadKeys loads the keys of the keystore and of the HD wallet.
+func (c CLIConfig) LoadKeys() ([]*ecdsa.PrivateKey, error) {
+	var keys []*ecdsa.PrivateKey
+	if c.KeystoreDir != "" {
+		password, err := os.ReadFile(c.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read password file: %w", err)
+		}
+		keystoreKeys, err := LoadKeystore(c.KeystoreDir, strings.TrimRight(string(password), "\r\n"))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, keystoreKeys...)
+	}
+	if c.Mnemonic != "" {
+		hdKeys, err := DeriveHDKeys(c.Mnemonic, c.HDPaths)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, hdKeys...)
+	}
+	return keys, nil
+}
+
+func NewConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		/* Required Flags */
+		ClientsConfig: ctx.GlobalString(flags.ClientsConfigFlag.Name),
+		AuditLog:      ctx.GlobalString(flags.AuditLogFlag.Name),
+
+		/* Optional Flags */
+		KeystoreDir:  ctx.GlobalString(flags.KeystoreFlag.Name),
+		PasswordFile: ctx.GlobalString(flags.PasswordFileFlag.Name),
+		Mnemonic:     ctx.GlobalString(flags.MnemonicFlag.Name),
+		HDPaths:      ctx.GlobalStringSlice(flags.HDPathsFlag.Name),
+		RPCConfig:    oprpc.ReadCLIConfig(ctx),
+		TLSConfig:    optls.ReadCLIConfig(ctx),
+		LogConfig:    oplog.ReadCLIConfig(ctx),
+	}
+}
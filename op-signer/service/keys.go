@@ -0,0 +1,67 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+
+	hdwallet "github.com/ethereum-optimism/go-ethereum-hdwallet"
+)
+
+// LoadKeystore decrypts all keys of the keystore directory with the password.
+func LoadKeystore(dir string, password string) ([]*ecdsa.PrivateKey, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	var keys []*ecdsa.PrivateKey
+	for _, entry := range entries {
+		// skip the editor backups and dotfiles, like the keystore of geth
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || strings.HasSuffix(entry.Name(), "~") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		keyJSON, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file %s: %w", path, err)
+		}
+		key, err := keystore.DecryptKey(keyJSON, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt key file %s: %w", path, err)
+		}
+		keys = append(keys, key.PrivateKey)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys in keystore %s", dir)
+	}
+	return keys, nil
+}
+
+// DeriveHDKeys derives the keys of the HD wallet of the mnemonic at the derivation paths.
+func DeriveHDKeys(mnemonic string, hdPaths []string) ([]*ecdsa.PrivateKey, error) {
+	wallet, err := hdwallet.NewFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mnemonic: %w", err)
+	}
+	keys := make([]*ecdsa.PrivateKey, 0, len(hdPaths))
+	for _, hdPath := range hdPaths {
+		if _, err := accounts.ParseDerivationPath(hdPath); err != nil {
+			return nil, fmt.Errorf("invalid HD path %q: %w", hdPath, err)
+		}
+		key, err := wallet.PrivateKey(accounts.Account{
+			URL: accounts.URL{
+				Path: hdPath,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key at %s: %w", hdPath, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
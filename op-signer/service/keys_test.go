@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeystore(t *testing.T) {
+	dir := t.TempDir()
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = ks.ImportECDSA(key, "password")
+	require.NoError(t, err)
+
+	keys, err := LoadKeystore(dir, "password")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(keys[0].PublicKey))
+
+	_, err = LoadKeystore(dir, "wrong")
+	require.ErrorContains(t, err, "failed to decrypt key file")
+	_, err = LoadKeystore(t.TempDir(), "password")
+	require.ErrorContains(t, err, "no keys in keystore")
+}
+
+func TestDeriveHDKeys(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+	keys, err := DeriveHDKeys(mnemonic, []string{"m/44'/60'/0'/0/0", "m/44'/60'/0'/0/1"})
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), crypto.PubkeyToAddress(keys[0].PublicKey))
+	require.Equal(t, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), crypto.PubkeyToAddress(keys[1].PublicKey))
+
+	_, err = DeriveHDKeys(mnemonic, []string{"not a path"})
+	require.ErrorContains(t, err, "invalid HD path")
+}
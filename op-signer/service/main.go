@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/urfave/cli"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-signer/flags"
+)
+
+// Main is the entrypoint into the signer service. It loads the keys and the clients config,
+// and serves the signer API over TLS until it is interrupted.
+func Main(version string, cliCtx *cli.Context) error {
+	if err := flags.CheckRequired(cliCtx); err != nil {
+		return err
+	}
+	cfg := NewConfig(cliCtx)
+	if err := cfg.Check(); err != nil {
+		return fmt.Errorf("invalid CLI flags: %w", err)
+	}
+
+	l := oplog.NewLogger(cfg.LogConfig)
+	l.Info("Initializing Signer")
+
+	keys, err := cfg.LoadKeys()
+	if err != nil {
+		return err
+	}
+	clients, err := LoadClientsConfig(cfg.ClientsConfig)
+	if err != nil {
+		return err
+	}
+	audit, err := OpenAuditLog(cfg.AuditLog)
+	if err != nil {
+		return err
+	}
+	defer audit.Close()
+	svc, err := NewSignerService(l, keys, clients, audit)
+	if err != nil {
+		return err
+	}
+	for _, c := range clients {
+		if c.Unrestricted {
+			l.Warn("client is unrestricted, it may sign transactions to any address and for any chain", "client", c.Name)
+		}
+	}
+
+	tlsConfig, cm, err := NewServerTLSConfig(l, cfg.TLSConfig)
+	if err != nil {
+		return err
+	}
+	defer cm.Stop()
+
+	rpcCfg := cfg.RPCConfig
+	server := oprpc.NewServer(
+		rpcCfg.ListenAddr,
+		rpcCfg.ListenPort,
+		version,
+		oprpc.WithLogger(l),
+		oprpc.WithAPIs(svc.APIs()),
+		oprpc.WithTLSConfig(&oprpc.ServerTLSConfig{
+			Config:    tlsConfig,
+			CLIConfig: &cfg.TLSConfig,
+		}),
+	)
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("error starting RPC server: %w", err)
+	}
+	l.Info("Signer started", "endpoint", server.Endpoint(), "accounts", svc.Accounts(), "clients", len(clients))
+
+	interruptChannel := make(chan os.Signal, 1)
+	signal.Notify(interruptChannel, []os.Signal{
+		os.Interrupt,
+		os.Kill,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	}...)
+	<-interruptChannel
+	if err := server.Stop(); err != nil {
+		l.Error("Error shutting down http server", "err", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+)
+
+// SignerService signs transactions over JSON-RPC, in the eth_signTransaction format of
+// op-signer/client. Clients are identified by the common name of their TLS certificate, and
+// may only sign within the allowlists of their ClientConfig. Every request is recorded in the
+// audit log, and no signature is returned if it can't be recorded.
+type SignerService struct {
+	log     log.Logger
+	keys    map[common.Address]*ecdsa.PrivateKey
+	clients map[string]ClientConfig
+	audit   *AuditLog
+}
+
+func NewSignerService(log log.Logger, keys []*ecdsa.PrivateKey, clients []ClientConfig, audit *AuditLog) (*SignerService, error) {
+	s := &SignerService{
+		log:     log,
+		keys:    make(map[common.Address]*ecdsa.PrivateKey, len(keys)),
+		clients: make(map[string]ClientConfig, len(clients)),
+		audit:   audit,
+	}
+	for _, key := range keys {
+		s.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	for _, c := range clients {
+		if err := c.Check(); err != nil {
+			return nil, err
+		}
+		if _, ok := s.clients[c.Name]; ok {
+			return nil, fmt.Errorf("duplicate client %q", c.Name)
+		}
+		for _, account := range c.Accounts {
+			if _, ok := s.keys[account]; !ok {
+				return nil, fmt.Errorf("client %q may sign for account %s, which has no key", c.Name, account)
+			}
+		}
+		s.clients[c.Name] = c
+	}
+	return s, nil
+}
+
+// Accounts returns the accounts of the keys of the service, sorted.
+func (s *SignerService) Accounts() []common.Address {
+	accounts := make([]common.Address, 0, len(s.keys))
+	for addr := range s.keys {
+		accounts = append(accounts, addr)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Hex() < accounts[j].Hex()
+	})
+	return accounts
+}
+
+// APIs returns the eth namespace of the service, to register it with an RPC server.
+func (s *SignerService) APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "eth",
+			Service:   &EthAPI{s: s},
+		},
+	}
+}
+
+// clientName returns the common name of the TLS certificate of the client of the request,
+// or an empty name if the client didn't authenticate.
+func clientName(ctx context.Context) string {
+	info := optls.PeerTLSInfoFromContext(ctx)
+	if info.LeafCertificate == nil {
+		return ""
+	}
+	return info.LeafCertificate.Subject.CommonName
+}
+
+// signTransaction checks that the client may sign the transaction of the arguments, and signs it.
+func (s *SignerService) signTransaction(name string, args *client.TransactionArgs) (*types.Transaction, error) {
+	if err := checkTransactionArgs(args); err != nil {
+		return nil, err
+	}
+	cfg, ok := s.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("client %q is not allowed to sign", name)
+	}
+	if !cfg.allowsAccount(*args.From) {
+		return nil, fmt.Errorf("client %q is not allowed to sign for account %s", name, args.From)
+	}
+	chainID := args.ChainID.ToInt()
+	if !cfg.allowsChainID(chainID) {
+		return nil, fmt.Errorf("client %q is not allowed to sign for chain %s", name, chainID)
+	}
+	if !cfg.allowsTo(args.To) {
+		if args.To == nil {
+			return nil, fmt.Errorf("client %q is not allowed to create contracts", name)
+		}
+		return nil, fmt.Errorf("client %q is not allowed to send to %s", name, args.To)
+	}
+	key, ok := s.keys[*args.From]
+	if !ok {
+		return nil, fmt.Errorf("no key for account %s", args.From)
+	}
+	tx := args.ToTransaction()
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return signed, nil
+}
+
+// EthAPI is the eth namespace of the signer service.
+type EthAPI struct {
+	s *SignerService
+}
+
+// SignTransaction signs the EIP-1559 transaction of the arguments, and returns it RLP-encoded.
+func (api *EthAPI) SignTransaction(ctx context.Context, args client.TransactionArgs) (hexutil.Bytes, error) {
+	name := clientName(ctx)
+	signed, err := api.s.signTransaction(name, &args)
+
+	record := AuditRecord{
+		Time:    time.Now().UTC(),
+		Client:  name,
+		From:    args.From,
+		To:      args.To,
+		ChainID: args.ChainID,
+		Nonce:   args.Nonce,
+	}
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		hash := signed.Hash()
+		record.TxHash = &hash
+	}
+	if auditErr := api.s.audit.Record(record); auditErr != nil {
+		api.s.log.Error("failed to write audit log", "client", name, "err", auditErr)
+		return nil, errors.New("failed to write audit log")
+	}
+
+	if err != nil {
+		api.s.log.Warn("rejected transaction", "client", name, "from", args.From, "to", args.To, "err", err)
+		return nil, err
+	}
+	api.s.log.Info("signed transaction", "client", name, "from", args.From, "to", signed.To(), "nonce", signed.Nonce(), "chain_id", signed.ChainId(), "hash", signed.Hash())
+	return signed.MarshalBinary()
+}
+
+// checkTransactionArgs checks that the arguments have all fields of an EIP-1559 transaction.
+func checkTransactionArgs(args *client.TransactionArgs) error {
+	switch {
+	case args.From == nil:
+		return errors.New("missing from")
+	case args.ChainID == nil:
+		return errors.New("missing chainId")
+	case args.Nonce == nil:
+		return errors.New("missing nonce")
+	case args.Gas == nil:
+		return errors.New("missing gas")
+	case args.MaxFeePerGas == nil || args.MaxPriorityFeePerGas == nil:
+		return errors.New("missing maxFeePerGas or maxPriorityFeePerGas")
+	case args.Data != nil && args.Input != nil && string(*args.Data) != string(*args.Input):
+		return errors.New("both data and input are set and not equal")
+	}
+	return nil
+}
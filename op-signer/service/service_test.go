@@ -0,0 +1,142 @@
+package service_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+	"github.com/ethereum-optimism/optimism/op-signer/service"
+	"github.com/ethereum-optimism/optimism/op-signer/signertest"
+)
+
+// TestSignerService serves the signer service over TLS like the op-signer binary, and checks that
+// the client may only sign within its allowlists, and that every request is in the audit log.
+func TestSignerService(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	batcherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	proposerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	batcher := crypto.PubkeyToAddress(batcherKey.PublicKey)
+	proposer := crypto.PubkeyToAddress(proposerKey.PublicKey)
+	inbox := common.Address{0x42}
+
+	dir := t.TempDir()
+	serverTLS, clientTLS, err := signertest.GenerateTLS(dir)
+	require.NoError(t, err)
+	auditPath := filepath.Join(dir, "audit.log")
+	audit, err := service.OpenAuditLog(auditPath)
+	require.NoError(t, err)
+	defer audit.Close()
+	svc, err := service.NewSignerService(logger, []*ecdsa.PrivateKey{batcherKey, proposerKey}, []service.ClientConfig{{
+		Name:        signertest.ClientName,
+		Accounts:    []common.Address{batcher},
+		ToAddresses: []common.Address{inbox},
+		ChainIDs:    []uint64{900},
+	}}, audit)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Address{batcher, proposer}, svc.Accounts())
+
+	tlsConfig, cm, err := service.NewServerTLSConfig(logger, serverTLS)
+	require.NoError(t, err)
+	defer cm.Stop()
+	port := 10000 + rand.Intn(22768)
+	server := oprpc.NewServer("127.0.0.1", port, "test",
+		oprpc.WithLogger(logger),
+		oprpc.WithAPIs(svc.APIs()),
+		oprpc.WithTLSConfig(&oprpc.ServerTLSConfig{Config: tlsConfig, CLIConfig: &serverTLS}),
+	)
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop()
+	}()
+
+	signer, err := client.NewSignerClient(logger, fmt.Sprintf("https://127.0.0.1:%d", port), clientTLS)
+	require.NoError(t, err)
+	chainID := big.NewInt(900)
+	newTx := func(chainID *big.Int, to *common.Address) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     3,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(10),
+			Gas:       21_000,
+			To:        to,
+		})
+	}
+
+	signed, err := signer.SignTransaction(context.Background(), chainID, batcher, newTx(chainID, &inbox))
+	require.NoError(t, err)
+	_, err = signer.SignTransaction(context.Background(), chainID, proposer, newTx(chainID, &inbox))
+	require.ErrorContains(t, err, "not allowed to sign for account")
+	_, err = signer.SignTransaction(context.Background(), big.NewInt(901), batcher, newTx(big.NewInt(901), &inbox))
+	require.ErrorContains(t, err, "not allowed to sign for chain 901")
+	_, err = signer.SignTransaction(context.Background(), chainID, batcher, newTx(chainID, &common.Address{0x01}))
+	require.ErrorContains(t, err, "not allowed to send to")
+	_, err = signer.SignTransaction(context.Background(), chainID, batcher, newTx(chainID, nil))
+	require.ErrorContains(t, err, "not allowed to create contracts")
+
+	f, err := os.Open(auditPath)
+	require.NoError(t, err)
+	defer f.Close()
+	var records []service.AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record service.AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, 5)
+	require.Equal(t, signertest.ClientName, records[0].Client)
+	require.Equal(t, signed.Hash(), *records[0].TxHash)
+	require.Empty(t, records[0].Error)
+	for _, record := range records[1:] {
+		require.Equal(t, signertest.ClientName, record.Client)
+		require.Nil(t, record.TxHash)
+		require.NotEmpty(t, record.Error)
+	}
+}
+
+func TestNewSignerServiceChecksAccounts(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = service.NewSignerService(testlog.Logger(t, log.LvlInfo), []*ecdsa.PrivateKey{key}, []service.ClientConfig{
+		{Name: "batcher", Accounts: []common.Address{{0x01}}, ToAddresses: []common.Address{{0x42}}, ChainIDs: []uint64{900}},
+	}, service.NewAuditLog(io.Discard))
+	require.ErrorContains(t, err, "which has no key")
+}
+
+// TestNewSignerServiceFailsClosed checks that clients without to addresses or chain IDs are rejected,
+// unless they are explicitly unrestricted.
+func TestNewSignerServiceFailsClosed(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	newService := func(c service.ClientConfig) error {
+		c.Name, c.Accounts = "batcher", []common.Address{addr}
+		_, err := service.NewSignerService(testlog.Logger(t, log.LvlInfo), []*ecdsa.PrivateKey{key}, []service.ClientConfig{c}, service.NewAuditLog(io.Discard))
+		return err
+	}
+	require.ErrorContains(t, newService(service.ClientConfig{ChainIDs: []uint64{900}}), "has no to addresses")
+	require.ErrorContains(t, newService(service.ClientConfig{ToAddresses: []common.Address{{0x42}}}), "has no chain IDs")
+	require.NoError(t, newService(service.ClientConfig{ToAddresses: []common.Address{{0x42}}, ChainIDs: []uint64{900}}))
+	require.NoError(t, newService(service.ClientConfig{Unrestricted: true}))
+}
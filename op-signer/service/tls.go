@@ -0,0 +1,45 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
+)
+
+// NewServerTLSConfig returns the TLS config of a server that requires the clients to authenticate
+// with a certificate signed by the CA of cfg. The certificate of the server is reloaded by certman
+// when its files change, the caller must stop the returned certman.
+func NewServerTLSConfig(log log.Logger, cfg optls.CLIConfig) (*tls.Config, *certman.CertMan, error) {
+	caCert, err := os.ReadFile(cfg.TLSCaCert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tls ca: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caCert) {
+		return nil, nil, errors.New("no certificates in tls ca")
+	}
+	// certman only logs a certificate that fails to load, fail early instead
+	if _, err := tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to load tls cert and key: %w", err)
+	}
+	cm, err := certman.New(log, cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certman: %w", err)
+	}
+	if err := cm.Watch(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start certman watcher: %w", err)
+	}
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS13,
+		GetCertificate: cm.GetCertificate,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		ClientCAs:      clientCAs,
+	}, cm, nil
+}
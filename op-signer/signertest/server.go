@@ -0,0 +1,115 @@
+// Package signertest provides an in-process remote signer, to test the services that sign
+// their transactions with op-signer/client locally.
+package signertest
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+	"github.com/ethereum-optimism/optimism/op-signer/service"
+)
+
+// Server serves a signer service on a local port. The clients must authenticate with a
+// certificate of ClientName that is signed by the CA of the TLS config.
+type Server struct {
+	svc      *service.SignerService
+	addr     common.Address
+	rpc      *rpc.Server
+	http     *http.Server
+	certman  *certman.CertMan
+	listener net.Listener
+}
+
+// NewServer starts a signer server for the key. The TLS config has the CA of the client
+// certificates, and the certificate and key of the server.
+func NewServer(log log.Logger, key *ecdsa.PrivateKey, tlsConfig optls.CLIConfig) (*Server, error) {
+	if err := tlsConfig.Check(); err != nil {
+		return nil, err
+	}
+	if !tlsConfig.TLSEnabled() {
+		return nil, errors.New("the signer server requires tls")
+	}
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	svc, err := service.NewSignerService(log, []*ecdsa.PrivateKey{key}, []service.ClientConfig{
+		{Name: ClientName, Accounts: []common.Address{addr}, Unrestricted: true},
+	}, service.NewAuditLog(io.Discard))
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		svc:  svc,
+		addr: addr,
+		rpc:  rpc.NewServer(),
+	}
+	for _, api := range s.svc.APIs() {
+		if err := s.rpc.RegisterName(api.Namespace, api.Service); err != nil {
+			return nil, fmt.Errorf("failed to register %s API: %w", api.Namespace, err)
+		}
+	}
+	if err := s.rpc.RegisterName("health", &healthAPI{}); err != nil {
+		return nil, fmt.Errorf("failed to register health API: %w", err)
+	}
+	s.http = &http.Server{Handler: optls.NewPeerTLSMiddleware(s.rpc)}
+
+	cfg, cm, err := service.NewServerTLSConfig(log, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	s.certman = cm
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		cm.Stop()
+		return nil, err
+	}
+	s.listener = tls.NewListener(listener, cfg)
+	go func() {
+		if err := s.http.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("signer server failed", "err", err)
+		}
+	}()
+	return s, nil
+}
+
+type healthAPI struct{}
+
+func (healthAPI) Status() string {
+	return "signertest"
+}
+
+// Address returns the account that the server signs for.
+func (s *Server) Address() common.Address {
+	return s.addr
+}
+
+// Endpoint returns the URL of the server.
+func (s *Server) Endpoint() string {
+	return "https://" + s.listener.Addr().String()
+}
+
+// ClientConfig returns the config of a signer client that connects to the server with the given TLS config.
+func (s *Server) ClientConfig(tlsConfig optls.CLIConfig) client.CLIConfig {
+	return client.CLIConfig{
+		Endpoint:  s.Endpoint(),
+		Address:   s.Address().Hex(),
+		TLSConfig: tlsConfig,
+	}
+}
+
+func (s *Server) Close() error {
+	s.rpc.Stop()
+	s.certman.Stop()
+	return s.http.Close()
+}
@@ -0,0 +1,108 @@
+package signertest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+// ClientName is the common name of the client certificate of GenerateTLS, the only client of the server.
+const ClientName = "client"
+
+// GenerateTLS writes a CA, a certificate of the server for the local host, and a certificate
+// of a client to dir. It returns the TLS configs of the server and of the client, which both
+// trust the certificates signed by the CA.
+func GenerateTLS(dir string) (server optls.CLIConfig, client optls.CLIConfig, err error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return server, client, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "signertest CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return server, client, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return server, client, err
+	}
+	caPath := filepath.Join(dir, "ca.crt")
+	if err := writePEM(caPath, "CERTIFICATE", caDER); err != nil {
+		return server, client, err
+	}
+
+	server = optls.CLIConfig{
+		TLSCaCert: caPath,
+		TLSCert:   filepath.Join(dir, "server.crt"),
+		TLSKey:    filepath.Join(dir, "server.key"),
+	}
+	if err := writeCert(caCert, caKey, server, 2, "localhost", x509.ExtKeyUsageServerAuth); err != nil {
+		return server, client, err
+	}
+	client = optls.CLIConfig{
+		TLSCaCert: caPath,
+		TLSCert:   filepath.Join(dir, "client.crt"),
+		TLSKey:    filepath.Join(dir, "client.key"),
+	}
+	if err := writeCert(caCert, caKey, client, 3, ClientName, x509.ExtKeyUsageClientAuth); err != nil {
+		return server, client, err
+	}
+	return server, client, nil
+}
+
+// writeCert writes a certificate and key signed by the CA to the paths of the TLS config.
+func writeCert(ca *x509.Certificate, caKey *ecdsa.PrivateKey, cfg optls.CLIConfig, serial int64, name string, usage x509.ExtKeyUsage) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(cfg.TLSCert, "CERTIFICATE", der); err != nil {
+		return err
+	}
+	return writePEM(cfg.TLSKey, "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(path string, typ string, der []byte) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+/**
+ * @title DataStream
+ * @notice The DataStream contract is the L1 contract that sequencers propose L2 blocks to. The
+ *         derivation of an L2 chain reads the blocks from the calldata of the successful propose
+ *         calls, so the contract does not store the blocks. It only records the latest block number
+ *         that every sender proposed for every chain, and rejects proposals of the sender that would
+ *         leave a gap after it. Senders are tracked separately, so that proposals of other senders,
+ *         which the derivation ignores, cannot move the latest block of a batcher forward. Blocks may
+ *         be proposed again, which happens when a batcher resumes from the safe head.
+ *
+ *         The contract is not upgradeable and has no owner. Which proposals of a chain are
+ *         derived from is decided by the derivation, based on the sender of the transaction.
+ */
+contract DataStream {
+    /**
+     * @notice Latest proposed L2 block number of every chain, keyed by sender and L2 chain ID.
+     *         Zero if the sender did not propose a block of the chain yet.
+     */
+    mapping(address => mapping(uint32 => uint256)) public latestBlockNumber;
+
+    /**
+     * @notice Proposes a single L2 block. The hash of the block, which is checked against the
+     *         derived block, and the block payload, a version byte followed by the optionally
+     *         compressed batch of the block, are only read from the calldata.
+     *
+     * @param _chainId     L2 chain ID of the block.
+     * @param _blockNumber Number of the block.
+     */
+    function propose(
+        uint32 _chainId,
+        uint256 _blockNumber,
+        bytes32,
+        bytes calldata
+    ) external {
+        _record(_chainId, _blockNumber, 1);
+    }
+
+    /**
+     * @notice Proposes consecutive L2 blocks of a chain, starting at the given block number. The
+     *         block hashes and payloads are only read from the calldata, like the ones of propose.
+     *
+     * @param _chainId          L2 chain ID of the blocks.
+     * @param _firstBlockNumber Number of the first block.
+     * @param _blockHashes      Hashes of the blocks.
+     * @param _blocks           Payloads of the blocks.
+     */
+    function proposeRange(
+        uint32 _chainId,
+        uint256 _firstBlockNumber,
+        bytes32[] calldata _blockHashes,
+        bytes[] calldata _blocks
+    ) external {
+        require(_blocks.length > 0, "DataStream: empty range");
+        require(
+            _blockHashes.length == _blocks.length,
+            "DataStream: block hashes and blocks length mismatch"
+        );
+        _record(_chainId, _firstBlockNumber, _blocks.length);
+    }
+
+    /**
+     * @notice Records the proposal of `_count` blocks of a chain starting at `_first` by the
+     *         sender. The first block must not be after the block that follows the latest block
+     *         the sender proposed, unless the sender did not propose a block of the chain yet. The
+     *         last block must be below the maximum block number, so that it can be followed.
+     *
+     * @param _chainId L2 chain ID of the blocks.
+     * @param _first   Number of the first block.
+     * @param _count   Number of blocks.
+     */
+    function _record(
+        uint32 _chainId,
+        uint256 _first,
+        uint256 _count
+    ) internal {
+        require(_first <= type(uint256).max - _count, "DataStream: block number overflow");
+        uint256 latest = latestBlockNumber[msg.sender][_chainId];
+        require(latest == 0 || _first <= latest + 1, "DataStream: gap after latest block");
+        uint256 last = _first + _count - 1;
+        if (last > latest) {
+            latestBlockNumber[msg.sender][_chainId] = last;
+        }
+    }
+}
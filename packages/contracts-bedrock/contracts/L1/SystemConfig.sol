@@ -22,12 +22,15 @@ contract SystemConfig is OwnableUpgradeable, Semver {
      * @custom:value GAS_LIMIT            Represents an update to gas limit on L2.
      * @custom:value UNSAFE_BLOCK_SIGNER  Represents an update to the signer key for unsafe
      *                                    block distrubution.
+     * @custom:value PROPOSER             Represents a scheduled rotation of the proposer of the
+     *                                    data stream.
      */
     enum UpdateType {
         BATCHER,
         GAS_CONFIG,
         GAS_LIMIT,
-        UNSAFE_BLOCK_SIGNER
+        UNSAFE_BLOCK_SIGNER,
+        PROPOSER
     }
 
     /**
@@ -42,6 +45,23 @@ contract SystemConfig is OwnableUpgradeable, Semver {
      */
     bytes32 public constant UNSAFE_BLOCK_SIGNER_SLOT = keccak256("systemconfig.unsafeblocksigner");
 
+    /**
+     * @notice Storage slot of the current proposer of the data stream. Like the unsafe block
+     *         signer, the `op-node` uses a storage proof to fetch this value.
+     */
+    bytes32 public constant PROPOSER_SLOT = keccak256("systemconfig.proposer");
+
+    /**
+     * @notice Storage slot of the proposer that is scheduled to take over from the current one.
+     */
+    bytes32 public constant NEXT_PROPOSER_SLOT = keccak256("systemconfig.nextproposer");
+
+    /**
+     * @notice Storage slot of the L1 block number from which the next proposer takes over. Zero
+     *         if no rotation is scheduled.
+     */
+    bytes32 public constant NEXT_PROPOSER_BLOCK_SLOT = keccak256("systemconfig.nextproposerblock");
+
     /**
      * @notice Fixed L2 gas overhead. Used as part of the L2 fee calculation.
      */
@@ -80,7 +100,7 @@ contract SystemConfig is OwnableUpgradeable, Semver {
     event ConfigUpdate(uint256 indexed version, UpdateType indexed updateType, bytes data);
 
     /**
-     * @custom:semver 1.3.0
+     * @custom:semver 1.4.0
      *
      * @param _owner             Initial owner of the contract.
      * @param _overhead          Initial overhead value.
@@ -98,7 +118,7 @@ contract SystemConfig is OwnableUpgradeable, Semver {
         uint64 _gasLimit,
         address _unsafeBlockSigner,
         ResourceMetering.ResourceConfig memory _config
-    ) Semver(1, 3, 0) {
+    ) Semver(1, 4, 0) {
         initialize({
             _owner: _owner,
             _overhead: _overhead,
@@ -184,6 +204,48 @@ contract SystemConfig is OwnableUpgradeable, Semver {
         emit ConfigUpdate(VERSION, UpdateType.UNSAFE_BLOCK_SIGNER, data);
     }
 
+    /**
+     * @notice High level getter for the proposer of the data stream at the current L1 block.
+     *         Only the propose calls of this address are derived. If it is the zero address,
+     *         the batcher proposes.
+     *
+     * @return Address of the proposer.
+     */
+    function proposer() external view returns (address) {
+        (address current, address next, uint256 nextBlock) = _proposerSchedule();
+        if (nextBlock != 0 && block.number >= nextBlock) {
+            return next;
+        }
+        return current;
+    }
+
+    /**
+     * @notice Schedules a new proposer of the data stream, which takes over from the L1 block
+     *         `_startBlock` on. It replaces a rotation that is scheduled but not started yet.
+     *
+     * @param _proposer   New proposer address, or the zero address to let the batcher propose.
+     * @param _startBlock L1 block number from which the new proposer takes over.
+     */
+    function setProposer(address _proposer, uint64 _startBlock) external onlyOwner {
+        require(_startBlock > block.number, "SystemConfig: proposer must start in a future block");
+
+        (address current, address next, uint256 nextBlock) = _proposerSchedule();
+        if (nextBlock != 0 && block.number >= nextBlock) {
+            current = next;
+        }
+        bytes32 proposerSlot = PROPOSER_SLOT;
+        bytes32 nextSlot = NEXT_PROPOSER_SLOT;
+        bytes32 nextBlockSlot = NEXT_PROPOSER_BLOCK_SLOT;
+        assembly {
+            sstore(proposerSlot, current)
+            sstore(nextSlot, _proposer)
+            sstore(nextBlockSlot, _startBlock)
+        }
+
+        bytes memory data = abi.encode(_proposer, _startBlock);
+        emit ConfigUpdate(VERSION, UpdateType.PROPOSER, data);
+    }
+
     /**
      * @notice Updates the batcher hash.
      *
@@ -236,6 +298,26 @@ contract SystemConfig is OwnableUpgradeable, Semver {
         }
     }
 
+    /**
+     * @notice Low level getter for the proposer schedule.
+     *
+     * @return The current proposer, the next proposer and the L1 block it takes over from.
+     */
+    function _proposerSchedule() internal view returns (address, address, uint256) {
+        address current;
+        address next;
+        uint256 nextBlock;
+        bytes32 proposerSlot = PROPOSER_SLOT;
+        bytes32 nextSlot = NEXT_PROPOSER_SLOT;
+        bytes32 nextBlockSlot = NEXT_PROPOSER_BLOCK_SLOT;
+        assembly {
+            current := sload(proposerSlot)
+            next := sload(nextSlot)
+            nextBlock := sload(nextBlockSlot)
+        }
+        return (current, next, nextBlock);
+    }
+
     /**
      * @notice A getter for the resource config. Ensures that the struct is
      *         returned instead of a tuple.
@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+import { CommonTest } from "./CommonTest.t.sol";
+import { DataStream } from "../L1/DataStream.sol";
+
+contract DataStream_Init is CommonTest {
+    DataStream stream;
+
+    uint32 constant CHAIN_ID = 901;
+
+    function setUp() public virtual override {
+        super.setUp();
+        stream = new DataStream();
+    }
+
+    function _range(uint256 _count)
+        internal
+        pure
+        returns (bytes32[] memory hashes_, bytes[] memory blocks_)
+    {
+        hashes_ = new bytes32[](_count);
+        blocks_ = new bytes[](_count);
+        for (uint256 i = 0; i < _count; i++) {
+            hashes_[i] = keccak256(abi.encode(i));
+            blocks_[i] = hex"00c0";
+        }
+    }
+}
+
+contract DataStream_Propose_Test is DataStream_Init {
+    function test_propose_firstBlock_succeeds() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 10);
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID + 1), 0);
+    }
+
+    function test_propose_nextBlock_succeeds() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        stream.propose(CHAIN_ID, 11, bytes32(hex"02"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 11);
+    }
+
+    function test_propose_again_succeeds() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        stream.propose(CHAIN_ID, 11, bytes32(hex"02"), hex"00c0");
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 11);
+    }
+
+    function test_propose_otherChain_succeeds() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        stream.propose(CHAIN_ID + 1, 100, bytes32(hex"02"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 10);
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID + 1), 100);
+    }
+
+    function test_proposeRange_succeeds() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        (bytes32[] memory hashes, bytes[] memory blocks) = _range(3);
+        stream.proposeRange(CHAIN_ID, 11, hashes, blocks);
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 13);
+
+        // a range that overlaps the proposed blocks only moves the latest block forward
+        stream.proposeRange(CHAIN_ID, 9, hashes, blocks);
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 13);
+    }
+
+    function test_propose_otherSender_succeeds() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        vm.prank(alice);
+        stream.propose(CHAIN_ID, 1000, bytes32(hex"02"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 10);
+        assertEq(stream.latestBlockNumber(alice, CHAIN_ID), 1000);
+
+        // the proposals of another sender do not lift the gap check
+        vm.expectRevert("DataStream: gap after latest block");
+        stream.propose(CHAIN_ID, 12, bytes32(hex"03"), hex"00c0");
+        stream.propose(CHAIN_ID, 11, bytes32(hex"03"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 11);
+    }
+
+    function test_propose_lastBlockNumber_succeeds() external {
+        stream.propose(CHAIN_ID, type(uint256).max - 1, bytes32(hex"01"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), type(uint256).max - 1);
+    }
+}
+
+contract DataStream_Propose_TestFail is DataStream_Init {
+    function test_propose_gap_reverts() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        vm.expectRevert("DataStream: gap after latest block");
+        stream.propose(CHAIN_ID, 12, bytes32(hex"02"), hex"00c0");
+    }
+
+    function test_propose_overflow_reverts() external {
+        vm.expectRevert("DataStream: block number overflow");
+        stream.propose(CHAIN_ID, type(uint256).max, bytes32(hex"01"), hex"00c0");
+
+        // the chain can still be proposed to
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 10);
+    }
+
+    function test_proposeRange_overflow_reverts() external {
+        (bytes32[] memory hashes, bytes[] memory blocks) = _range(3);
+        vm.expectRevert("DataStream: block number overflow");
+        stream.proposeRange(CHAIN_ID, type(uint256).max - 2, hashes, blocks);
+        assertEq(stream.latestBlockNumber(address(this), CHAIN_ID), 0);
+    }
+
+    function test_propose_value_reverts() external {
+        (bool success, ) = address(stream).call{ value: 1 }(
+            abi.encodeWithSelector(
+                DataStream.propose.selector,
+                CHAIN_ID,
+                10,
+                bytes32(hex"01"),
+                hex"00c0"
+            )
+        );
+        assertFalse(success);
+    }
+
+    function test_propose_malformed_reverts() external {
+        (bool success, ) = address(stream).call(
+            abi.encodeWithSelector(DataStream.propose.selector, CHAIN_ID, 10)
+        );
+        assertFalse(success);
+    }
+
+    function test_proposeRange_gap_reverts() external {
+        stream.propose(CHAIN_ID, 10, bytes32(hex"01"), hex"00c0");
+        (bytes32[] memory hashes, bytes[] memory blocks) = _range(2);
+        vm.expectRevert("DataStream: gap after latest block");
+        stream.proposeRange(CHAIN_ID, 12, hashes, blocks);
+    }
+
+    function test_proposeRange_empty_reverts() external {
+        (bytes32[] memory hashes, bytes[] memory blocks) = _range(0);
+        vm.expectRevert("DataStream: empty range");
+        stream.proposeRange(CHAIN_ID, 10, hashes, blocks);
+    }
+
+    function test_proposeRange_lengthMismatch_reverts() external {
+        (bytes32[] memory hashes, ) = _range(2);
+        (, bytes[] memory blocks) = _range(3);
+        vm.expectRevert("DataStream: block hashes and blocks length mismatch");
+        stream.proposeRange(CHAIN_ID, 10, hashes, blocks);
+    }
+}
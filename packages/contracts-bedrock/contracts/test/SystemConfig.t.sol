@@ -80,6 +80,17 @@ contract SystemConfig_Setters_TestFail is SystemConfig_Init {
         sysConf.setUnsafeBlockSigner(address(0x20));
     }
 
+    function test_setProposer_notOwner_reverts() external {
+        vm.expectRevert("Ownable: caller is not the owner");
+        sysConf.setProposer(address(0x20), uint64(block.number + 1));
+    }
+
+    function test_setProposer_notFutureBlock_reverts() external {
+        vm.prank(sysConf.owner());
+        vm.expectRevert("SystemConfig: proposer must start in a future block");
+        sysConf.setProposer(address(0x20), uint64(block.number));
+    }
+
     function test_setResourceConfig_notOwner_reverts() external {
         ResourceMetering.ResourceConfig memory config = Constants.DEFAULT_RESOURCE_CONFIG();
         vm.expectRevert("Ownable: caller is not the owner");
@@ -201,4 +212,40 @@ contract SystemConfig_Setters_Test is SystemConfig_Init {
         sysConf.setUnsafeBlockSigner(newUnsafeSigner);
         assertEq(sysConf.unsafeBlockSigner(), newUnsafeSigner);
     }
+
+    function testFuzz_setProposer_succeeds(address newProposer, uint64 startBlock) external {
+        startBlock = uint64(bound(uint256(startBlock), block.number + 1, type(uint64).max));
+
+        vm.expectEmit(true, true, true, true);
+        emit ConfigUpdate(0, SystemConfig.UpdateType.PROPOSER, abi.encode(newProposer, startBlock));
+
+        vm.prank(sysConf.owner());
+        sysConf.setProposer(newProposer, startBlock);
+        assertEq(sysConf.proposer(), address(0));
+
+        vm.roll(startBlock);
+        assertEq(sysConf.proposer(), newProposer);
+    }
+
+    function test_setProposer_rotation_succeeds() external {
+        vm.startPrank(sysConf.owner());
+        sysConf.setProposer(address(0x20), uint64(block.number + 10));
+        // a rotation that did not start yet is replaced
+        sysConf.setProposer(address(0x21), uint64(block.number + 5));
+
+        vm.roll(block.number + 5);
+        assertEq(sysConf.proposer(), address(0x21));
+
+        // the started rotation becomes the current proposer when the next one is scheduled
+        sysConf.setProposer(address(0x22), uint64(block.number + 5));
+        assertEq(sysConf.proposer(), address(0x21));
+        assertEq(
+            address(uint160(uint256(vm.load(address(sysConf), sysConf.PROPOSER_SLOT())))),
+            address(0x21)
+        );
+
+        vm.roll(block.number + 5);
+        assertEq(sysConf.proposer(), address(0x22));
+        vm.stopPrank();
+    }
 }